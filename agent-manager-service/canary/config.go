@@ -0,0 +1,78 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package canary runs a synthetic end-to-end check alongside the real
+// monitor runner: it periodically pushes a trace with a known expected
+// outcome, then waits for the evaluator pipeline to score it, so a broken
+// pipeline shows up as a liveness metric instead of only a unit test
+// failure. It depends on repositories.ScoreRepo and the monitor/evaluator
+// domain models, neither of which exist in this checkout yet; Runner takes
+// a local ScoreRepo interface shaped to match the eventual real one so this
+// package can be wired up once that repository lands.
+package canary
+
+import "time"
+
+// Config configures one canary loop targeting a single monitor/evaluator
+// pair.
+type Config struct {
+	// MonitorID and EvaluatorName identify which monitor/evaluator the
+	// synthetic trace should be scored against.
+	MonitorID     string
+	EvaluatorName string
+
+	// PushURL is where synthetic traces are submitted, and Tenant scopes
+	// them so canary volume can be excluded from real aggregates.
+	PushURL string
+	Tenant  string
+
+	// RetentionWindow is how long a pushed canary trace is kept around.
+	// Runner does not read it: a push is reported as not found once
+	// ScoreSLO elapses.
+	RetentionWindow time.Duration
+
+	// MinInterval and MaxInterval bound the jittered delay between pushes,
+	// so canary traffic doesn't land on a predictable cadence.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// ScoreSLO is how long the runner waits for a Score row to appear
+	// after a push before recording it as not found.
+	ScoreSLO time.Duration
+
+	// ExpectedScoreMin and ExpectedScoreMax bound the score the evaluator
+	// is expected to produce for the synthetic trace's known input/output.
+	ExpectedScoreMin float64
+	ExpectedScoreMax float64
+
+	// WriteBackoff and ReadBackoff are the base backoff durations used
+	// after a failed push and a failed score lookup respectively; they are
+	// tracked separately since a flaky push path and a flaky score path
+	// point at different failures.
+	WriteBackoff time.Duration
+	ReadBackoff  time.Duration
+}
+
+// nextInterval returns a pseudo-random duration in [MinInterval,
+// MaxInterval), using r as the source of randomness so callers control
+// determinism (and tests don't depend on the disallowed global rand seed).
+func (c Config) nextInterval(r interface{ Int63n(n int64) int64 }) time.Duration {
+	if c.MaxInterval <= c.MinInterval {
+		return c.MinInterval
+	}
+	spread := int64(c.MaxInterval - c.MinInterval)
+	return c.MinInterval + time.Duration(r.Int63n(spread))
+}
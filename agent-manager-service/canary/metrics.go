@@ -0,0 +1,88 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package canary
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics tracks the liveness counters operators use to tell "the canary
+// itself is broken" apart from "the evaluator pipeline is broken": a
+// healthy runner has TracesFound tracking TracesWritten closely, with low
+// SearchLatency; a rising TracesNotFound or LongestWriteBackoff points at
+// the pipeline instead. The zero value is ready to use.
+type Metrics struct {
+	tracesWritten         uint64
+	tracesFound           uint64
+	tracesNotFound        uint64
+	longestWriteBackoffNs int64
+	lastSearchLatencyNs   int64
+}
+
+// RecordWrite increments the written-trace counter.
+func (m *Metrics) RecordWrite() {
+	atomic.AddUint64(&m.tracesWritten, 1)
+}
+
+// RecordFound increments the found-score counter and records the latency
+// between the push and the score appearing.
+func (m *Metrics) RecordFound(searchLatency time.Duration) {
+	atomic.AddUint64(&m.tracesFound, 1)
+	atomic.StoreInt64(&m.lastSearchLatencyNs, int64(searchLatency))
+}
+
+// RecordNotFound increments the not-found counter, for when ScoreSLO
+// elapses after a canary push with no matching Score row.
+func (m *Metrics) RecordNotFound() {
+	atomic.AddUint64(&m.tracesNotFound, 1)
+}
+
+// RecordWriteBackoff widens LongestWriteBackoff if backoff exceeds it.
+func (m *Metrics) RecordWriteBackoff(backoff time.Duration) {
+	for {
+		current := atomic.LoadInt64(&m.longestWriteBackoffNs)
+		if int64(backoff) <= current {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&m.longestWriteBackoffNs, current, int64(backoff)) {
+			return
+		}
+	}
+}
+
+// TracesWritten returns the number of synthetic traces pushed so far.
+func (m *Metrics) TracesWritten() uint64 { return atomic.LoadUint64(&m.tracesWritten) }
+
+// TracesFound returns the number of pushed traces whose Score row was
+// found within ScoreSLO.
+func (m *Metrics) TracesFound() uint64 { return atomic.LoadUint64(&m.tracesFound) }
+
+// TracesNotFound returns the number of pushed traces that had no matching
+// Score row once ScoreSLO elapsed.
+func (m *Metrics) TracesNotFound() uint64 { return atomic.LoadUint64(&m.tracesNotFound) }
+
+// LongestWriteBackoff returns the longest backoff the runner has had to
+// wait through on the write (push) path.
+func (m *Metrics) LongestWriteBackoff() time.Duration {
+	return time.Duration(atomic.LoadInt64(&m.longestWriteBackoffNs))
+}
+
+// SearchLatency returns the most recently observed push-to-score latency.
+func (m *Metrics) SearchLatency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&m.lastSearchLatencyNs))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package canary
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetricsRecordWriteAndFound(t *testing.T) {
+	m := &Metrics{}
+	m.RecordWrite()
+	m.RecordWrite()
+	m.RecordFound(50 * time.Millisecond)
+	m.RecordNotFound()
+
+	if got := m.TracesWritten(); got != 2 {
+		t.Errorf("TracesWritten() = %d, want 2", got)
+	}
+	if got := m.TracesFound(); got != 1 {
+		t.Errorf("TracesFound() = %d, want 1", got)
+	}
+	if got := m.TracesNotFound(); got != 1 {
+		t.Errorf("TracesNotFound() = %d, want 1", got)
+	}
+	if got := m.SearchLatency(); got != 50*time.Millisecond {
+		t.Errorf("SearchLatency() = %v, want 50ms", got)
+	}
+}
+
+func TestMetricsRecordWriteBackoffKeepsMax(t *testing.T) {
+	m := &Metrics{}
+	m.RecordWriteBackoff(2 * time.Second)
+	m.RecordWriteBackoff(1 * time.Second)
+	m.RecordWriteBackoff(5 * time.Second)
+
+	if got := m.LongestWriteBackoff(); got != 5*time.Second {
+		t.Errorf("LongestWriteBackoff() = %v, want 5s", got)
+	}
+}
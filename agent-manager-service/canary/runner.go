@@ -0,0 +1,182 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package canary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScoreLookup is the slice of repositories.ScoreRepo the canary runner
+// needs: find the Score row produced for a pushed trace, if any exists
+// yet. It is declared locally, rather than depending on
+// repositories.ScoreRepo directly, because that repository does not exist
+// in this checkout; a real ScoreRepo is expected to satisfy this interface
+// unchanged.
+type ScoreLookup interface {
+	FindScoreByTraceID(ctx context.Context, monitorID, evaluatorName, traceID string) (score float64, found bool, err error)
+}
+
+// syntheticTrace is the known-input/known-output payload pushed to
+// PushURL; its TraceID is what the runner later looks up via ScoreLookup.
+type syntheticTrace struct {
+	TraceID   string    `json:"traceId"`
+	Tenant    string    `json:"tenant"`
+	MonitorID string    `json:"monitorId"`
+	Input     string    `json:"input"`
+	Output    string    `json:"output"`
+	PushedAt  time.Time `json:"pushedAt"`
+}
+
+// Runner periodically pushes a synthetic trace and asserts the evaluator
+// pipeline scores it within Config.ScoreSLO and Config.ExpectedScoreMin/Max,
+// recording liveness metrics along the way.
+type Runner struct {
+	cfg     Config
+	scores  ScoreLookup
+	httpc   *http.Client
+	logger  *slog.Logger
+	metrics *Metrics
+}
+
+// NewRunner returns a Runner that pushes synthetic traces over HTTP to
+// cfg.PushURL and looks up their resulting Score via scores.
+func NewRunner(cfg Config, scores ScoreLookup, logger *slog.Logger) *Runner {
+	return &Runner{
+		cfg:     cfg,
+		scores:  scores,
+		httpc:   &http.Client{Timeout: 10 * time.Second},
+		logger:  logger,
+		metrics: &Metrics{},
+	}
+}
+
+// Metrics returns the runner's liveness counters.
+func (r *Runner) Metrics() *Metrics { return r.metrics }
+
+// Run pushes synthetic traces in a loop, at a jittered interval, until ctx
+// is cancelled. Each push is awaited to completion (push, then poll for
+// score) before the next jittered sleep begins.
+func (r *Runner) Run(ctx context.Context) {
+	for {
+		r.runOnce(ctx)
+
+		interval := r.cfg.nextInterval(rand.New(rand.NewSource(time.Now().UnixNano())))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// runOnce pushes one synthetic trace (retrying with WriteBackoff on
+// failure) and then polls for its Score (retrying with ReadBackoff) until
+// ScoreSLO elapses.
+func (r *Runner) runOnce(ctx context.Context) {
+	trace := syntheticTrace{
+		TraceID:   uuid.New().String(),
+		Tenant:    r.cfg.Tenant,
+		MonitorID: r.cfg.MonitorID,
+		Input:     "canary-input",
+		Output:    "canary-output",
+		PushedAt:  time.Now(),
+	}
+
+	if err := r.push(ctx, trace); err != nil {
+		r.logger.Warn("canary push failed", "monitorId", r.cfg.MonitorID, "err", err)
+		return
+	}
+	r.metrics.RecordWrite()
+
+	r.awaitScore(ctx, trace)
+}
+
+// push submits trace to PushURL, backing off and retrying on failure until
+// the push succeeds or ctx is done.
+func (r *Runner) push(ctx context.Context, trace syntheticTrace) error {
+	backoff := r.cfg.WriteBackoff
+	for {
+		body, err := json.Marshal(trace)
+		if err != nil {
+			return fmt.Errorf("failed to marshal synthetic trace: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.cfg.PushURL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to build canary push request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := r.httpc.Do(req)
+		if err == nil && resp.StatusCode < 300 {
+			_ = resp.Body.Close()
+			return nil
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		r.metrics.RecordWriteBackoff(backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// awaitScore polls ScoreLookup at ReadBackoff intervals until the score
+// appears, ScoreSLO elapses, or ctx is done, recording the outcome.
+func (r *Runner) awaitScore(ctx context.Context, trace syntheticTrace) {
+	deadline := trace.PushedAt.Add(r.cfg.ScoreSLO)
+
+	for {
+		score, found, err := r.scores.FindScoreByTraceID(ctx, r.cfg.MonitorID, r.cfg.EvaluatorName, trace.TraceID)
+		if err != nil {
+			r.logger.Warn("canary score lookup failed", "traceId", trace.TraceID, "err", err)
+		} else if found {
+			r.metrics.RecordFound(time.Since(trace.PushedAt))
+			if score < r.cfg.ExpectedScoreMin || score > r.cfg.ExpectedScoreMax {
+				r.logger.Error("canary score out of expected range",
+					"traceId", trace.TraceID, "score", score,
+					"expectedMin", r.cfg.ExpectedScoreMin, "expectedMax", r.cfg.ExpectedScoreMax)
+			}
+			return
+		}
+
+		if time.Now().After(deadline) {
+			r.metrics.RecordNotFound()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.cfg.ReadBackoff):
+		}
+	}
+}
@@ -28,17 +28,23 @@ import (
 // Using the DNS namespace UUID as a stable, well-known base.
 var catalogNamespace = uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
 
-// Entry is a builtin evaluator catalog entry
+// Entry is an evaluator catalog entry, either compiled into the binary or
+// seeded at startup from an overlay (see LoadOverlay).
 type Entry struct {
-	Identifier   string
-	DisplayName  string
-	Description  string
-	Version      string
-	Provider     string
-	ClassName    string
-	Level        string // "trace", "agent", or "llm"
-	Tags         []string
-	ConfigSchema []models.EvaluatorConfigParam
+	Identifier   string                        `yaml:"identifier"`
+	DisplayName  string                        `yaml:"displayName"`
+	Description  string                        `yaml:"description"`
+	Version      string                        `yaml:"version"`
+	Provider     string                        `yaml:"provider"`
+	ClassName    string                        `yaml:"className"`
+	Level        string                        `yaml:"level"` // "trace", "agent", or "llm"
+	Tags         []string                      `yaml:"tags"`
+	ConfigSchema []models.EvaluatorConfigParam `yaml:"configSchema"`
+
+	// Source is "builtin" for entries compiled into the binary, or
+	// "overlay:<path>" for one merged in by LoadOverlay from the named
+	// overlay file.
+	Source string `yaml:"-"`
 }
 
 // ID returns a deterministic UUID derived from the evaluator identifier.
@@ -118,19 +124,26 @@ func floatPtr(v float64) *float64 {
 // EnvVar is the environment variable the platform must set on the evaluation job process;
 // LiteLLM reads these natively so no evaluator code changes are needed.
 type LLMConfigField struct {
-	Key       string
-	Label     string
-	FieldType string // "password" | "text"
-	Required  bool
-	EnvVar    string
+	Key       string `yaml:"key"`
+	Label     string `yaml:"label"`
+	FieldType string `yaml:"fieldType"` // "password" | "text"
+	Required  bool   `yaml:"required"`
+	EnvVar    string `yaml:"envVar"`
 }
 
-// LLMProviderEntry is a builtin LLM provider catalog entry generated from the Python library.
+// LLMProviderEntry is an LLM provider catalog entry, either generated from
+// the Python library at build time or seeded at startup from an overlay
+// (see LoadOverlay).
 type LLMProviderEntry struct {
-	Name         string
-	DisplayName  string
-	ConfigFields []LLMConfigField
-	Models       []string // curated model names in provider/model format
+	Name         string           `yaml:"name"`
+	DisplayName  string           `yaml:"displayName"`
+	ConfigFields []LLMConfigField `yaml:"configFields"`
+	Models       []string         `yaml:"models"` // curated model names in provider/model format
+
+	// Source is "builtin" for entries generated at build time, or
+	// "overlay:<path>" for one merged in by LoadOverlay from the named
+	// overlay file.
+	Source string `yaml:"-"`
 }
 
 // AllProviders returns all builtin LLM provider entries.
@@ -0,0 +1,224 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envVarPattern matches a POSIX-shell-safe environment variable name, the
+// form every EnvVar must take since it's set directly on the evaluation
+// job process environment.
+var envVarPattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// OverlayDocument is the shape of one overlay YAML file: a ConfigMap-like
+// set of evaluator and LLM provider catalog entries an operator injects or
+// overrides at startup, for air-gapped or restricted environments where
+// rebuilding the binary to add a catalog entry isn't an option.
+type OverlayDocument struct {
+	Evaluators []Entry            `yaml:"evaluators"`
+	Providers  []LLMProviderEntry `yaml:"providers"`
+}
+
+// ValidateOverlay checks doc for the invariants LoadOverlay relies on:
+// every evaluator/provider identifier is non-empty and unique within doc,
+// every evaluator has a non-empty ConfigSchema, and every EnvVar (on
+// evaluator config params and provider config fields alike) is a valid
+// environment variable name. It collects every violation instead of
+// stopping at the first, so the `catalog validate` CLI subcommand can
+// report a complete list in one pass.
+func ValidateOverlay(doc OverlayDocument) []error {
+	var errs []error
+
+	seenEvaluators := make(map[string]bool, len(doc.Evaluators))
+	for _, e := range doc.Evaluators {
+		if e.Identifier == "" {
+			errs = append(errs, fmt.Errorf("evaluator entry has an empty identifier"))
+			continue
+		}
+		if seenEvaluators[e.Identifier] {
+			errs = append(errs, fmt.Errorf("duplicate evaluator identifier %q", e.Identifier))
+		}
+		seenEvaluators[e.Identifier] = true
+
+		if len(e.ConfigSchema) == 0 {
+			errs = append(errs, fmt.Errorf("evaluator %q: ConfigSchema must not be empty", e.Identifier))
+		}
+		for _, param := range e.ConfigSchema {
+			if param.EnvVar != "" && !envVarPattern.MatchString(param.EnvVar) {
+				errs = append(errs, fmt.Errorf("evaluator %q: invalid EnvVar %q", e.Identifier, param.EnvVar))
+			}
+		}
+	}
+
+	seenProviders := make(map[string]bool, len(doc.Providers))
+	for _, p := range doc.Providers {
+		if p.Name == "" {
+			errs = append(errs, fmt.Errorf("provider entry has an empty name"))
+			continue
+		}
+		if seenProviders[p.Name] {
+			errs = append(errs, fmt.Errorf("duplicate provider name %q", p.Name))
+		}
+		seenProviders[p.Name] = true
+
+		for _, field := range p.ConfigFields {
+			if field.EnvVar != "" && !envVarPattern.MatchString(field.EnvVar) {
+				errs = append(errs, fmt.Errorf("provider %q: invalid EnvVar %q for field %q", p.Name, field.EnvVar, field.Key))
+			}
+		}
+	}
+
+	return errs
+}
+
+// LoadOverlay reads every *.yaml/*.yml file under fsys, validates it with
+// ValidateOverlay, and merges its entries into the package-level
+// entries/llmProviderEntries catalogs. An evaluator or provider whose
+// identifier/name matches an existing entry replaces it; anything new is
+// appended. Every merged entry's Source is set to "overlay:<path>" so
+// callers (and the UI, via List/AllProviders) can tell a seeded override
+// from a builtin.
+//
+// Overlay-defined evaluator IDs still flow through Entry.ID(), which
+// derives solely from Identifier, so an overlay entry's ID stays
+// deterministic across restarts just like a builtin one.
+func LoadOverlay(fsys fs.FS) error {
+	return walkOverlayFiles(fsys, func(path string, doc OverlayDocument) error {
+		source := "overlay:" + path
+		mergeEvaluators(doc.Evaluators, source)
+		mergeProviders(doc.Providers, source)
+		return nil
+	})
+}
+
+// ValidateOverlayDir runs ValidateOverlay over every overlay file under
+// fsys without merging anything into the catalog, so the `agent-manager
+// catalog validate` CLI subcommand can check an overlay directory offline,
+// the same way LoadOverlay would at process start.
+func ValidateOverlayDir(fsys fs.FS) error {
+	return walkOverlayFiles(fsys, func(path string, doc OverlayDocument) error {
+		return nil
+	})
+}
+
+// walkOverlayFiles reads and parses every *.yaml/*.yml file under fsys in
+// sorted order, validates each with ValidateOverlay, and hands the parsed
+// document to apply. apply is only called once a file passes validation.
+func walkOverlayFiles(fsys fs.FS, apply func(path string, doc OverlayDocument) error) error {
+	paths, err := overlayFilePaths(fsys)
+	if err != nil {
+		return fmt.Errorf("failed to list overlay files: %w", err)
+	}
+
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("failed to read overlay %s: %w", path, err)
+		}
+
+		var doc OverlayDocument
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to parse overlay %s: %w", path, err)
+		}
+
+		if errs := ValidateOverlay(doc); len(errs) > 0 {
+			return fmt.Errorf("overlay %s is invalid: %w", path, joinErrors(errs))
+		}
+
+		if err := apply(path, doc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// overlayFilePaths returns every .yaml/.yml file under fsys, sorted, so
+// LoadOverlay applies overlays in a stable, predictable order.
+func overlayFilePaths(fsys fs.FS) ([]string, error) {
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := fileExt(path); ext == ".yaml" || ext == ".yml" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func fileExt(path string) string {
+	for i := len(path) - 1; i >= 0 && path[i] != '/'; i-- {
+		if path[i] == '.' {
+			return path[i:]
+		}
+	}
+	return ""
+}
+
+func mergeEvaluators(overlay []Entry, source string) {
+	for i := range overlay {
+		e := overlay[i]
+		e.Source = source
+
+		if existing := Get(e.Identifier); existing != nil {
+			*existing = e
+			continue
+		}
+		entries = append(entries, &e)
+	}
+}
+
+func mergeProviders(overlay []LLMProviderEntry, source string) {
+	for i := range overlay {
+		p := overlay[i]
+		p.Source = source
+
+		if existing := GetProvider(p.Name); existing != nil {
+			*existing = p
+			continue
+		}
+		llmProviderEntries = append(llmProviderEntries, &p)
+	}
+}
+
+func joinErrors(errs []error) error {
+	msg := ""
+	for i, err := range errs {
+		if i > 0 {
+			msg += "; "
+		}
+		msg += err.Error()
+	}
+	return fmt.Errorf("%s", msg)
+}
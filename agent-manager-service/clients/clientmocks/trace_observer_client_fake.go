@@ -20,6 +20,9 @@ import (
 	"context"
 	"sync"
 
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
 	traceobserver "github.com/wso2/ai-agent-management-platform/agent-manager-service/clients/trace_observer"
 )
 
@@ -42,6 +45,24 @@ type TraceObserverClientMock struct {
 		Ctx    context.Context
 		Params traceobserver.TraceDetailsByIdParams
 	}
+
+	// ExportTraces
+	ExportTracesFunc  func(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error)
+	exportTracesMutex sync.RWMutex
+	exportTracesCalls []struct {
+		Ctx context.Context
+		Req *coltracepb.ExportTraceServiceRequest
+	}
+
+	// WatchTraces: WatchTracesFunc returns the channel the mock streams
+	// ResourceSpans batches from, letting tests script a replay by sending
+	// on (and then closing) a channel they control.
+	WatchTracesFunc  func(ctx context.Context, filter traceobserver.WatchTracesFilter) (<-chan *tracepb.ResourceSpans, error)
+	watchTracesMutex sync.RWMutex
+	watchTracesCalls []struct {
+		Ctx    context.Context
+		Filter traceobserver.WatchTracesFilter
+	}
 }
 
 func (m *TraceObserverClientMock) ListTraces(ctx context.Context, params traceobserver.ListTracesParams) (*traceobserver.TraceOverviewResponse, error) {
@@ -97,3 +118,63 @@ func (m *TraceObserverClientMock) TraceDetailsByIdCalls() []struct {
 	defer m.traceDetailsByIdMutex.RUnlock()
 	return m.traceDetailsByIdCalls
 }
+
+func (m *TraceObserverClientMock) ExportTraces(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	m.exportTracesMutex.Lock()
+	m.exportTracesCalls = append(m.exportTracesCalls, struct {
+		Ctx context.Context
+		Req *coltracepb.ExportTraceServiceRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	})
+	m.exportTracesMutex.Unlock()
+
+	if m.ExportTracesFunc != nil {
+		return m.ExportTracesFunc(ctx, req)
+	}
+
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (m *TraceObserverClientMock) ExportTracesCalls() []struct {
+	Ctx context.Context
+	Req *coltracepb.ExportTraceServiceRequest
+} {
+	m.exportTracesMutex.RLock()
+	defer m.exportTracesMutex.RUnlock()
+	return m.exportTracesCalls
+}
+
+// WatchTraces records the call and defers to WatchTracesFunc for the
+// channel a test wants to replay from. Tests that want to assert the
+// subscriber drains the channel fully should close it once their scripted
+// batches have been sent.
+func (m *TraceObserverClientMock) WatchTraces(ctx context.Context, filter traceobserver.WatchTracesFilter) (<-chan *tracepb.ResourceSpans, error) {
+	m.watchTracesMutex.Lock()
+	m.watchTracesCalls = append(m.watchTracesCalls, struct {
+		Ctx    context.Context
+		Filter traceobserver.WatchTracesFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	})
+	m.watchTracesMutex.Unlock()
+
+	if m.WatchTracesFunc != nil {
+		return m.WatchTracesFunc(ctx, filter)
+	}
+
+	ch := make(chan *tracepb.ResourceSpans)
+	close(ch)
+	return ch, nil
+}
+
+func (m *TraceObserverClientMock) WatchTracesCalls() []struct {
+	Ctx    context.Context
+	Filter traceobserver.WatchTracesFilter
+} {
+	m.watchTracesMutex.RLock()
+	defer m.watchTracesMutex.RUnlock()
+	return m.watchTracesCalls
+}
@@ -0,0 +1,431 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gitprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// BitbucketAPIBaseURL is the base URL for Bitbucket Cloud's REST API.
+	BitbucketAPIBaseURL = "https://api.bitbucket.org/2.0"
+)
+
+// BitbucketProvider implements the Provider interface for Bitbucket Cloud.
+//
+// Unlike GitHub/GitLab, Bitbucket paginates with a full `next` URL rather
+// than a page number, so ListBranches/ListCommits translate Page into a
+// `page` query parameter on the first request but determine HasMore purely
+// from whether the response carried a `next` link.
+type BitbucketProvider struct {
+	username   string
+	token      string
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewBitbucketProvider creates a new Bitbucket provider. If cfg.Username is
+// set, Token is treated as an app password and sent as HTTP Basic auth;
+// otherwise Token is treated as an OAuth access token and sent as a Bearer
+// token.
+func NewBitbucketProvider(cfg Config) (*BitbucketProvider, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = BitbucketAPIBaseURL
+	}
+	return &BitbucketProvider{
+		username:   cfg.Username,
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		baseURL:    baseURL,
+	}, nil
+}
+
+// GetProviderType returns the provider type.
+func (b *BitbucketProvider) GetProviderType() ProviderType {
+	return ProviderBitbucket
+}
+
+// ListBranches returns available branches for a repository.
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-branch-restrictions/
+func (b *BitbucketProvider) ListBranches(ctx context.Context, owner, repo string, opts ListBranchesOptions) (*ListBranchesResponse, error) {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	page := opts.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	defaultBranch, err := b.getDefaultBranch(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf("%s/repositories/%s/%s/refs/branches?pagelen=%d&page=%d", b.baseURL, owner, repo, perPage, page)
+
+	var page1 bitbucketPage[bitbucketBranch]
+	if err := b.get(ctx, reqURL, &page1); err != nil {
+		return nil, err
+	}
+
+	branches := make([]Branch, len(page1.Values))
+	for i, bb := range page1.Values {
+		branches[i] = Branch{
+			Name:      bb.Name,
+			CommitSHA: bb.Target.Hash,
+			IsDefault: bb.Name == defaultBranch,
+		}
+	}
+
+	return &ListBranchesResponse{
+		Branches: branches,
+		Page:     page,
+		PerPage:  perPage,
+		HasMore:  page1.Next != "",
+	}, nil
+}
+
+// getDefaultBranch fetches the repository's main branch name.
+func (b *BitbucketProvider) getDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	reqURL := fmt.Sprintf("%s/repositories/%s/%s", b.baseURL, owner, repo)
+
+	var repoInfo struct {
+		MainBranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := b.get(ctx, reqURL, &repoInfo); err != nil {
+		return "", err
+	}
+	return repoInfo.MainBranch.Name, nil
+}
+
+// ListCommits returns commits for a repository.
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/
+func (b *BitbucketProvider) ListCommits(ctx context.Context, owner, repo string, opts ListCommitsOptions) (*ListCommitsResponse, error) {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	page := opts.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	ref := opts.SHA
+	reqURL := fmt.Sprintf("%s/repositories/%s/%s/commits/%s?pagelen=%d&page=%d", b.baseURL, owner, repo, ref, perPage, page)
+	if opts.Path != "" {
+		reqURL += "&path=" + opts.Path
+	}
+
+	var page1 bitbucketPage[bitbucketCommit]
+	if err := b.get(ctx, reqURL, &page1); err != nil {
+		return nil, err
+	}
+
+	commits := make([]Commit, 0, len(page1.Values))
+	for i, bc := range page1.Values {
+		if opts.Author != "" && bc.Author.User.DisplayName != opts.Author {
+			continue
+		}
+		if opts.Since != nil && bc.Date.Before(*opts.Since) {
+			continue
+		}
+		if opts.Until != nil && bc.Date.After(*opts.Until) {
+			continue
+		}
+		commits = append(commits, Commit{
+			SHA:     bc.Hash,
+			Message: bc.Message,
+			Author: Author{
+				Name:      bc.Author.User.DisplayName,
+				AvatarURL: bc.Author.User.Links.Avatar.Href,
+			},
+			Timestamp: bc.Date,
+			IsLatest:  i == 0 && page == 1,
+		})
+	}
+
+	return &ListCommitsResponse{
+		Commits: commits,
+		Page:    page,
+		PerPage: perPage,
+		HasMore: page1.Next != "",
+	}, nil
+}
+
+// CompareCommits reports the commits and file changes between base and
+// head: the commits reachable from head but not base come from the commits
+// endpoint with an exclude filter, and the file changes come from the
+// diffstat endpoint.
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-commits-revision-get
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diffstat-spec-get
+func (b *BitbucketProvider) CompareCommits(ctx context.Context, owner, repo, base, head string) (*CompareResponse, error) {
+	commitsURL := fmt.Sprintf("%s/repositories/%s/%s/commits/%s?exclude=%s", b.baseURL, owner, repo, head, base)
+
+	var commitsPage bitbucketPage[bitbucketCommit]
+	if err := b.get(ctx, commitsURL, &commitsPage); err != nil {
+		return nil, fmt.Errorf("failed to list commits for comparison: %w", err)
+	}
+
+	commits := make([]Commit, len(commitsPage.Values))
+	for i, bc := range commitsPage.Values {
+		commits[i] = Commit{
+			SHA:     bc.Hash,
+			Message: bc.Message,
+			Author: Author{
+				Name:      bc.Author.User.DisplayName,
+				AvatarURL: bc.Author.User.Links.Avatar.Href,
+			},
+			Timestamp: bc.Date,
+		}
+	}
+
+	files, totalAdditions, totalDeletions, err := b.diffstat(ctx, owner, repo, fmt.Sprintf("%s..%s", head, base))
+	if err != nil {
+		return nil, err
+	}
+
+	headSHA := head
+	if len(commits) > 0 {
+		headSHA = commits[0].SHA
+	}
+
+	return &CompareResponse{
+		BaseSHA:        base,
+		HeadSHA:        headSHA,
+		Commits:        commits,
+		Files:          files,
+		TotalAdditions: totalAdditions,
+		TotalDeletions: totalDeletions,
+	}, nil
+}
+
+// GetCommit returns a single commit's detail, including its parent SHAs and
+// the files it touched.
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-commit-revision-get
+func (b *BitbucketProvider) GetCommit(ctx context.Context, owner, repo, sha string) (*CommitDetail, error) {
+	reqURL := fmt.Sprintf("%s/repositories/%s/%s/commit/%s", b.baseURL, owner, repo, sha)
+
+	var bc bitbucketCommitDetail
+	if err := b.get(ctx, reqURL, &bc); err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	parents := make([]string, len(bc.Parents))
+	for i, p := range bc.Parents {
+		parents[i] = p.Hash
+	}
+
+	files, _, _, err := b.diffstat(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitDetail{
+		Commit: Commit{
+			SHA:     bc.Hash,
+			Message: bc.Message,
+			Author: Author{
+				Name:      bc.Author.User.DisplayName,
+				AvatarURL: bc.Author.User.Links.Avatar.Href,
+			},
+			Timestamp: bc.Date,
+		},
+		Parents: parents,
+		Files:   files,
+	}, nil
+}
+
+// diffstat fetches every page of Bitbucket's diffstat endpoint for spec (a
+// single revision, or a "<source>..<destination>" range) and converts it to
+// the shared CommitFile shape. Bitbucket's diffstat Status values already
+// match our added/modified/removed/renamed vocabulary.
+func (b *BitbucketProvider) diffstat(ctx context.Context, owner, repo, spec string) ([]CommitFile, int, int, error) {
+	reqURL := fmt.Sprintf("%s/repositories/%s/%s/diffstat/%s", b.baseURL, owner, repo, spec)
+
+	var files []CommitFile
+	var totalAdditions, totalDeletions int
+	for reqURL != "" {
+		var page bitbucketPage[bitbucketDiffStat]
+		if err := b.get(ctx, reqURL, &page); err != nil {
+			return nil, 0, 0, fmt.Errorf("failed to get diffstat: %w", err)
+		}
+
+		for _, d := range page.Values {
+			filename := d.New.Path
+			if filename == "" {
+				filename = d.Old.Path
+			}
+			files = append(files, CommitFile{
+				Filename:  filename,
+				Status:    d.Status,
+				Additions: d.LinesAdded,
+				Deletions: d.LinesRemoved,
+				Changes:   d.LinesAdded + d.LinesRemoved,
+			})
+			totalAdditions += d.LinesAdded
+			totalDeletions += d.LinesRemoved
+		}
+
+		reqURL = page.Next
+	}
+
+	return files, totalAdditions, totalDeletions, nil
+}
+
+// get issues an authenticated GET against reqURL and decodes the JSON body
+// into out.
+func (b *BitbucketProvider) get(ctx context.Context, reqURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if b.username != "" {
+		req.SetBasicAuth(b.username, b.token)
+	} else if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call bitbucket API: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := b.checkResponse(resp); err != nil {
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode bitbucket response: %w", err)
+	}
+	return nil
+}
+
+// checkResponse checks the response for errors.
+func (b *BitbucketProvider) checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var bbError bitbucketErrorResponse
+	if err := json.Unmarshal(body, &bbError); err == nil && bbError.Error.Message != "" {
+		return &BitbucketError{StatusCode: resp.StatusCode, Message: bbError.Error.Message, Response: string(body)}
+	}
+
+	return &BitbucketError{
+		StatusCode: resp.StatusCode,
+		Message:    fmt.Sprintf("Bitbucket API error: %d", resp.StatusCode),
+		Response:   string(body),
+	}
+}
+
+// BitbucketError represents an error from the Bitbucket API.
+type BitbucketError struct {
+	StatusCode int
+	Message    string
+	Response   string
+}
+
+func (e *BitbucketError) Error() string {
+	return fmt.Sprintf("Bitbucket API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// Bitbucket API response types
+
+// bitbucketPage is Bitbucket's paginated envelope: rather than a page
+// number, Next carries the full URL to fetch for the next page (or "" on
+// the last page).
+type bitbucketPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+type bitbucketBranch struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+type bitbucketCommit struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Date    time.Time `json:"date"`
+	Author  struct {
+		User struct {
+			DisplayName string `json:"display_name"`
+			Links       struct {
+				Avatar struct {
+					Href string `json:"href"`
+				} `json:"avatar"`
+			} `json:"links"`
+		} `json:"user"`
+	} `json:"author"`
+}
+
+type bitbucketCommitDetail struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Date    time.Time `json:"date"`
+	Author  struct {
+		User struct {
+			DisplayName string `json:"display_name"`
+			Links       struct {
+				Avatar struct {
+					Href string `json:"href"`
+				} `json:"avatar"`
+			} `json:"links"`
+		} `json:"user"`
+	} `json:"author"`
+	Parents []struct {
+		Hash string `json:"hash"`
+	} `json:"parents"`
+}
+
+type bitbucketDiffStat struct {
+	Status       string `json:"status"`
+	LinesAdded   int    `json:"lines_added"`
+	LinesRemoved int    `json:"lines_removed"`
+	Old          struct {
+		Path string `json:"path"`
+	} `json:"old"`
+	New struct {
+		Path string `json:"path"`
+	} `json:"new"`
+}
+
+type bitbucketErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gitprovider
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultETagCacheSize is used when Config.EnableETagCache is set but
+	// CacheSize is left at its zero value.
+	DefaultETagCacheSize = 256
+
+	// DefaultETagCacheTTL is used when Config.EnableETagCache is set but
+	// CacheTTL is left at its zero value.
+	DefaultETagCacheTTL = 10 * time.Minute
+)
+
+// etagEntry is one cached response: the validators GitHub needs to answer
+// with 304, and the decoded-free raw body to replay when it does.
+type etagEntry struct {
+	ETag         string
+	LastModified string
+	Body         []byte
+	LinkHeader   string
+	CachedAt     time.Time
+}
+
+// ETagCache is an in-memory, size- and TTL-bounded cache of GitHub GET
+// responses keyed by URL, letting repeat polls of unchanged
+// branches/commits/repository-info cost a 304 instead of a full rate-limit
+// unit. Evicts least-recently-used entries once Size is exceeded.
+//
+// This intentionally only covers the in-memory case; a Redis-backed tier
+// for sharing the cache across replicas can implement the same Get/Set
+// shape once a shared cache client exists in this service.
+type ETagCache struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type etagCacheItem struct {
+	key   string
+	entry etagEntry
+}
+
+// NewETagCache returns an empty cache bounded to size entries, each valid
+// for ttl after being stored.
+func NewETagCache(size int, ttl time.Duration) *ETagCache {
+	if size <= 0 {
+		size = DefaultETagCacheSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultETagCacheTTL
+	}
+	return &ETagCache{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached entry for url, if present and not expired.
+func (c *ETagCache) Get(url string) (etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[url]
+	if !ok {
+		return etagEntry{}, false
+	}
+	item := elem.Value.(*etagCacheItem)
+	if time.Since(item.entry.CachedAt) > c.ttl {
+		c.ll.Remove(elem)
+		delete(c.items, url)
+		return etagEntry{}, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return item.entry, true
+}
+
+// Set stores entry for url, evicting the least-recently-used entry first
+// if the cache is at capacity.
+func (c *ETagCache) Set(url string, entry etagEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.CachedAt = time.Now()
+
+	if elem, ok := c.items[url]; ok {
+		elem.Value.(*etagCacheItem).entry = entry
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	elem := c.ll.PushFront(&etagCacheItem{key: url, entry: entry})
+	c.items[url] = elem
+
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*etagCacheItem).key)
+		}
+	}
+}
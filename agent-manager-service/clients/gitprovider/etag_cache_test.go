@@ -0,0 +1,67 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gitprovider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestETagCacheGetSetRoundTrip(t *testing.T) {
+	c := NewETagCache(2, time.Minute)
+	c.Set("url1", etagEntry{ETag: "abc", Body: []byte(`[]`)})
+
+	entry, ok := c.Get("url1")
+	if !ok {
+		t.Fatal("Get() expected hit, got miss")
+	}
+	if entry.ETag != "abc" {
+		t.Errorf("ETag = %q, want %q", entry.ETag, "abc")
+	}
+}
+
+func TestETagCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewETagCache(2, time.Minute)
+	c.Set("url1", etagEntry{ETag: "a"})
+	c.Set("url2", etagEntry{ETag: "b"})
+
+	// Touch url1 so it's most-recently-used.
+	c.Get("url1")
+
+	c.Set("url3", etagEntry{ETag: "c"})
+
+	if _, ok := c.Get("url2"); ok {
+		t.Error("Get(url2) expected eviction, got hit")
+	}
+	if _, ok := c.Get("url1"); !ok {
+		t.Error("Get(url1) expected hit (recently used), got miss")
+	}
+	if _, ok := c.Get("url3"); !ok {
+		t.Error("Get(url3) expected hit, got miss")
+	}
+}
+
+func TestETagCacheExpiresByTTL(t *testing.T) {
+	c := NewETagCache(2, time.Millisecond)
+	c.Set("url1", etagEntry{ETag: "a"})
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("url1"); ok {
+		t.Error("Get() expected miss after TTL expiry, got hit")
+	}
+}
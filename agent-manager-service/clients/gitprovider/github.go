@@ -19,11 +19,14 @@ package gitprovider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -43,22 +46,60 @@ const (
 
 	// requestTimeout is the timeout for individual API requests
 	requestTimeout = 30 * time.Second
+
+	// DefaultMaxRateLimitWait caps how long GitHubProvider sleeps for a
+	// rate-limit reset before giving up.
+	DefaultMaxRateLimitWait = 2 * time.Minute
+
+	// maxTransientRetries bounds how many times a 5xx response is retried
+	// with exponential backoff before the error is returned to the caller.
+	maxTransientRetries = 3
 )
 
+// ErrRateLimited is returned when GitHub's rate limit is exhausted and
+// waiting for it to reset would exceed MaxRateLimitWait.
+var ErrRateLimited = errors.New("gitprovider: github rate limit exceeded")
+
 // GitHubProvider implements the Provider interface for GitHub
 type GitHubProvider struct {
-	token      string
-	httpClient *http.Client
-	baseURL    string
+	token       string
+	httpClient  *http.Client
+	baseURL     string
+	rateLimiter RateLimiter
+	maxRateWait time.Duration
+
+	mu            sync.RWMutex
+	lastRateLimit *RateLimitInfo
+
+	etagCache *ETagCache
 }
 
 // NewGitHubProvider creates a new GitHub provider
 func NewGitHubProvider(cfg Config) (*GitHubProvider, error) {
-	return &GitHubProvider{
-		token:      cfg.Token,
-		httpClient: &http.Client{Timeout: requestTimeout},
-		baseURL:    GitHubAPIBaseURL,
-	}, nil
+	maxRateWait := cfg.MaxRateLimitWait
+	if maxRateWait <= 0 {
+		maxRateWait = DefaultMaxRateLimitWait
+	}
+
+	g := &GitHubProvider{
+		token:       cfg.Token,
+		httpClient:  &http.Client{Timeout: requestTimeout},
+		baseURL:     GitHubAPIBaseURL,
+		rateLimiter: cfg.RateLimiter,
+		maxRateWait: maxRateWait,
+	}
+	if cfg.EnableETagCache {
+		g.etagCache = NewETagCache(cfg.CacheSize, cfg.CacheTTL)
+	}
+	return g, nil
+}
+
+// LastRateLimit returns the rate-limit state observed on the most recent
+// response, or nil if no request has completed yet.
+func (g *GitHubProvider) LastRateLimit() *RateLimitInfo {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.lastRateLimit
 }
 
 // GetProviderType returns the provider type
@@ -93,27 +134,11 @@ func (g *GitHubProvider) ListBranches(ctx context.Context, owner, repo string, o
 	url := fmt.Sprintf("%s/repos/%s/%s/branches?per_page=%d&page=%d",
 		g.baseURL, owner, repo, perPage, page)
 
-	// Make request
-	req, err := g.newRequest(ctx, http.MethodGet, url)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := g.httpClient.Do(req)
+	var ghBranches []githubBranch
+	linkHeader, err := g.getJSON(ctx, url, &ghBranches)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list branches: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if err := g.checkResponse(resp); err != nil {
-		return nil, err
-	}
-
-	// Parse response
-	var ghBranches []githubBranch
-	if err := json.NewDecoder(resp.Body).Decode(&ghBranches); err != nil {
-		return nil, fmt.Errorf("failed to decode branches response: %w", err)
-	}
 
 	// Convert to our model
 	branches := make([]Branch, len(ghBranches))
@@ -126,7 +151,7 @@ func (g *GitHubProvider) ListBranches(ctx context.Context, owner, repo string, o
 	}
 
 	// Check if there are more pages using Link header
-	hasMore := g.hasNextPage(resp)
+	hasMore := hasNextPageLink(linkHeader)
 
 	return &ListBranchesResponse{
 		Branches: branches,
@@ -140,26 +165,11 @@ func (g *GitHubProvider) ListBranches(ctx context.Context, owner, repo string, o
 func (g *GitHubProvider) getDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s", g.baseURL, owner, repo)
 
-	req, err := g.newRequest(ctx, http.MethodGet, url)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := g.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to get repository info: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if err := g.checkResponse(resp); err != nil {
-		return "", err
-	}
-
 	var repoInfo struct {
 		DefaultBranch string `json:"default_branch"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&repoInfo); err != nil {
-		return "", fmt.Errorf("failed to decode repository response: %w", err)
+	if _, err := g.getJSON(ctx, url, &repoInfo); err != nil {
+		return "", fmt.Errorf("failed to get repository info: %w", err)
 	}
 
 	return repoInfo.DefaultBranch, nil
@@ -202,27 +212,11 @@ func (g *GitHubProvider) ListCommits(ctx context.Context, owner, repo string, op
 		url += fmt.Sprintf("&until=%s", opts.Until.Format(time.RFC3339))
 	}
 
-	// Make request
-	req, err := g.newRequest(ctx, http.MethodGet, url)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := g.httpClient.Do(req)
+	var ghCommits []githubCommit
+	linkHeader, err := g.getJSON(ctx, url, &ghCommits)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list commits: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if err := g.checkResponse(resp); err != nil {
-		return nil, err
-	}
-
-	// Parse response
-	var ghCommits []githubCommit
-	if err := json.NewDecoder(resp.Body).Decode(&ghCommits); err != nil {
-		return nil, fmt.Errorf("failed to decode commits response: %w", err)
-	}
 
 	// Convert to our model
 	commits := make([]Commit, len(ghCommits))
@@ -241,7 +235,7 @@ func (g *GitHubProvider) ListCommits(ctx context.Context, owner, repo string, op
 	}
 
 	// Check if there are more pages using Link header
-	hasMore := g.hasNextPage(resp)
+	hasMore := hasNextPageLink(linkHeader)
 
 	return &ListCommitsResponse{
 		Commits: commits,
@@ -251,6 +245,105 @@ func (g *GitHubProvider) ListCommits(ctx context.Context, owner, repo string, op
 	}, nil
 }
 
+// CompareCommits reports the commits and file changes between base and
+// head.
+// Reference: https://docs.github.com/en/rest/commits/commits#compare-two-commits
+func (g *GitHubProvider) CompareCommits(ctx context.Context, owner, repo, base, head string) (*CompareResponse, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/compare/%s...%s", g.baseURL, owner, repo, base, head)
+
+	var ghCompare githubCompare
+	if _, err := g.getJSON(ctx, url, &ghCompare); err != nil {
+		return nil, fmt.Errorf("failed to compare commits: %w", err)
+	}
+
+	commits := make([]Commit, len(ghCompare.Commits))
+	for i, c := range ghCompare.Commits {
+		commits[i] = Commit{
+			SHA:     c.SHA,
+			Message: c.Commit.Message,
+			Author: Author{
+				Name:      c.Commit.Author.Name,
+				Email:     c.Commit.Author.Email,
+				AvatarURL: c.Author.AvatarURL,
+			},
+			Timestamp: c.Commit.Author.Date,
+		}
+	}
+
+	headSHA := head
+	if len(commits) > 0 {
+		headSHA = commits[len(commits)-1].SHA
+	}
+
+	files := make([]CommitFile, len(ghCompare.Files))
+	var totalAdditions, totalDeletions int
+	for i, f := range ghCompare.Files {
+		files[i] = CommitFile{
+			Filename:  f.Filename,
+			Status:    f.Status,
+			Additions: f.Additions,
+			Deletions: f.Deletions,
+			Changes:   f.Changes,
+		}
+		totalAdditions += f.Additions
+		totalDeletions += f.Deletions
+	}
+
+	return &CompareResponse{
+		BaseSHA:        ghCompare.BaseCommit.SHA,
+		HeadSHA:        headSHA,
+		AheadBy:        ghCompare.AheadBy,
+		BehindBy:       ghCompare.BehindBy,
+		Commits:        commits,
+		Files:          files,
+		TotalAdditions: totalAdditions,
+		TotalDeletions: totalDeletions,
+	}, nil
+}
+
+// GetCommit returns a single commit's detail, including its parent SHAs and
+// the files it touched.
+// Reference: https://docs.github.com/en/rest/commits/commits#get-a-commit
+func (g *GitHubProvider) GetCommit(ctx context.Context, owner, repo, sha string) (*CommitDetail, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/commits/%s", g.baseURL, owner, repo, sha)
+
+	var ghCommit githubCommitDetail
+	if _, err := g.getJSON(ctx, url, &ghCommit); err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	parents := make([]string, len(ghCommit.Parents))
+	for i, p := range ghCommit.Parents {
+		parents[i] = p.SHA
+	}
+
+	files := make([]CommitFile, len(ghCommit.Files))
+	for i, f := range ghCommit.Files {
+		files[i] = CommitFile{
+			Filename:  f.Filename,
+			Status:    f.Status,
+			Additions: f.Additions,
+			Deletions: f.Deletions,
+			Changes:   f.Changes,
+		}
+	}
+
+	return &CommitDetail{
+		Commit: Commit{
+			SHA:     ghCommit.SHA,
+			Message: ghCommit.Commit.Message,
+			Author: Author{
+				Name:      ghCommit.Commit.Author.Name,
+				Email:     ghCommit.Commit.Author.Email,
+				AvatarURL: ghCommit.Author.AvatarURL,
+			},
+			Timestamp: ghCommit.Commit.Author.Date,
+		},
+		Parents: parents,
+		Files:   files,
+	}, nil
+}
+
 // newRequest creates a new HTTP request with appropriate headers
 func (g *GitHubProvider) newRequest(ctx context.Context, method, url string) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, nil)
@@ -271,6 +364,107 @@ func (g *GitHubProvider) newRequest(ctx context.Context, method, url string) (*h
 	return req, nil
 }
 
+// do sends req, proactively respecting g.rateLimiter if configured, then
+// reactively handling the response: a 403/429 that looks like a rate limit
+// is slept out (via Retry-After if present, else X-RateLimit-Reset) up to
+// g.maxRateWait and retried, and a 5xx is retried with exponential
+// backoff+jitter up to maxTransientRetries times. The final response
+// (whatever its status) is returned for checkResponse to turn into an
+// error; ErrRateLimited is returned directly instead when waiting for
+// reset would exceed g.maxRateWait.
+func (g *GitHubProvider) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	backoff := 500 * time.Millisecond
+
+	for attempt := 0; ; attempt++ {
+		if g.rateLimiter != nil {
+			if err := g.rateLimiter.Allow(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := g.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		g.mu.Lock()
+		g.lastRateLimit = g.getRateLimitInfo(resp)
+		g.mu.Unlock()
+
+		if g.isRateLimitResponse(resp) {
+			wait, ok := g.rateLimitWait(resp)
+			_ = resp.Body.Close()
+			if !ok || wait > g.maxRateWait {
+				return nil, ErrRateLimited
+			}
+			if err := sleepCtx(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 500 && attempt < maxTransientRetries {
+			_ = resp.Body.Close()
+			if err := sleepCtx(ctx, jitter(backoff)); err != nil {
+				return nil, err
+			}
+			backoff *= 2
+			continue
+		}
+
+		return resp, nil
+	}
+}
+
+// isRateLimitResponse reports whether resp looks like GitHub's primary or
+// secondary rate limit rather than an ordinary 403 (e.g. insufficient
+// token scope).
+func (g *GitHubProvider) isRateLimitResponse(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return true
+	}
+	return false
+}
+
+// rateLimitWait derives how long to sleep before retrying a rate-limited
+// response, preferring Retry-After and falling back to X-RateLimit-Reset.
+func (g *GitHubProvider) rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if v, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			return time.Until(time.Unix(v, 0)), true
+		}
+	}
+	return 0, false
+}
+
+// sleepCtx waits for d, returning ctx's error if it's cancelled first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// jitter randomizes d by up to +/-20%, so concurrent retries don't wake in
+// lockstep.
+func jitter(d time.Duration) time.Duration {
+	delta := float64(d) * 0.2
+	return d + time.Duration(delta*(2*rand.Float64()-1))
+}
+
 // checkResponse checks the response for errors
 func (g *GitHubProvider) checkResponse(resp *http.Response) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -294,16 +488,82 @@ func (g *GitHubProvider) checkResponse(resp *http.Response) error {
 	}
 }
 
-// hasNextPage checks if there are more pages by parsing the Link header
+// hasNextPageLink checks if there are more pages by parsing a Link header
+// value (from either a live response or a cached one).
 // Reference: https://docs.github.com/en/rest/using-the-rest-api/best-practices-for-using-the-rest-api#use-link-headers
-func (g *GitHubProvider) hasNextPage(resp *http.Response) bool {
-	linkHeader := resp.Header.Get("Link")
+func hasNextPageLink(linkHeader string) bool {
 	if linkHeader == "" {
 		return false
 	}
 	return strings.Contains(linkHeader, `rel="next"`)
 }
 
+// getJSON issues a GET to url and decodes the JSON body into out. If the
+// provider has an ETagCache and holds a prior entry for url, it's sent as
+// If-None-Match/If-Modified-Since; a 304 response replays the cached body
+// without consuming rate-limit quota. On a fresh 200 with an ETag, the
+// response is cached for next time. Returns the response's Link header
+// (live or replayed from cache) so callers can resolve HasMore.
+func (g *GitHubProvider) getJSON(ctx context.Context, url string, out interface{}) (string, error) {
+	req, err := g.newRequest(ctx, http.MethodGet, url)
+	if err != nil {
+		return "", err
+	}
+
+	var cached etagEntry
+	haveCached := false
+	if g.etagCache != nil {
+		if entry, ok := g.etagCache.Get(url); ok {
+			cached, haveCached = entry, true
+			if entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
+
+	resp, err := g.do(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && haveCached {
+		if err := json.Unmarshal(cached.Body, out); err != nil {
+			return "", fmt.Errorf("failed to decode cached response: %w", err)
+		}
+		return cached.LinkHeader, nil
+	}
+
+	if err := g.checkResponse(resp); err != nil {
+		return "", err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	linkHeader := resp.Header.Get("Link")
+	if g.etagCache != nil {
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			g.etagCache.Set(url, etagEntry{
+				ETag:         etag,
+				LastModified: resp.Header.Get("Last-Modified"),
+				Body:         body,
+				LinkHeader:   linkHeader,
+			})
+		}
+	}
+
+	return linkHeader, nil
+}
+
 // getRateLimitInfo extracts rate limit information from response headers
 func (g *GitHubProvider) getRateLimitInfo(resp *http.Response) *RateLimitInfo {
 	remaining := resp.Header.Get("X-RateLimit-Remaining")
@@ -374,6 +634,43 @@ type githubCommit struct {
 	} `json:"author"`
 }
 
+type githubCommitFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Changes   int    `json:"changes"`
+}
+
+type githubCompare struct {
+	BaseCommit struct {
+		SHA string `json:"sha"`
+	} `json:"base_commit"`
+	AheadBy  int                `json:"ahead_by"`
+	BehindBy int                `json:"behind_by"`
+	Commits  []githubCommit     `json:"commits"`
+	Files    []githubCommitFile `json:"files"`
+}
+
+type githubCommitDetail struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Author struct {
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+		} `json:"author"`
+		Message string `json:"message"`
+	} `json:"commit"`
+	Author struct {
+		AvatarURL string `json:"avatar_url"`
+	} `json:"author"`
+	Parents []struct {
+		SHA string `json:"sha"`
+	} `json:"parents"`
+	Files []githubCommitFile `json:"files"`
+}
+
 type githubErrorResponse struct {
 	Message          string `json:"message"`
 	DocumentationURL string `json:"documentation_url"`
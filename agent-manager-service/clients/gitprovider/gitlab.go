@@ -0,0 +1,462 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gitprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// GitLabAPIBaseURL is the base URL for GitLab's public SaaS API.
+	GitLabAPIBaseURL = "https://gitlab.com/api/v4"
+)
+
+// GitLabProvider implements the Provider interface for GitLab.
+type GitLabProvider struct {
+	token      string
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewGitLabProvider creates a new GitLab provider, authenticating with a
+// personal/project access token as a Bearer token.
+func NewGitLabProvider(cfg Config) (*GitLabProvider, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = GitLabAPIBaseURL
+	}
+	return &GitLabProvider{
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		baseURL:    baseURL,
+	}, nil
+}
+
+// GetProviderType returns the provider type.
+func (g *GitLabProvider) GetProviderType() ProviderType {
+	return ProviderGitLab
+}
+
+// ListBranches returns available branches for a repository.
+// Reference: https://docs.gitlab.com/ee/api/branches.html
+func (g *GitLabProvider) ListBranches(ctx context.Context, owner, repo string, opts ListBranchesOptions) (*ListBranchesResponse, error) {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	page := opts.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	project := url.QueryEscape(owner + "/" + repo)
+	reqURL := fmt.Sprintf("%s/projects/%s/repository/branches?per_page=%d&page=%d", g.baseURL, project, perPage, page)
+
+	req, err := g.newRequest(ctx, http.MethodGet, reqURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := g.checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var glBranches []gitlabBranch
+	if err := json.NewDecoder(resp.Body).Decode(&glBranches); err != nil {
+		return nil, fmt.Errorf("failed to decode branches response: %w", err)
+	}
+
+	branches := make([]Branch, len(glBranches))
+	for i, b := range glBranches {
+		branches[i] = Branch{
+			Name:      b.Name,
+			CommitSHA: b.Commit.ID,
+			IsDefault: b.Default,
+		}
+	}
+
+	return &ListBranchesResponse{
+		Branches: branches,
+		Page:     page,
+		PerPage:  perPage,
+		HasMore:  g.hasNextPage(resp),
+	}, nil
+}
+
+// ListCommits returns commits for a repository.
+// Reference: https://docs.gitlab.com/ee/api/commits.html
+func (g *GitLabProvider) ListCommits(ctx context.Context, owner, repo string, opts ListCommitsOptions) (*ListCommitsResponse, error) {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	page := opts.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	project := url.QueryEscape(owner + "/" + repo)
+	reqURL := fmt.Sprintf("%s/projects/%s/repository/commits?per_page=%d&page=%d", g.baseURL, project, perPage, page)
+
+	if opts.SHA != "" {
+		reqURL += "&ref_name=" + url.QueryEscape(opts.SHA)
+	}
+	if opts.Path != "" {
+		reqURL += "&path=" + url.QueryEscape(opts.Path)
+	}
+	if opts.Author != "" {
+		reqURL += "&author=" + url.QueryEscape(opts.Author)
+	}
+	if opts.Since != nil {
+		reqURL += "&since=" + opts.Since.Format(time.RFC3339)
+	}
+	if opts.Until != nil {
+		reqURL += "&until=" + opts.Until.Format(time.RFC3339)
+	}
+
+	req, err := g.newRequest(ctx, http.MethodGet, reqURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := g.checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var glCommits []gitlabCommit
+	if err := json.NewDecoder(resp.Body).Decode(&glCommits); err != nil {
+		return nil, fmt.Errorf("failed to decode commits response: %w", err)
+	}
+
+	commits := make([]Commit, len(glCommits))
+	for i, c := range glCommits {
+		commits[i] = Commit{
+			SHA:     c.ID,
+			Message: c.Message,
+			Author: Author{
+				Name:  c.AuthorName,
+				Email: c.AuthorEmail,
+			},
+			Timestamp: c.AuthoredDate,
+			IsLatest:  i == 0 && page == 1,
+		}
+	}
+
+	return &ListCommitsResponse{
+		Commits: commits,
+		Page:    page,
+		PerPage: perPage,
+		HasMore: g.hasNextPage(resp),
+	}, nil
+}
+
+// CompareCommits reports the commits and file changes between base and
+// head. GitLab's compare endpoint returns unified diffs rather than
+// structured additions/deletions counts, so those are derived by counting
+// +/- lines in each diff (see countDiffStats).
+// Reference: https://docs.gitlab.com/ee/api/repositories.html#compare-branches-tags-or-commits
+func (g *GitLabProvider) CompareCommits(ctx context.Context, owner, repo, base, head string) (*CompareResponse, error) {
+	project := url.QueryEscape(owner + "/" + repo)
+	reqURL := fmt.Sprintf("%s/projects/%s/repository/compare?from=%s&to=%s",
+		g.baseURL, project, url.QueryEscape(base), url.QueryEscape(head))
+
+	req, err := g.newRequest(ctx, http.MethodGet, reqURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare commits: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := g.checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var glCompare gitlabCompare
+	if err := json.NewDecoder(resp.Body).Decode(&glCompare); err != nil {
+		return nil, fmt.Errorf("failed to decode compare response: %w", err)
+	}
+
+	commits := make([]Commit, len(glCompare.Commits))
+	for i, c := range glCompare.Commits {
+		commits[i] = Commit{
+			SHA:     c.ID,
+			Message: c.Message,
+			Author: Author{
+				Name:  c.AuthorName,
+				Email: c.AuthorEmail,
+			},
+			Timestamp: c.AuthoredDate,
+		}
+	}
+
+	files := make([]CommitFile, len(glCompare.Diffs))
+	var totalAdditions, totalDeletions int
+	for i, d := range glCompare.Diffs {
+		additions, deletions := countDiffStats(d.Diff)
+		files[i] = CommitFile{
+			Filename:  d.NewPath,
+			Status:    gitlabDiffStatus(d),
+			Additions: additions,
+			Deletions: deletions,
+			Changes:   additions + deletions,
+		}
+		totalAdditions += additions
+		totalDeletions += deletions
+	}
+
+	return &CompareResponse{
+		BaseSHA:        base,
+		HeadSHA:        glCompare.Commit.ID,
+		Commits:        commits,
+		Files:          files,
+		TotalAdditions: totalAdditions,
+		TotalDeletions: totalDeletions,
+	}, nil
+}
+
+// GetCommit returns a single commit's detail, including its parent SHAs and
+// the files it touched. GitLab splits this across two endpoints, so
+// GetCommit issues the commit lookup and its diff in sequence.
+// Reference: https://docs.gitlab.com/ee/api/commits.html#get-a-single-commit
+func (g *GitLabProvider) GetCommit(ctx context.Context, owner, repo, sha string) (*CommitDetail, error) {
+	project := url.QueryEscape(owner + "/" + repo)
+
+	commitURL := fmt.Sprintf("%s/projects/%s/repository/commits/%s", g.baseURL, project, url.PathEscape(sha))
+	req, err := g.newRequest(ctx, http.MethodGet, commitURL)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := g.checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var glCommit gitlabCommitDetail
+	if err := json.NewDecoder(resp.Body).Decode(&glCommit); err != nil {
+		return nil, fmt.Errorf("failed to decode commit response: %w", err)
+	}
+
+	diffURL := fmt.Sprintf("%s/projects/%s/repository/commits/%s/diff", g.baseURL, project, url.PathEscape(sha))
+	diffReq, err := g.newRequest(ctx, http.MethodGet, diffURL)
+	if err != nil {
+		return nil, err
+	}
+	diffResp, err := g.httpClient.Do(diffReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit diff: %w", err)
+	}
+	defer func() { _ = diffResp.Body.Close() }()
+
+	if err := g.checkResponse(diffResp); err != nil {
+		return nil, err
+	}
+
+	var glDiffs []gitlabDiff
+	if err := json.NewDecoder(diffResp.Body).Decode(&glDiffs); err != nil {
+		return nil, fmt.Errorf("failed to decode commit diff response: %w", err)
+	}
+
+	files := make([]CommitFile, len(glDiffs))
+	for i, d := range glDiffs {
+		additions, deletions := countDiffStats(d.Diff)
+		files[i] = CommitFile{
+			Filename:  d.NewPath,
+			Status:    gitlabDiffStatus(d),
+			Additions: additions,
+			Deletions: deletions,
+			Changes:   additions + deletions,
+		}
+	}
+
+	return &CommitDetail{
+		Commit: Commit{
+			SHA:     glCommit.ID,
+			Message: glCommit.Message,
+			Author: Author{
+				Name:  glCommit.AuthorName,
+				Email: glCommit.AuthorEmail,
+			},
+			Timestamp: glCommit.AuthoredDate,
+		},
+		Parents: glCommit.ParentIDs,
+		Files:   files,
+	}, nil
+}
+
+// countDiffStats counts added/removed lines in a unified diff body, since
+// GitLab's compare/diff endpoints return the raw diff text rather than
+// structured additions/deletions counts.
+func countDiffStats(diff string) (additions, deletions int) {
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---"):
+			// Hunk file headers, not content lines.
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// gitlabDiffStatus maps GitLab's boolean diff flags to the same
+// added/modified/removed/renamed vocabulary GitHub's Status field uses.
+func gitlabDiffStatus(d gitlabDiff) string {
+	switch {
+	case d.NewFile:
+		return "added"
+	case d.DeletedFile:
+		return "removed"
+	case d.RenamedFile:
+		return "renamed"
+	default:
+		return "modified"
+	}
+}
+
+// newRequest creates a new HTTP request with GitLab's Bearer auth header.
+func (g *GitLabProvider) newRequest(ctx context.Context, method, reqURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+	return req, nil
+}
+
+// checkResponse checks the response for errors.
+func (g *GitLabProvider) checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var glError gitlabErrorResponse
+	if err := json.Unmarshal(body, &glError); err == nil && glError.Message != "" {
+		return &GitLabError{StatusCode: resp.StatusCode, Message: glError.Message, Response: string(body)}
+	}
+
+	return &GitLabError{
+		StatusCode: resp.StatusCode,
+		Message:    fmt.Sprintf("GitLab API error: %d", resp.StatusCode),
+		Response:   string(body),
+	}
+}
+
+// hasNextPage translates GitLab's X-Next-Page header (present and
+// non-empty when another page exists) to the shared HasMore shape.
+func (g *GitLabProvider) hasNextPage(resp *http.Response) bool {
+	next := resp.Header.Get("X-Next-Page")
+	return next != ""
+}
+
+// GitLabError represents an error from the GitLab API.
+type GitLabError struct {
+	StatusCode int
+	Message    string
+	Response   string
+}
+
+func (e *GitLabError) Error() string {
+	return fmt.Sprintf("GitLab API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// GitLab API response types
+
+type gitlabBranch struct {
+	Name    string `json:"name"`
+	Default bool   `json:"default"`
+	Commit  struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+type gitlabCommit struct {
+	ID           string    `json:"id"`
+	Message      string    `json:"message"`
+	AuthorName   string    `json:"author_name"`
+	AuthorEmail  string    `json:"author_email"`
+	AuthoredDate time.Time `json:"authored_date"`
+}
+
+type gitlabErrorResponse struct {
+	Message string `json:"message"`
+}
+
+type gitlabCompare struct {
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+	Commits []gitlabCommit `json:"commits"`
+	Diffs   []gitlabDiff   `json:"diffs"`
+}
+
+type gitlabCommitDetail struct {
+	ID           string    `json:"id"`
+	Message      string    `json:"message"`
+	AuthorName   string    `json:"author_name"`
+	AuthorEmail  string    `json:"author_email"`
+	AuthoredDate time.Time `json:"authored_date"`
+	ParentIDs    []string  `json:"parent_ids"`
+}
+
+type gitlabDiff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	Diff        string `json:"diff"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
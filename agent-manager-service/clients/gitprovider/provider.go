@@ -0,0 +1,210 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package gitprovider abstracts branch/commit listing over whichever git
+// hosting service an agent's source repository lives on, so the rest of
+// agent-manager-service can call a single Provider interface instead of
+// branching on vendor.
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProviderType identifies which git hosting service a Config/Provider
+// targets.
+type ProviderType string
+
+const (
+	ProviderGitHub    ProviderType = "github"
+	ProviderGitLab    ProviderType = "gitlab"
+	ProviderBitbucket ProviderType = "bitbucket"
+)
+
+// Config holds the connection details needed to construct any Provider.
+// Not every field applies to every provider: Workspace is Bitbucket-only,
+// and BaseURL only needs to be set for self-hosted GitLab/GitHub Enterprise.
+type Config struct {
+	ProviderType ProviderType
+
+	// Token authenticates the request: a PAT for GitHub/GitLab, or an
+	// app password/OAuth token for Bitbucket.
+	Token string
+
+	// Username is required alongside Token for Bitbucket's app-password
+	// auth; GitHub and GitLab authenticate with the token alone.
+	Username string
+
+	// Workspace scopes Bitbucket API calls, which are workspace-rooted
+	// rather than organization-rooted like GitHub/GitLab.
+	Workspace string
+
+	// BaseURL overrides the provider's default API base, for self-hosted
+	// GitLab or GitHub Enterprise instances. Empty means use the
+	// provider's public SaaS API.
+	BaseURL string
+
+	// RateLimiter, if set, bounds how often GitHubProvider issues requests
+	// proactively, on top of its reactive Retry-After/X-RateLimit handling.
+	RateLimiter RateLimiter
+
+	// MaxRateLimitWait caps how long GitHubProvider will sleep for a
+	// rate-limit reset before giving up and returning ErrRateLimited.
+	// Zero means use DefaultMaxRateLimitWait.
+	MaxRateLimitWait time.Duration
+
+	// EnableETagCache turns on GitHubProvider's in-memory conditional
+	// request cache, so repeat polls of unchanged branches/commits/repo
+	// info cost a 304 instead of a full rate-limit unit.
+	EnableETagCache bool
+	// CacheTTL bounds how long a cached entry is trusted before it's
+	// refetched unconditionally. Zero means DefaultETagCacheTTL.
+	CacheTTL time.Duration
+	// CacheSize bounds how many URLs the cache remembers. Zero means
+	// DefaultETagCacheSize.
+	CacheSize int
+}
+
+// Author identifies who authored a commit.
+type Author struct {
+	Name      string
+	Email     string
+	AvatarURL string
+}
+
+// Branch describes one branch of a repository.
+type Branch struct {
+	Name      string
+	CommitSHA string
+	IsDefault bool
+}
+
+// Commit describes one commit in a repository's history.
+type Commit struct {
+	SHA       string
+	Message   string
+	Author    Author
+	Timestamp time.Time
+	IsLatest  bool
+}
+
+// ListBranchesOptions paginates ListBranches.
+type ListBranchesOptions struct {
+	Page    int
+	PerPage int
+}
+
+// ListBranchesResponse is one page of ListBranches results.
+type ListBranchesResponse struct {
+	Branches []Branch
+	Page     int
+	PerPage  int
+	HasMore  bool
+}
+
+// ListCommitsOptions paginates and filters ListCommits.
+type ListCommitsOptions struct {
+	Page    int
+	PerPage int
+
+	// SHA restricts results to commits reachable from this branch/tag/SHA.
+	SHA string
+	// Path restricts results to commits touching this file path.
+	Path string
+	// Author filters by commit author.
+	Author string
+	// Since and Until bound the commit timestamp range.
+	Since *time.Time
+	Until *time.Time
+}
+
+// ListCommitsResponse is one page of ListCommits results.
+type ListCommitsResponse struct {
+	Commits []Commit
+	Page    int
+	PerPage int
+	HasMore bool
+}
+
+// CommitFile describes one file touched by a commit or a comparison between
+// two refs.
+type CommitFile struct {
+	Filename  string
+	Status    string // e.g. "added", "modified", "removed", "renamed"
+	Additions int
+	Deletions int
+	Changes   int
+}
+
+// CommitDetail is a single commit together with its parent SHAs and the
+// files it touched, as returned by GetCommit.
+type CommitDetail struct {
+	Commit
+	Parents []string
+	Files   []CommitFile
+}
+
+// CompareResponse is the result of comparing two refs, as returned by
+// CompareCommits: the commits reachable from head but not base, and the
+// files they touch in aggregate.
+type CompareResponse struct {
+	BaseSHA        string
+	HeadSHA        string
+	AheadBy        int
+	BehindBy       int
+	Commits        []Commit
+	Files          []CommitFile
+	TotalAdditions int
+	TotalDeletions int
+}
+
+// Provider lists branches and commits for a repository hosted on a
+// particular git service. Implementations are expected to be safe for
+// concurrent use, matching how GitHubProvider wraps a shared *http.Client.
+type Provider interface {
+	ListBranches(ctx context.Context, owner, repo string, opts ListBranchesOptions) (*ListBranchesResponse, error)
+	ListCommits(ctx context.Context, owner, repo string, opts ListCommitsOptions) (*ListCommitsResponse, error)
+
+	// CompareCommits reports the commits and file changes between base and
+	// head, so callers can describe what changed across a deployment
+	// instead of trading opaque revision IDs.
+	CompareCommits(ctx context.Context, owner, repo, base, head string) (*CompareResponse, error)
+	// GetCommit returns a single commit's detail, including its parent SHAs
+	// and the files it touched.
+	GetCommit(ctx context.Context, owner, repo, sha string) (*CommitDetail, error)
+
+	GetProviderType() ProviderType
+}
+
+// NewProvider constructs the Provider implementation matching
+// cfg.ProviderType. Callers are expected to build cfg from whatever record
+// stores a source repository's hosting details (provider type, token,
+// workspace) and call NewProvider once per repo, rather than assuming
+// GitHub.
+func NewProvider(cfg Config) (Provider, error) {
+	switch cfg.ProviderType {
+	case ProviderGitHub, "":
+		return NewGitHubProvider(cfg)
+	case ProviderGitLab:
+		return NewGitLabProvider(cfg)
+	case ProviderBitbucket:
+		return NewBitbucketProvider(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported git provider type: %q", cfg.ProviderType)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gitprovider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter bounds how often a Provider issues requests, independent of
+// the reactive Retry-After/X-RateLimit-Reset handling a Provider also does
+// after the fact; this lets many goroutines sharing one GitHubProvider stay
+// under a primary/secondary rate limit proactively. Allow blocks until a
+// request may proceed, or returns ctx's error if it's cancelled first.
+type RateLimiter interface {
+	Allow(ctx context.Context) error
+}
+
+// TokenBucket is a RateLimiter that refills at a fixed rate up to Capacity,
+// suitable for GitHub's "N requests per hour" primary rate limit expressed
+// as a steady-state rate.
+type TokenBucket struct {
+	mu         sync.Mutex
+	capacity   float64
+	tokens     float64
+	refillRate float64 // tokens per second
+	lastRefill time.Time
+}
+
+// NewTokenBucket returns a TokenBucket starting full, that refills at
+// refillPerSecond tokens/second up to capacity.
+func NewTokenBucket(capacity float64, refillPerSecond float64) *TokenBucket {
+	return &TokenBucket{
+		capacity:   capacity,
+		tokens:     capacity,
+		refillRate: refillPerSecond,
+		lastRefill: time.Now(),
+	}
+}
+
+// Allow blocks until a token is available (waiting for refill if needed),
+// or ctx is done first.
+func (b *TokenBucket) Allow(ctx context.Context) error {
+	for {
+		wait := b.reserve()
+		if wait <= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// reserve refills the bucket for elapsed time and either consumes a token
+// (returning 0) or returns how long the caller must wait for one.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+	b.tokens += elapsed * b.refillRate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	deficit := 1 - b.tokens
+	return time.Duration(deficit/b.refillRate*1000) * time.Millisecond
+}
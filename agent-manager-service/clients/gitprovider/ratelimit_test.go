@@ -0,0 +1,68 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gitprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllowsUpToCapacityImmediately(t *testing.T) {
+	b := NewTokenBucket(3, 1)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		start := time.Now()
+		if err := b.Allow(ctx); err != nil {
+			t.Fatalf("Allow() call %d unexpected error = %v", i, err)
+		}
+		if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+			t.Errorf("Allow() call %d took %v, want near-instant while tokens remain", i, elapsed)
+		}
+	}
+}
+
+func TestTokenBucketBlocksWhenExhausted(t *testing.T) {
+	b := NewTokenBucket(1, 20) // refills one token every 50ms
+	ctx := context.Background()
+
+	if err := b.Allow(ctx); err != nil {
+		t.Fatalf("first Allow() unexpected error = %v", err)
+	}
+
+	start := time.Now()
+	if err := b.Allow(ctx); err != nil {
+		t.Fatalf("second Allow() unexpected error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("second Allow() took %v, want to block for a refill", elapsed)
+	}
+}
+
+func TestTokenBucketRespectsContextCancellation(t *testing.T) {
+	b := NewTokenBucket(1, 0.001) // effectively never refills within the test
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := b.Allow(ctx); err != nil {
+		t.Fatalf("first Allow() unexpected error = %v", err)
+	}
+	if err := b.Allow(ctx); err == nil {
+		t.Error("second Allow() expected context deadline error, got nil")
+	}
+}
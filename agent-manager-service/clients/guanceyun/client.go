@@ -0,0 +1,104 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package guanceyun is a minimal client for Guanceyun (观测云), used to poll
+// whether any monitor rules are alerting in a workspace over a lookback
+// window, mirroring the Guanceyun-check job used elsewhere for CI gating.
+package guanceyun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// requestTimeout bounds a single poll against the Guanceyun API.
+	requestTimeout = 15 * time.Second
+)
+
+// Config holds the connection details for a workspace's Guanceyun account.
+// A *Config is expected to be stored on whatever record configures a
+// periodic check (e.g. a monitor) so it can be round-tripped through JSON.
+type Config struct {
+	Endpoint  string `json:"endpoint"`
+	APIKey    string `json:"apiKey"`
+	Workspace string `json:"workspace"`
+}
+
+// AlertRule describes a single alerting rule returned by CheckAlerts.
+type AlertRule struct {
+	RuleName string    `json:"ruleName"`
+	Level    string    `json:"level"`
+	Message  string    `json:"message"`
+	FiredAt  time.Time `json:"firedAt"`
+}
+
+// CheckResult is the outcome of one poll: whether anything in the workspace
+// is currently alerting, and if so, which rules.
+type CheckResult struct {
+	Alerting       bool        `json:"alerting"`
+	OffendingRules []AlertRule `json:"offendingRules"`
+}
+
+// Client queries a Guanceyun workspace for alerting state.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// NewClient returns a Client for the given workspace configuration.
+func NewClient(cfg Config) *Client {
+	return &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// CheckAlerts queries whether any monitor rule in the workspace alerted
+// within the lookback window ending now, returning the offending rule names
+// if so.
+func (c *Client) CheckAlerts(ctx context.Context, lookback time.Duration) (*CheckResult, error) {
+	url := fmt.Sprintf("%s/api/v1/alert/status?workspace=%s&lookback=%s", c.cfg.Endpoint, c.cfg.Workspace, lookback)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create guanceyun request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query guanceyun alert status: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("guanceyun alert status returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result CheckResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode guanceyun alert status response: %w", err)
+	}
+	result.Alerting = len(result.OffendingRules) > 0
+
+	return &result, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Command agent-manager is the operator-facing CLI for agent-manager-service.
+// It currently has a single subcommand, "catalog validate", which runs the
+// same checks catalog.LoadOverlay applies at process start, offline, so an
+// operator can validate a catalog overlay before rolling it out.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/catalog"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "catalog":
+		runCatalog(os.Args[2:])
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func runCatalog(args []string) {
+	if len(args) != 2 || args[0] != "validate" {
+		usage()
+		os.Exit(1)
+	}
+
+	dir := args[1]
+	if err := catalog.ValidateOverlayDir(os.DirFS(dir)); err != nil {
+		fmt.Fprintf(os.Stderr, "catalog validate: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("catalog validate: %s is valid\n", dir)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: agent-manager catalog validate <dir>")
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils/retry"
+)
+
+// ArtifactTrustController exposes an organization's artifact signing trust
+// material: the published public key set gateways verify against, and the
+// admin operation that rotates the active signing key.
+type ArtifactTrustController interface {
+	// GetTrustKeys handles GET /api/internal/v1/trust/keys
+	// A gateway calls this (using the same API-key auth as the rest of the
+	// internal API) to fetch every key it should accept a signature from.
+	GetTrustKeys(w http.ResponseWriter, r *http.Request)
+
+	// RotateSigningKey handles POST /orgs/{orgName}/signing-keys:rotate
+	RotateSigningKey(w http.ResponseWriter, r *http.Request)
+}
+
+type artifactTrustController struct {
+	signingService services.ArtifactSigningService
+	orgRepo        repositories.OrganizationRepository
+}
+
+// NewArtifactTrustController creates a new artifact trust controller
+func NewArtifactTrustController(
+	signingService services.ArtifactSigningService,
+	orgRepo repositories.OrganizationRepository,
+) ArtifactTrustController {
+	return &artifactTrustController{
+		signingService: signingService,
+		orgRepo:        orgRepo,
+	}
+}
+
+// GetTrustKeys handles GET /api/internal/v1/trust/keys
+func (c *artifactTrustController) GetTrustKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	gateway, ok := middleware.GatewayFromContext(ctx)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing gateway authentication")
+		return
+	}
+
+	keys, err := c.signingService.PublicKeys(ctx, gateway.OrganizationName)
+	if err != nil {
+		log.Error("GetTrustKeys: failed to list public keys", "error", err)
+		if errors.Is(err, retry.ErrCircuitOpen) {
+			if wait, ok := services.SigningKeyRetryAfter(gateway.OrganizationName); ok {
+				utils.WriteRetryAfterResponse(w, wait, "Trust key service temporarily unavailable")
+				return
+			}
+		}
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get trust keys")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusOK, map[string]interface{}{
+		"keys": keys,
+	})
+}
+
+// RotateSigningKey handles POST /orgs/{orgName}/signing-keys:rotate
+func (c *artifactTrustController) RotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgName := r.PathValue(utils.PathParamOrgName)
+
+	if _, err := c.orgRepo.GetOrganizationByName(orgName); err != nil {
+		log.Error("RotateSigningKey: failed to get organization", "orgName", orgName, "error", err)
+		utils.WriteErrorResponse(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	key, err := c.signingService.RotateKey(ctx, orgName)
+	if err != nil {
+		log.Error("RotateSigningKey: failed to rotate signing key", "orgName", orgName, "error", err)
+		if errors.Is(err, retry.ErrCircuitOpen) {
+			if wait, ok := services.SigningKeyRetryAfter(orgName); ok {
+				utils.WriteRetryAfterResponse(w, wait, "Signing key service temporarily unavailable")
+				return
+			}
+		}
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to rotate signing key")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusCreated, key)
+}
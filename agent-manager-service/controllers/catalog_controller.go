@@ -17,7 +17,10 @@
 package controllers
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
@@ -27,22 +30,172 @@ import (
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
 )
 
+// nextCursorHeader carries the opaque cursor for the next ListCatalog page.
+// It's returned as a header rather than a response body field so existing
+// clients decoding spec.CatalogListResponse are unaffected.
+const nextCursorHeader = "X-Next-Page-Cursor"
+
 // CatalogController defines the interface for catalog HTTP handlers
 type CatalogController interface {
 	ListCatalog(w http.ResponseWriter, r *http.Request)
+	// SearchCatalog handles GET /orgs/{orgName}/catalog:search
+	SearchCatalog(w http.ResponseWriter, r *http.Request)
+	// PublishCatalogEntry handles POST /orgs/{orgName}/catalog/{uuid}:publish
+	PublishCatalogEntry(w http.ResponseWriter, r *http.Request)
+	// PullCatalogEntry handles POST /orgs/{orgName}/catalog:pull
+	PullCatalogEntry(w http.ResponseWriter, r *http.Request)
+	// InstantiateLLMProvider handles POST /orgs/{orgName}/catalog/llm-providers/{handle}:instantiate
+	InstantiateLLMProvider(w http.ResponseWriter, r *http.Request)
 }
 
 type catalogController struct {
-	catalogService services.CatalogService
-	orgRepo        repositories.OrganizationRepository
+	catalogService         services.CatalogService
+	catalogRegistryService services.CatalogRegistryService
+	catalogRepo            repositories.CatalogRepository
+	orgRepo                repositories.OrganizationRepository
 }
 
 // NewCatalogController creates a new catalog controller
-func NewCatalogController(catalogService services.CatalogService, orgRepo repositories.OrganizationRepository) CatalogController {
+func NewCatalogController(
+	catalogService services.CatalogService,
+	catalogRegistryService services.CatalogRegistryService,
+	catalogRepo repositories.CatalogRepository,
+	orgRepo repositories.OrganizationRepository,
+) CatalogController {
 	return &catalogController{
-		catalogService: catalogService,
-		orgRepo:        orgRepo,
+		catalogService:         catalogService,
+		catalogRegistryService: catalogRegistryService,
+		catalogRepo:            catalogRepo,
+		orgRepo:                orgRepo,
+	}
+}
+
+// publishCatalogEntryRequest is the request body for PublishCatalogEntry
+type publishCatalogEntryRequest struct {
+	Ref         string                      `json:"ref"`
+	Credentials registryCredentialsRequest  `json:"credentials"`
+	Artifact    publishCatalogEntryArtifact `json:"artifact"`
+}
+
+type publishCatalogEntryArtifact struct {
+	Manifest string `json:"manifest"` // base64-free; expected to already be plain template YAML text
+	Schema   string `json:"schema,omitempty"`
+	Icon     string `json:"icon,omitempty"`
+}
+
+// pullCatalogEntryRequest is the request body for PullCatalogEntry
+type pullCatalogEntryRequest struct {
+	Ref         string                     `json:"ref"`
+	Credentials registryCredentialsRequest `json:"credentials"`
+}
+
+// registryCredentialsRequest describes how the caller wants to authenticate to the registry.
+// Exactly one of Anonymous, Basic, or DockerConfig should be set.
+type registryCredentialsRequest struct {
+	Anonymous    bool   `json:"anonymous,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+	DockerConfig string `json:"dockerConfig,omitempty"`
+}
+
+func (r registryCredentialsRequest) toServiceCredentials() services.RegistryCredentials {
+	return services.RegistryCredentials{
+		Anonymous:        r.Anonymous,
+		Username:         r.Username,
+		Password:         r.Password,
+		DockerConfigJSON: []byte(r.DockerConfig),
+	}
+}
+
+// PublishCatalogEntry handles POST /orgs/{orgName}/catalog/{uuid}:publish
+func (c *catalogController) PublishCatalogEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgName := r.PathValue(utils.PathParamOrgName)
+	entryUUID := r.PathValue("uuid")
+
+	org, err := c.orgRepo.GetOrganizationByName(orgName)
+	if err != nil {
+		log.Error("PublishCatalogEntry: failed to get organization", "orgName", orgName, "error", err)
+		utils.WriteErrorResponse(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var req publishCatalogEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Ref == "" {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "ref is required")
+		return
+	}
+
+	entry, err := c.catalogRepo.GetByUUID(org.UUID.String(), entryUUID)
+	if err != nil {
+		log.Error("PublishCatalogEntry: failed to get catalog entry", "uuid", entryUUID, "error", err)
+		utils.WriteErrorResponse(w, http.StatusNotFound, "Catalog entry not found")
+		return
+	}
+
+	digest, err := c.catalogRegistryService.Publish(ctx, org.UUID.String(), entry, req.Ref, services.CatalogArtifact{
+		Manifest: []byte(req.Artifact.Manifest),
+		Schema:   []byte(req.Artifact.Schema),
+		Icon:     []byte(req.Artifact.Icon),
+	}, req.Credentials.toServiceCredentials())
+	if err != nil {
+		log.Error("PublishCatalogEntry: failed to publish", "uuid", entryUUID, "ref", req.Ref, "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to publish catalog entry")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusOK, map[string]string{
+		"ref":    req.Ref,
+		"digest": digest,
+	})
+}
+
+// PullCatalogEntry handles POST /orgs/{orgName}/catalog:pull
+func (c *catalogController) PullCatalogEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgName := r.PathValue(utils.PathParamOrgName)
+
+	org, err := c.orgRepo.GetOrganizationByName(orgName)
+	if err != nil {
+		log.Error("PullCatalogEntry: failed to get organization", "orgName", orgName, "error", err)
+		utils.WriteErrorResponse(w, http.StatusNotFound, "Organization not found")
+		return
 	}
+
+	var req pullCatalogEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Ref == "" {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "ref is required")
+		return
+	}
+
+	entry, _, err := c.catalogRegistryService.Pull(ctx, org.UUID.String(), req.Ref, req.Credentials.toServiceCredentials())
+	if err != nil {
+		log.Error("PullCatalogEntry: failed to pull", "ref", req.Ref, "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to pull catalog entry")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusCreated, spec.CatalogEntry{
+		Uuid:      entry.UUID.String(),
+		Handle:    entry.Handle,
+		Name:      entry.Name,
+		Version:   entry.Version,
+		Kind:      entry.Kind,
+		InCatalog: entry.InCatalog,
+		CreatedAt: entry.CreatedAt,
+	})
 }
 
 // ListCatalog handles GET /orgs/{orgName}/catalog
@@ -61,9 +214,10 @@ func (c *catalogController) ListCatalog(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Parse query parameters
-	kind := r.URL.Query().Get("kind")
+	query := r.URL.Query()
 	limit := getIntQueryParam(r, "limit", utils.DefaultLimit)
 	offset := getIntQueryParam(r, "offset", utils.DefaultOffset)
+	cursor := query.Get("cursor")
 
 	// Validate parameters
 	if limit < utils.MinLimit || limit > utils.MaxLimit {
@@ -75,25 +229,218 @@ func (c *catalogController) ListCatalog(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Validate kind parameter if provided
-	if kind != "" && !isValidCatalogKind(kind) {
-		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid kind parameter. Must be one of: llmProvider, agent, mcp")
-		return
+	// kind accepts a comma-separated list, e.g. "agent,mcp"
+	var kinds []string
+	if raw := query.Get("kind"); raw != "" {
+		for _, k := range strings.Split(raw, ",") {
+			k = strings.TrimSpace(k)
+			if k == "" {
+				continue
+			}
+			if !isValidCatalogKind(k) {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid kind parameter. Must be a comma-separated list of: llmProvider, agent, mcp")
+				return
+			}
+			kinds = append(kinds, k)
+		}
+	}
+
+	params := services.ListCatalogParams{
+		Kinds:        kinds,
+		HandlePrefix: query.Get("handle~="),
+		Limit:        limit,
+		Offset:       offset,
+		Cursor:       cursor,
+	}
+	if raw := query.Get("createdAfter"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid createdAfter parameter")
+			return
+		}
+		params.CreatedAfter = &t
+	}
+	if raw := query.Get("createdBefore"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid createdBefore parameter")
+			return
+		}
+		params.CreatedBefore = &t
 	}
 
 	// Call service
-	entries, total, err := c.catalogService.ListCatalog(ctx, org.UUID.String(), kind, limit, offset)
+	page, err := c.catalogService.ListCatalog(ctx, org.UUID.String(), params)
 	if err != nil {
 		log.Error("ListCatalog: failed to list catalog", "error", err)
 		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to list catalog entries")
 		return
 	}
 
+	if page.NextCursor != "" {
+		w.Header().Set(nextCursorHeader, page.NextCursor)
+	}
+
 	// Convert to spec response
-	response := convertToCatalogListResponse(entries, int32(total), int32(limit), int32(offset))
+	response := convertToCatalogListResponse(page.Entries, int32(page.Total), int32(limit), int32(offset))
+	utils.WriteSuccessResponse(w, http.StatusOK, response)
+}
+
+// SearchCatalog handles GET /orgs/{orgName}/catalog:search
+func (c *catalogController) SearchCatalog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgName := r.PathValue(utils.PathParamOrgName)
+
+	org, err := c.orgRepo.GetOrganizationByName(orgName)
+	if err != nil {
+		log.Error("SearchCatalog: failed to get organization", "orgName", orgName, "error", err)
+		utils.WriteErrorResponse(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	query := r.URL.Query()
+	limit := getIntQueryParam(r, "limit", utils.DefaultLimit)
+	offset := getIntQueryParam(r, "offset", utils.DefaultOffset)
+	cursor := query.Get("cursor")
+
+	if limit < utils.MinLimit || limit > utils.MaxLimit {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid limit parameter")
+		return
+	}
+	if offset < 0 {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid offset parameter")
+		return
+	}
+
+	var kinds []string
+	if raw := query.Get("kind"); raw != "" {
+		for _, k := range strings.Split(raw, ",") {
+			k = strings.TrimSpace(k)
+			if k == "" {
+				continue
+			}
+			if !isValidCatalogKind(k) {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid kind parameter. Must be a comma-separated list of: llmProvider, agent, mcp")
+				return
+			}
+			kinds = append(kinds, k)
+		}
+	}
+
+	var statuses []models.CatalogStatus
+	if raw := query.Get("status"); raw != "" {
+		for _, st := range strings.Split(raw, ",") {
+			st = strings.TrimSpace(st)
+			if st == "" {
+				continue
+			}
+			if !isValidCatalogStatus(st) {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid status parameter. Must be a comma-separated list of: published, deprecated, draft")
+				return
+			}
+			statuses = append(statuses, models.CatalogStatus(st))
+		}
+	}
+
+	var tags []string
+	if raw := query.Get("tag"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, t)
+			}
+		}
+	}
+
+	var publishers []string
+	if raw := query.Get("publisher"); raw != "" {
+		for _, p := range strings.Split(raw, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				publishers = append(publishers, p)
+			}
+		}
+	}
+
+	sort := repositories.CatalogSort(query.Get("sort"))
+	switch sort {
+	case "", repositories.CatalogSortRelevance, repositories.CatalogSortRecent, repositories.CatalogSortName:
+	default:
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid sort parameter. Must be one of: relevance, recent, name")
+		return
+	}
+
+	params := services.SearchCatalogParams{
+		Terms:      query.Get("q"),
+		Kinds:      kinds,
+		Tags:       tags,
+		Publishers: publishers,
+		Statuses:   statuses,
+		Sort:       sort,
+		Limit:      limit,
+		Offset:     offset,
+		Cursor:     cursor,
+	}
+
+	page, err := c.catalogService.SearchCatalog(ctx, org.UUID.String(), params)
+	if err != nil {
+		log.Error("SearchCatalog: failed to search catalog", "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to search catalog entries")
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set(nextCursorHeader, page.NextCursor)
+	}
+
+	response := convertToCatalogSearchResponse(page, int32(limit), int32(offset))
 	utils.WriteSuccessResponse(w, http.StatusOK, response)
 }
 
+// instantiateLLMProviderRequest is the request body for InstantiateLLMProvider
+type instantiateLLMProviderRequest struct {
+	Values map[string]any `json:"values"`
+}
+
+// InstantiateLLMProvider handles POST /orgs/{orgName}/catalog/llm-providers/{handle}:instantiate
+func (c *catalogController) InstantiateLLMProvider(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgName := r.PathValue(utils.PathParamOrgName)
+	handle := r.PathValue("handle")
+
+	org, err := c.orgRepo.GetOrganizationByName(orgName)
+	if err != nil {
+		log.Error("InstantiateLLMProvider: failed to get organization", "orgName", orgName, "error", err)
+		utils.WriteErrorResponse(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var req instantiateLLMProviderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	entry, err := c.catalogService.InstantiateLLMProvider(ctx, org.UUID.String(), handle, req.Values)
+	if err != nil {
+		log.Error("InstantiateLLMProvider: failed to instantiate", "handle", handle, "error", err)
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Failed to instantiate LLM provider: "+err.Error())
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusCreated, spec.CatalogEntry{
+		Uuid:      entry.UUID.String(),
+		Handle:    entry.Handle,
+		Name:      entry.Name,
+		Version:   entry.Version,
+		Kind:      entry.Kind,
+		InCatalog: entry.InCatalog,
+		CreatedAt: entry.CreatedAt,
+	})
+}
+
 // Helper functions
 
 func isValidCatalogKind(kind string) bool {
@@ -126,3 +473,49 @@ func convertToCatalogListResponse(entries []models.CatalogEntry, total, limit, o
 		Offset:  offset,
 	}
 }
+
+func isValidCatalogStatus(status string) bool {
+	validStatuses := map[string]bool{
+		string(models.CatalogStatusPublished):  true,
+		string(models.CatalogStatusDeprecated): true,
+		string(models.CatalogStatusDraft):      true,
+	}
+	return validStatuses[status]
+}
+
+// convertToFacetCounts adapts a repository facet count list to the spec's
+// wire representation.
+func convertToFacetCounts(counts []repositories.FacetCount) []spec.CatalogFacetCount {
+	specCounts := make([]spec.CatalogFacetCount, len(counts))
+	for i, c := range counts {
+		specCounts[i] = spec.CatalogFacetCount{Value: c.Value, Count: c.Count}
+	}
+	return specCounts
+}
+
+func convertToCatalogSearchResponse(page services.CatalogSearchPage, limit, offset int32) *spec.CatalogSearchResponse {
+	specEntries := make([]spec.CatalogEntry, len(page.Entries))
+	for i, entry := range page.Entries {
+		specEntries[i] = spec.CatalogEntry{
+			Uuid:      entry.UUID.String(),
+			Handle:    entry.Handle,
+			Name:      entry.Name,
+			Version:   entry.Version,
+			Kind:      entry.Kind,
+			InCatalog: entry.InCatalog,
+			CreatedAt: entry.CreatedAt,
+		}
+	}
+
+	return &spec.CatalogSearchResponse{
+		Entries: specEntries,
+		Total:   int32(page.Total),
+		Limit:   limit,
+		Offset:  offset,
+		Facets: spec.CatalogFacets{
+			Kinds:      convertToFacetCounts(page.Facets.Kinds),
+			Publishers: convertToFacetCounts(page.Facets.Publishers),
+			Statuses:   convertToFacetCounts(page.Facets.Statuses),
+		},
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/driftdetector"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+)
+
+// defaultDriftEventsLimit bounds how many drift events ListDriftEvents
+// returns when the caller doesn't pass ?limit=.
+const defaultDriftEventsLimit = 100
+
+// DriftController exposes operator visibility into driftdetector findings.
+// Like GatewayWebhookController, it relies on the surrounding deployment to
+// gate access (e.g. an internal-only ingress rule), since this service has
+// no admin RBAC of its own.
+type DriftController interface {
+	// ListDriftEvents handles GET /api/internal/v1/drift-events?gatewayId=&limit=
+	ListDriftEvents(w http.ResponseWriter, r *http.Request)
+
+	// RepushConfig handles POST /api/internal/v1/gateways/:gatewayId/providers/:providerId/repush
+	RepushConfig(w http.ResponseWriter, r *http.Request)
+}
+
+type driftController struct {
+	detector        *driftdetector.Detector
+	internalService services.GatewayInternalService
+}
+
+// NewDriftController creates a new drift controller.
+func NewDriftController(detector *driftdetector.Detector, internalService services.GatewayInternalService) DriftController {
+	return &driftController{
+		detector:        detector,
+		internalService: internalService,
+	}
+}
+
+// ListDriftEvents returns the most recent drift events, optionally scoped
+// to a single gateway, newest first.
+func (c *driftController) ListDriftEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	var gatewayUUID uuid.UUID
+	if raw := r.URL.Query().Get("gatewayId"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid gatewayId")
+			return
+		}
+		gatewayUUID = parsed
+	}
+
+	limit := defaultDriftEventsLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = parsed
+	}
+
+	events, err := c.detector.ListEvents(ctx, gatewayUUID, limit)
+	if err != nil {
+		log.Error("ListDriftEvents: failed to list drift events", "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to list drift events")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusOK, map[string]interface{}{
+		"events": events,
+	})
+}
+
+// RepushConfig re-publishes a provider's currently-deployed configuration
+// to a gateway, for an operator to force after a drift event shows the
+// gateway fell out of sync.
+func (c *driftController) RepushConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	gatewayID := r.PathValue("gatewayId")
+	providerID := r.PathValue("providerId")
+	if gatewayID == "" || providerID == "" {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Missing gatewayId or providerId")
+		return
+	}
+
+	if err := c.internalService.RepushConfig(ctx, gatewayID, providerID); err != nil {
+		log.Error("RepushConfig: failed to re-publish config", "gatewayId", gatewayID, "providerId", providerID, "error", err)
+		if errors.Is(err, utils.ErrProviderNotFound) {
+			utils.WriteErrorResponse(w, http.StatusNotFound, "Deployment not found")
+		} else {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to re-push configuration")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusAccepted, map[string]interface{}{
+		"message": "Configuration re-push published",
+	})
+}
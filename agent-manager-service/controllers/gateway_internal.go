@@ -17,17 +17,27 @@
 package controllers
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
 )
 
+// gatewayEventHeartbeatInterval bounds how long a gateway's SSE connection
+// can go quiet before GetEvents writes a comment line to keep any
+// intermediate proxy from timing out the connection.
+const gatewayEventHeartbeatInterval = 15 * time.Second
+
 // GatewayInternalController handles internal API requests from gateways
 // Endpoints must match api-platform's internal API exactly
 type GatewayInternalController interface {
@@ -41,22 +51,76 @@ type GatewayInternalController interface {
 
 	// CreateGatewayDeployment handles POST /api/internal/v1/apis/:apiId/gateway-deployments
 	CreateGatewayDeployment(w http.ResponseWriter, r *http.Request)
+
+	// GetBundle handles GET /api/internal/v1/bundle
+	// Returns a single ZIP with every active LLM provider (and, best-effort,
+	// agent/mcp catalog entries) deployed to the calling gateway, plus a
+	// manifest.json, so the gateway can sync in one request instead of
+	// fetching each artifact individually.
+	GetBundle(w http.ResponseWriter, r *http.Request)
+
+	// GetEvents handles GET /api/internal/v1/events
+	// Streams Server-Sent Events whenever a deployment targeting the calling
+	// gateway is created, updated, or retired, so the gateway can react
+	// instead of re-polling GetAPI/GetBundle on a timer. Supports resuming
+	// after a brief disconnect via the Last-Event-ID header.
+	GetEvents(w http.ResponseWriter, r *http.Request)
 }
 
 type gatewayInternalController struct {
 	internalService services.GatewayInternalService
-	gatewayService  services.GatewayService
+	signingService  services.ArtifactSigningService
+	eventBus        services.GatewayEventBus
 }
 
-// NewGatewayInternalController creates a new gateway internal controller
+// NewGatewayInternalController creates a new gateway internal controller.
+// Gateway authentication is handled by middleware.GatewayAPIKeyAuth, applied
+// to these routes at the router level, rather than here.
 func NewGatewayInternalController(
 	internalService services.GatewayInternalService,
-	gatewayService services.GatewayService,
+	signingService services.ArtifactSigningService,
+	eventBus services.GatewayEventBus,
 ) GatewayInternalController {
 	return &gatewayInternalController{
 		internalService: internalService,
-		gatewayService:  gatewayService,
+		signingService:  signingService,
+		eventBus:        eventBus,
+	}
+}
+
+// signZip signs manifest with orgName's active key and returns the
+// ZipOptions a WriteAPIYamlZip/WriteBundleZip call should use to embed that
+// signature, logging (rather than failing the request) if signing itself
+// errors — an unsigned archive is still useful to a gateway that doesn't
+// enforce verification yet.
+func (c *gatewayInternalController) signZip(ctx context.Context, orgName string, manifest []byte) utils.ZipOptions {
+	log := logger.GetLogger(ctx)
+
+	signature, err := c.signingService.Sign(ctx, orgName, manifest)
+	if err != nil {
+		log.Error("Failed to sign artifact package", "orgName", orgName, "error", err)
+		return utils.ZipOptions{}
+	}
+
+	keys, err := c.signingService.PublicKeys(ctx, orgName)
+	if err != nil {
+		log.Error("Failed to load signer public key", "orgName", orgName, "error", err)
+		return utils.ZipOptions{}
+	}
+	for _, key := range keys {
+		if key.KID != signature.KID {
+			continue
+		}
+		publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey)
+		if err != nil {
+			log.Error("Failed to decode signer public key", "orgName", orgName, "kid", key.KID, "error", err)
+			return utils.ZipOptions{}
+		}
+		return utils.ZipOptions{Signature: signature, SignerPublicKey: publicKey}
 	}
+
+	log.Error("Signed artifact package but could not find matching public key", "orgName", orgName, "kid", signature.KID)
+	return utils.ZipOptions{}
 }
 
 // GetAPIsByOrganization handles GET /api/internal/v1/apis
@@ -66,43 +130,39 @@ func (c *gatewayInternalController) GetAPIsByOrganization(w http.ResponseWriter,
 	ctx := r.Context()
 	log := logger.GetLogger(ctx)
 
-	// Authenticate gateway using API key
-	apiKey := r.Header.Get("api-key")
-	if apiKey == "" {
-		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing API key")
-		return
-	}
-
-	gateway, err := c.gatewayService.VerifyToken(ctx, apiKey)
-	if err != nil {
-		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid API key")
+	gateway, ok := middleware.GatewayFromContext(ctx)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing gateway authentication")
 		return
 	}
 
 	orgID := gateway.OrganizationName
 
 	// Get all API configurations as YAML map
-	apis, err := c.internalService.GetAPIsByOrganization(ctx, orgID)
+	apis, err := c.internalService.GetAPIsByOrganization(ctx, orgID, gateway.UUID.String())
 	if err != nil {
 		log.Error("GetAPIsByOrganization: failed to get APIs", "error", err)
 		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get APIs")
 		return
 	}
 
-	// Create ZIP file from API YAML files
-	zipData, err := utils.CreateAPIYamlZip(apis)
+	manifest, err := json.Marshal(apis)
 	if err != nil {
-		log.Error("GetAPIsByOrganization: failed to create ZIP", "error", err)
-		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create API package")
+		log.Error("GetAPIsByOrganization: failed to marshal manifest for signing", "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get APIs")
 		return
 	}
 
-	// Set headers for ZIP file download
+	// Stream the ZIP directly to the response instead of buffering the
+	// whole archive in memory first; with no Content-Length set, the
+	// server falls back to chunked transfer encoding.
 	w.Header().Set("Content-Type", "application/zip")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"apis-org-%s.zip\"", orgID))
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(zipData)))
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(zipData)
+	if err := utils.WriteAPIYamlZip(w, apis, c.signZip(ctx, orgID, manifest)); err != nil {
+		log.Error("GetAPIsByOrganization: failed to stream ZIP", "error", err)
+		return
+	}
 }
 
 // GetAPI handles GET /api/internal/v1/apis/:apiId
@@ -112,16 +172,9 @@ func (c *gatewayInternalController) GetAPI(w http.ResponseWriter, r *http.Reques
 	ctx := r.Context()
 	log := logger.GetLogger(ctx)
 
-	// Authenticate gateway using API key
-	apiKey := r.Header.Get("api-key")
-	if apiKey == "" {
-		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing API key")
-		return
-	}
-
-	gateway, err := c.gatewayService.VerifyToken(ctx, apiKey)
-	if err != nil {
-		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid API key")
+	gateway, ok := middleware.GatewayFromContext(ctx)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing gateway authentication")
 		return
 	}
 
@@ -144,20 +197,23 @@ func (c *gatewayInternalController) GetAPI(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Create ZIP file from API YAML file
-	zipData, err := utils.CreateAPIYamlZip(api)
+	manifest, err := json.Marshal(api)
 	if err != nil {
-		log.Error("GetAPI: failed to create ZIP", "error", err)
-		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create API package")
+		log.Error("GetAPI: failed to marshal manifest for signing", "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get API")
 		return
 	}
 
-	// Set headers for ZIP file download (same as api-platform)
+	// Stream the ZIP directly to the response instead of buffering the
+	// whole archive in memory first; with no Content-Length set, the
+	// server falls back to chunked transfer encoding.
 	w.Header().Set("Content-Type", "application/zip")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"api-%s.zip\"", apiID))
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(zipData)))
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(zipData)
+	if err := utils.WriteAPIYamlZip(w, api, c.signZip(ctx, gateway.OrganizationName, manifest)); err != nil {
+		log.Error("GetAPI: failed to stream ZIP", "error", err)
+		return
+	}
 }
 
 // CreateGatewayDeployment handles POST /api/internal/v1/apis/:apiId/gateway-deployments
@@ -166,16 +222,9 @@ func (c *gatewayInternalController) CreateGatewayDeployment(w http.ResponseWrite
 	ctx := r.Context()
 	log := logger.GetLogger(ctx)
 
-	// Authenticate gateway using API key
-	apiKey := r.Header.Get("api-key")
-	if apiKey == "" {
-		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing API key")
-		return
-	}
-
-	gateway, err := c.gatewayService.VerifyToken(ctx, apiKey)
-	if err != nil {
-		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Invalid API key")
+	gateway, ok := middleware.GatewayFromContext(ctx)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing gateway authentication")
 		return
 	}
 
@@ -224,3 +273,121 @@ func (c *gatewayInternalController) CreateGatewayDeployment(w http.ResponseWrite
 		"message": response.Message,
 	})
 }
+
+// GetBundle handles GET /api/internal/v1/bundle
+// Gateway calls this endpoint on startup and after any change to sync every
+// artifact assigned to it in one request, instead of discovering and
+// fetching each LLM provider/proxy individually.
+func (c *gatewayInternalController) GetBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	gateway, ok := middleware.GatewayFromContext(ctx)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing gateway authentication")
+		return
+	}
+
+	since := r.URL.Query().Get("since")
+
+	bundle, err := c.internalService.GetBundleForGateway(ctx, gateway.OrganizationName, gateway.UUID.String(), since)
+	if err != nil {
+		log.Error("GetBundle: failed to build bundle", "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to build bundle")
+		return
+	}
+
+	w.Header().Set("ETag", `"`+bundle.ETag+`"`)
+	if since != "" && since == bundle.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"bundle-%s.zip\"", gateway.UUID.String()))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(bundle.Data)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(bundle.Data); err != nil {
+		log.Error("GetBundle: failed to write ZIP response", "error", err)
+	}
+}
+
+// GetEvents handles GET /api/internal/v1/events
+// Gateway calls this once and keeps the connection open to learn about
+// deployment changes as they happen instead of re-polling GetAPI/GetBundle.
+func (c *gatewayInternalController) GetEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	gateway, ok := middleware.GatewayFromContext(ctx)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing gateway authentication")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	var lastEventID int64
+	if raw := r.Header.Get("Last-Event-ID"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid Last-Event-ID")
+			return
+		}
+		lastEventID = parsed
+	}
+
+	events, replay, unsubscribe := c.eventBus.Subscribe(gateway.UUID.String(), lastEventID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, envelope := range replay {
+		if err := writeGatewayEvent(w, envelope); err != nil {
+			log.Error("GetEvents: failed to write replayed event", "error", err)
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(gatewayEventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case envelope := <-events:
+			if err := writeGatewayEvent(w, envelope); err != nil {
+				log.Error("GetEvents: failed to write event", "error", err)
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				log.Error("GetEvents: failed to write heartbeat", "error", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeGatewayEvent writes envelope as a single SSE frame, with its ring
+// buffer ID as the SSE id: field so a reconnecting gateway can resume from
+// it via Last-Event-ID.
+func writeGatewayEvent(w http.ResponseWriter, envelope services.GatewayEventEnvelope) error {
+	data, err := json.Marshal(envelope.Event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", envelope.ID, data)
+	return err
+}
@@ -20,12 +20,22 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
 )
 
+// zipCacheSize and zipCacheTTL bound gatewayInternalController's in-memory
+// ZIP artifact cache: small enough that a single instance's memory use stays
+// predictable, and short enough that a change made through other means
+// (e.g. a direct DB update) is never invisible for long.
+const (
+	zipCacheSize = 1000
+	zipCacheTTL  = 30 * time.Second
+)
+
 // GatewayInternalController defines interface for gateway internal API HTTP handlers
 type GatewayInternalController interface {
 	GetLLMProvider(w http.ResponseWriter, r *http.Request)
@@ -35,6 +45,7 @@ type GatewayInternalController interface {
 type gatewayInternalController struct {
 	gatewayService         *services.PlatformGatewayService
 	gatewayInternalService *services.GatewayInternalAPIService
+	zipCache               *utils.ZipArtifactCache
 }
 
 // NewGatewayInternalController creates a new gateway internal controller
@@ -45,6 +56,7 @@ func NewGatewayInternalController(
 	return &gatewayInternalController{
 		gatewayService:         gatewayService,
 		gatewayInternalService: gatewayInternalService,
+		zipCache:               utils.NewZipArtifactCache(zipCacheSize, zipCacheTTL),
 	}
 }
 
@@ -95,22 +107,35 @@ func (c *gatewayInternalController) GetLLMProvider(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// Create ZIP file from LLM provider YAML file
-	zipData, err := utils.CreateLLMProviderYamlZip(provider)
-	if err != nil {
-		log.Error("Failed to create ZIP file for LLM provider", "providerID", providerID, "error", err)
-		http.Error(w, "Failed to create LLM provider package", http.StatusInternalServerError)
+	cacheKey := fmt.Sprintf("llm-provider:%s:%s:%s", orgName, gatewayID, providerID)
+
+	artifact, cached := c.zipCache.Get(cacheKey)
+	if !cached {
+		zipData, err := utils.CreateLLMProviderYamlZip(provider)
+		if err != nil {
+			log.Error("Failed to create ZIP file for LLM provider", "providerID", providerID, "error", err)
+			http.Error(w, "Failed to create LLM provider package", http.StatusInternalServerError)
+			return
+		}
+		artifact = c.zipCache.Put(cacheKey, zipData)
+	}
+
+	w.Header().Set("ETag", artifact.ETag)
+	w.Header().Set("Last-Modified", artifact.BuiltAt.UTC().Format(http.TimeFormat))
+
+	if utils.ShouldReturnNotModified(r, artifact.ETag, artifact.BuiltAt) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
 	// Set headers for ZIP file download
 	w.Header().Set("Content-Type", "application/zip")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"llm-provider-%s.zip\"", providerID))
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(zipData)))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(artifact.Data)))
 
 	// Return ZIP file
 	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write(zipData); err != nil {
+	if _, err := w.Write(artifact.Data); err != nil {
 		log.Error("Failed to write ZIP response", "providerID", providerID, "error", err)
 	}
 }
@@ -162,22 +187,35 @@ func (c *gatewayInternalController) GetLLMProxy(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Create ZIP file from LLM proxy YAML file
-	zipData, err := utils.CreateLLMProxyYamlZip(proxy)
-	if err != nil {
-		log.Error("Failed to create ZIP file for LLM proxy", "proxyID", proxyID, "error", err)
-		http.Error(w, "Failed to create LLM proxy package", http.StatusInternalServerError)
+	cacheKey := fmt.Sprintf("llm-proxy:%s:%s:%s", orgName, gatewayID, proxyID)
+
+	artifact, cached := c.zipCache.Get(cacheKey)
+	if !cached {
+		zipData, err := utils.CreateLLMProxyYamlZip(proxy)
+		if err != nil {
+			log.Error("Failed to create ZIP file for LLM proxy", "proxyID", proxyID, "error", err)
+			http.Error(w, "Failed to create LLM proxy package", http.StatusInternalServerError)
+			return
+		}
+		artifact = c.zipCache.Put(cacheKey, zipData)
+	}
+
+	w.Header().Set("ETag", artifact.ETag)
+	w.Header().Set("Last-Modified", artifact.BuiltAt.UTC().Format(http.TimeFormat))
+
+	if utils.ShouldReturnNotModified(r, artifact.ETag, artifact.BuiltAt) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
 	// Set headers for ZIP file download
 	w.Header().Set("Content-Type", "application/zip")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"llm-proxy-%s.zip\"", proxyID))
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(zipData)))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(artifact.Data)))
 
 	// Return ZIP file
 	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write(zipData); err != nil {
+	if _, err := w.Write(artifact.Data); err != nil {
 		log.Error("Failed to write ZIP response", "proxyID", proxyID, "error", err)
 	}
 }
@@ -0,0 +1,126 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+)
+
+// GatewayWebhookController handles gateway webhook endpoint registration and
+// operator-triggered redelivery, the push-based replacement for gateways
+// polling GetAPIsByOrganization.
+type GatewayWebhookController interface {
+	// RegisterWebhookEndpoint handles POST /api/internal/v1/gateways/webhook
+	RegisterWebhookEndpoint(w http.ResponseWriter, r *http.Request)
+
+	// RedeliverDelivery handles POST /api/internal/v1/deliveries/:id/redeliver.
+	// Intended for operator tooling; like the rest of this package it relies
+	// on the surrounding deployment to gate access (e.g. an internal-only
+	// ingress rule), since this service has no admin RBAC of its own.
+	RedeliverDelivery(w http.ResponseWriter, r *http.Request)
+}
+
+type gatewayWebhookController struct {
+	webhookService services.GatewayWebhookDispatchService
+}
+
+// NewGatewayWebhookController creates a new gateway webhook controller.
+// Gateway authentication is handled by middleware.GatewayAPIKeyAuth, applied
+// to these routes at the router level, rather than here.
+func NewGatewayWebhookController(
+	webhookService services.GatewayWebhookDispatchService,
+) GatewayWebhookController {
+	return &gatewayWebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// registerWebhookEndpointRequest is the body of RegisterWebhookEndpoint.
+type registerWebhookEndpointRequest struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+// RegisterWebhookEndpoint lets a gateway register (or rotate) the callback
+// URL and HMAC secret agent-manager uses to push configuration.changed
+// events, so it can stop polling GetAPIsByOrganization.
+func (c *gatewayWebhookController) RegisterWebhookEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	gateway, ok := middleware.GatewayFromContext(ctx)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Missing gateway authentication")
+		return
+	}
+
+	var req registerWebhookEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	endpoint, err := c.webhookService.RegisterEndpoint(ctx, gateway.UUID, gateway.OrganizationName, req.URL, req.Secret)
+	if err != nil {
+		log.Error("RegisterWebhookEndpoint: failed to register endpoint", "error", err)
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusCreated, map[string]interface{}{
+		"id":     endpoint.ID,
+		"url":    endpoint.URL,
+		"active": endpoint.Active,
+	})
+}
+
+// RedeliverDelivery resets a delivery's backoff so the next DeliverDue pass
+// retries it immediately, for operators unblocking a gateway that came back
+// online sooner than its scheduled backoff.
+func (c *gatewayWebhookController) RedeliverDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	deliveryID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid delivery ID")
+		return
+	}
+
+	if err := c.webhookService.Redeliver(ctx, deliveryID); err != nil {
+		log.Error("RedeliverDelivery: failed to redeliver", "deliveryId", deliveryID, "error", err)
+		if errors.Is(err, services.ErrGatewayWebhookDeliveryNotFound) {
+			utils.WriteErrorResponse(w, http.StatusNotFound, "Delivery not found")
+		} else {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to redeliver")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusAccepted, map[string]interface{}{
+		"message": "Delivery re-queued",
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+)
+
+// sseHeartbeatInterval keeps idle proxies from closing the tail connection.
+const sseHeartbeatInterval = 15 * time.Second
+
+// ObservabilityController defines the interface for observability HTTP handlers
+type ObservabilityController interface {
+	// TailTraces handles GET /orgs/{orgName}/observability/traces:tail
+	TailTraces(w http.ResponseWriter, r *http.Request)
+}
+
+type observabilityController struct {
+	observabilityService services.ObservabilityManagerService
+}
+
+// NewObservabilityController creates a new observability controller
+func NewObservabilityController(observabilityService services.ObservabilityManagerService) ObservabilityController {
+	return &observabilityController{
+		observabilityService: observabilityService,
+	}
+}
+
+// TailTraces handles GET /orgs/{orgName}/observability/traces:tail and upgrades
+// the connection to Server-Sent Events, streaming new traces as they are observed.
+func (c *observabilityController) TailTraces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Streaming unsupported")
+		return
+	}
+
+	query := r.URL.Query()
+	params := services.TailParams{
+		ServiceName: query.Get("serviceName"),
+		Status:      query.Get("status"),
+	}
+	if v := query.Get("minDurationMs"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			params.MinDurationMs = parsed
+		}
+	}
+	if v := query.Get("pollIntervalSeconds"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			params.PollInterval = time.Duration(parsed) * time.Second
+		}
+	}
+
+	events, err := c.observabilityService.TailTraces(ctx, params)
+	if err != nil {
+		log.Error("TailTraces: failed to start tail", "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to start trace tail")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			payload, err := json.Marshal(event.Trace)
+			if err != nil {
+				log.Error("TailTraces: failed to marshal trace event", "error", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: trace\ndata: %s\n\n", payload)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+)
+
+// RoutingPolicyController manages RoutingPolicy resources. Like
+// DriftController, it relies on the surrounding deployment to gate access,
+// since this service has no admin RBAC of its own.
+type RoutingPolicyController interface {
+	// CreateRoutingPolicy handles POST /api/internal/v1/organizations/:orgId/routing-policies
+	CreateRoutingPolicy(w http.ResponseWriter, r *http.Request)
+}
+
+type routingPolicyController struct {
+	internalService services.GatewayInternalService
+}
+
+// NewRoutingPolicyController creates a new routing policy controller.
+func NewRoutingPolicyController(internalService services.GatewayInternalService) RoutingPolicyController {
+	return &routingPolicyController{internalService: internalService}
+}
+
+// createRoutingPolicyRequest is the POST body for CreateRoutingPolicy.
+type createRoutingPolicyRequest struct {
+	GatewayUUID  uuid.UUID            `json:"gatewayUuid"`
+	Name         string               `json:"name"`
+	VirtualModel string               `json:"virtualModel"`
+	Rules        []models.RoutingRule `json:"rules"`
+}
+
+// CreateRoutingPolicy creates a RoutingPolicy bound to a single gateway.
+// Every rule's ProviderUUID must already be DEPLOYED to that gateway;
+// CreateRoutingPolicy rejects the request with 400 otherwise, rather than
+// silently dropping the offending rule the way GetAPIsByOrganization does
+// for a rule that goes stale after the policy was created.
+func (c *routingPolicyController) CreateRoutingPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := r.PathValue("orgId")
+	if orgID == "" {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Missing orgId")
+		return
+	}
+
+	var req createRoutingPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	policy := &models.RoutingPolicy{
+		UUID:             uuid.New(),
+		OrganizationName: orgID,
+		GatewayUUID:      req.GatewayUUID,
+		Name:             req.Name,
+		VirtualModel:     req.VirtualModel,
+		Rules:            req.Rules,
+	}
+
+	if err := c.internalService.CreateRoutingPolicy(ctx, policy); err != nil {
+		if errors.Is(err, utils.ErrInvalidInput) {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("CreateRoutingPolicy: failed to create routing policy", "orgId", orgID, "error", err)
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create routing policy")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, http.StatusCreated, policy)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/middleware/logger"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+)
+
+// ScoreRemoteWriteController exposes evaluator scores over the Prometheus
+// remote_read/remote_write wire protocol, so existing Prometheus/Grafana
+// stacks can scrape and federate them alongside other metrics.
+type ScoreRemoteWriteController interface {
+	// RemoteWrite handles POST /orgs/{orgName}/scores:remote-write
+	RemoteWrite(w http.ResponseWriter, r *http.Request)
+	// RemoteRead handles POST /orgs/{orgName}/scores:remote-read
+	RemoteRead(w http.ResponseWriter, r *http.Request)
+}
+
+type scoreRemoteWriteController struct {
+	scoreRemoteWriteService services.ScoreRemoteWriteService
+}
+
+// NewScoreRemoteWriteController creates a new score remote_read/remote_write controller
+func NewScoreRemoteWriteController(scoreRemoteWriteService services.ScoreRemoteWriteService) ScoreRemoteWriteController {
+	return &scoreRemoteWriteController{
+		scoreRemoteWriteService: scoreRemoteWriteService,
+	}
+}
+
+// RemoteWrite handles POST /orgs/{orgName}/scores:remote-write, decoding a
+// snappy-compressed protobuf WriteRequest body and upserting the scores it
+// carries.
+func (c *scoreRemoteWriteController) RemoteWrite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	if err := c.scoreRemoteWriteService.RemoteWrite(ctx, body); err != nil {
+		log.Error("RemoteWrite: failed to ingest samples", "error", err)
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Failed to ingest remote_write samples")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoteRead handles POST /orgs/{orgName}/scores:remote-read, decoding a
+// snappy-compressed protobuf ReadRequest body and responding with the
+// matching series in the same wire format.
+func (c *scoreRemoteWriteController) RemoteRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	respBody, err := c.scoreRemoteWriteService.RemoteRead(ctx, body)
+	if err != nil {
+		log.Error("RemoteRead: failed to answer query", "error", err)
+		utils.WriteErrorResponse(w, http.StatusBadRequest, "Failed to answer remote_read query")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Encoding", "snappy")
+	w.WriteHeader(http.StatusOK)
+	w.Write(respBody)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dbmigrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Add a per-evaluator enforcement_action column, defaulting existing rows to
+// "deny" so rollout behavior is unchanged until an evaluator is explicitly
+// demoted to "warn" or "dryrun".
+var migration009 = migration{
+	ID: 9,
+	Migrate: func(db *gorm.DB) error {
+		addEnforcementActionSQL := `
+			ALTER TABLE monitor_run_evaluators ADD COLUMN IF NOT EXISTS enforcement_action VARCHAR(16) NOT NULL DEFAULT 'deny';
+
+			ALTER TABLE monitor_run_evaluators ADD CONSTRAINT chk_enforcement_action
+				CHECK (enforcement_action IN ('dryrun', 'warn', 'deny'));
+
+			CREATE INDEX IF NOT EXISTS idx_monitor_run_evaluators_action ON monitor_run_evaluators(monitor_id, enforcement_action);
+		`
+		return db.Transaction(func(tx *gorm.DB) error {
+			return runSQL(tx, addEnforcementActionSQL)
+		})
+	},
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dbmigrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Add monitor_run_daily_summaries, the rollup target for ArchiveMonitorRuns
+var migration010 = migration{
+	ID: 10,
+	Migrate: func(db *gorm.DB) error {
+		addDailySummariesSQL := `
+			CREATE TABLE IF NOT EXISTS monitor_run_daily_summaries (
+				monitor_id UUID NOT NULL,
+				day DATE NOT NULL,
+				total_count INT NOT NULL DEFAULT 0,
+				count_by_status JSONB NOT NULL DEFAULT '{}',
+				p50_duration_ms BIGINT NOT NULL DEFAULT 0,
+				p95_duration_ms BIGINT NOT NULL DEFAULT 0,
+				first_failure TIMESTAMPTZ,
+				last_failure TIMESTAMPTZ,
+				PRIMARY KEY (monitor_id, day)
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_monitor_run_daily_summaries_monitor ON monitor_run_daily_summaries(monitor_id, day DESC);
+		`
+		return db.Transaction(func(tx *gorm.DB) error {
+			return runSQL(tx, addDailySummariesSQL)
+		})
+	},
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dbmigrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Add locked_by/lease_expires_at columns so ClaimPendingRuns can safely
+// distribute monitor runs across worker replicas
+var migration011 = migration{
+	ID: 11,
+	Migrate: func(db *gorm.DB) error {
+		addLeaseColumnsSQL := `
+			ALTER TABLE monitor_runs ADD COLUMN IF NOT EXISTS locked_by VARCHAR(128);
+			ALTER TABLE monitor_runs ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMPTZ;
+
+			CREATE INDEX IF NOT EXISTS idx_monitor_runs_status_lease ON monitor_runs(status, lease_expires_at);
+		`
+		return db.Transaction(func(tx *gorm.DB) error {
+			return runSQL(tx, addLeaseColumnsSQL)
+		})
+	},
+}
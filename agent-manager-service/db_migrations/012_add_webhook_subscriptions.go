@@ -0,0 +1,60 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dbmigrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Add webhook_subscriptions and webhook_deliveries, backing the signed
+// outbound webhook channel for MonitorRun outcomes
+var migration012 = migration{
+	ID: 12,
+	Migrate: func(db *gorm.DB) error {
+		addWebhookTablesSQL := `
+			CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+				id UUID PRIMARY KEY,
+				org_name VARCHAR(255) NOT NULL,
+				agent_name VARCHAR(255) NOT NULL DEFAULT '',
+				url TEXT NOT NULL,
+				secret TEXT NOT NULL,
+				active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_org_agent ON webhook_subscriptions(org_name, agent_name) WHERE active;
+
+			CREATE TABLE IF NOT EXISTS webhook_deliveries (
+				id UUID PRIMARY KEY,
+				subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id),
+				event_type VARCHAR(64) NOT NULL,
+				payload JSONB NOT NULL,
+				status VARCHAR(16) NOT NULL DEFAULT 'pending',
+				attempt_count INT NOT NULL DEFAULT 0,
+				last_error TEXT,
+				next_attempt_at TIMESTAMPTZ,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+			CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, status, created_at DESC);
+		`
+		return db.Transaction(func(tx *gorm.DB) error {
+			return runSQL(tx, addWebhookTablesSQL)
+		})
+	},
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dbmigrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Add gateway_webhook_endpoints and gateway_webhook_deliveries, backing the
+// signed push channel that replaces gateways polling GetAPIsByOrganization
+var migration013 = migration{
+	ID: 13,
+	Migrate: func(db *gorm.DB) error {
+		addGatewayWebhookTablesSQL := `
+			CREATE TABLE IF NOT EXISTS gateway_webhook_endpoints (
+				id UUID PRIMARY KEY,
+				gateway_id UUID NOT NULL UNIQUE,
+				org_name VARCHAR(255) NOT NULL,
+				url TEXT NOT NULL,
+				secret TEXT NOT NULL,
+				active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_gateway_webhook_endpoints_org ON gateway_webhook_endpoints(org_name) WHERE active;
+
+			CREATE TABLE IF NOT EXISTS gateway_webhook_deliveries (
+				id UUID PRIMARY KEY,
+				endpoint_id UUID NOT NULL REFERENCES gateway_webhook_endpoints(id),
+				delivery_id VARCHAR(26) NOT NULL UNIQUE,
+				event_type VARCHAR(64) NOT NULL,
+				payload JSONB NOT NULL,
+				status VARCHAR(16) NOT NULL DEFAULT 'pending',
+				attempt_count INT NOT NULL DEFAULT 0,
+				last_error TEXT,
+				next_attempt_at TIMESTAMPTZ,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_gateway_webhook_deliveries_due ON gateway_webhook_deliveries(status, next_attempt_at);
+			CREATE INDEX IF NOT EXISTS idx_gateway_webhook_deliveries_endpoint ON gateway_webhook_deliveries(endpoint_id, status, created_at DESC);
+		`
+		return db.Transaction(func(tx *gorm.DB) error {
+			return runSQL(tx, addGatewayWebhookTablesSQL)
+		})
+	},
+}
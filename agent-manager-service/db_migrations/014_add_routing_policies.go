@@ -0,0 +1,47 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dbmigrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Add routing_policies, backing RoutingPolicy's weighted/fallback routing
+// rules emitted alongside the per-provider docs in GetAPIsByOrganization
+var migration014 = migration{
+	ID: 14,
+	Migrate: func(db *gorm.DB) error {
+		addRoutingPoliciesSQL := `
+			CREATE TABLE IF NOT EXISTS routing_policies (
+				uuid UUID PRIMARY KEY,
+				organization_name VARCHAR(255) NOT NULL,
+				gateway_uuid UUID NOT NULL,
+				name VARCHAR(255) NOT NULL,
+				virtual_model VARCHAR(255) NOT NULL,
+				rules JSONB NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_routing_policies_org ON routing_policies(organization_name);
+			CREATE INDEX IF NOT EXISTS idx_routing_policies_gateway ON routing_policies(gateway_uuid);
+		`
+		return db.Transaction(func(tx *gorm.DB) error {
+			return runSQL(tx, addRoutingPoliciesSQL)
+		})
+	},
+}
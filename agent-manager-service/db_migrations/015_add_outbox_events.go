@@ -0,0 +1,52 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dbmigrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Add outbox_events, the transactional outbox OnPremiseAdapter writes to
+// instead of broadcasting WebSocket events directly, so a crash between the
+// LLMProvider/ProviderGatewayDeployment write and the broadcast can't leave a
+// gateway unaware of a deployment.
+var migration015 = migration{
+	ID: 15,
+	Migrate: func(db *gorm.DB) error {
+		addOutboxEventsSQL := `
+			CREATE TABLE IF NOT EXISTS outbox_events (
+				id UUID PRIMARY KEY,
+				gateway_uuid UUID NOT NULL,
+				event_type VARCHAR(64) NOT NULL,
+				idempotency_key VARCHAR(26) NOT NULL UNIQUE,
+				payload JSONB NOT NULL,
+				status VARCHAR(16) NOT NULL DEFAULT 'pending',
+				attempt_count INT NOT NULL DEFAULT 0,
+				last_error TEXT,
+				next_attempt_at TIMESTAMPTZ,
+				dispatched_at TIMESTAMPTZ,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_outbox_events_due ON outbox_events(status, next_attempt_at);
+			CREATE INDEX IF NOT EXISTS idx_outbox_events_gateway ON outbox_events(gateway_uuid, created_at);
+		`
+		return db.Transaction(func(tx *gorm.DB) error {
+			return runSQL(tx, addOutboxEventsSQL)
+		})
+	},
+}
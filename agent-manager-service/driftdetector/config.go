@@ -0,0 +1,35 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package driftdetector periodically reconciles what agent-manager has
+// recorded in models.ProviderGatewayDeployment against what each gateway
+// reports as actually running via its own lightweight GET /status endpoint,
+// closing the gap where CreateGatewayDeployment records DEPLOYED once and
+// never re-verifies. Disagreements are recorded as
+// models.DeploymentDriftEvent rows, and the affected deployment is marked
+// DRIFTED with a short human summary in ErrorMessage.
+package driftdetector
+
+import "time"
+
+// Config configures one Detector's reconciliation loop.
+type Config struct {
+	// Interval is how often every gateway is polled and reconciled.
+	Interval time.Duration
+
+	// RequestTimeout bounds a single gateway's GET /status call.
+	RequestTimeout time.Duration
+}
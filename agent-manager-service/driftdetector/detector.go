@@ -0,0 +1,254 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package driftdetector
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/db"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// ConfigRenderer renders the exact configuration a gateway is expected to
+// be running for one deployment, matching
+// services.GatewayInternalService's (unexported) convertLLMProviderToAPIConfig,
+// so expectedConfigHash is computed from the same content a gateway
+// actually receives. Declared as an interface, rather than this package
+// depending on services directly, to avoid a services<->driftdetector
+// import cycle: the controller wiring these together passes the concrete
+// GatewayInternalService in as a ConfigRenderer.
+type ConfigRenderer interface {
+	RenderConfig(provider models.LLMProvider, revision *models.ProviderRevision, deployment models.ProviderGatewayDeployment) map[string]interface{}
+}
+
+// Detector periodically reconciles ProviderGatewayDeployment rows against
+// what each gateway's GET /status reports as actually running.
+type Detector struct {
+	cfg      Config
+	reporter StatusReporter
+	render   ConfigRenderer
+	logger   *slog.Logger
+}
+
+// NewDetector returns a Detector that polls every gateway via reporter at
+// cfg.Interval, computing each deployment's expected config hash with
+// render.
+func NewDetector(cfg Config, reporter StatusReporter, render ConfigRenderer, logger *slog.Logger) *Detector {
+	return &Detector{cfg: cfg, reporter: reporter, render: render, logger: logger}
+}
+
+// Run reconciles every gateway at cfg.Interval until ctx is cancelled.
+func (d *Detector) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.ReconcileAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileAll reconciles every gateway in the database, one at a time so
+// one gateway's unreachable /status endpoint doesn't block the rest.
+func (d *Detector) ReconcileAll(ctx context.Context) {
+	dbInstance := db.DB(ctx)
+
+	var gateways []models.Gateway
+	if err := dbInstance.Find(&gateways).Error; err != nil {
+		d.logger.Error("driftdetector: failed to list gateways", "error", err)
+		return
+	}
+
+	for _, gateway := range gateways {
+		if err := d.ReconcileGateway(ctx, gateway); err != nil {
+			d.logger.Error("driftdetector: failed to reconcile gateway", "gateway", gateway.UUID, "error", err)
+		}
+	}
+}
+
+// ReconcileGateway diffs gateway's DEPLOYED ProviderGatewayDeployment rows
+// against what it self-reports via StatusReporter, recording a
+// DeploymentDriftEvent for each mismatch and marking the affected row
+// DRIFTED with a short summary in ErrorMessage.
+func (d *Detector) ReconcileGateway(ctx context.Context, gateway models.Gateway) error {
+	dbInstance := db.DB(ctx)
+
+	var recorded []models.ProviderGatewayDeployment
+	if err := dbInstance.
+		Where("gateway_uuid = ? AND status = ?", gateway.UUID, "DEPLOYED").
+		Find(&recorded).Error; err != nil {
+		return fmt.Errorf("failed to list recorded deployments: %w", err)
+	}
+
+	reported, err := d.reporter.FetchStatus(ctx, gateway)
+	if err != nil {
+		return fmt.Errorf("failed to fetch gateway status: %w", err)
+	}
+
+	reportedByAPIID := make(map[string]ReportedDeployment, len(reported))
+	for _, r := range reported {
+		reportedByAPIID[r.APIID] = r
+	}
+
+	seen := make(map[string]bool, len(recorded))
+
+	for _, deployment := range recorded {
+		apiID := deployment.ProviderUUID.String()
+		seen[apiID] = true
+
+		reportedDeployment, ok := reportedByAPIID[apiID]
+		if !ok {
+			d.recordDrift(ctx, gateway.UUID, deployment, models.DriftMissingOnGateway,
+				fmt.Sprintf("provider %s is recorded DEPLOYED but the gateway did not report it", apiID))
+			continue
+		}
+
+		expectedHash, err := d.expectedConfigHash(ctx, deployment)
+		if err != nil {
+			d.logger.Error("driftdetector: failed to compute expected config hash", "provider", apiID, "error", err)
+			continue
+		}
+
+		switch {
+		case reportedDeployment.RevisionUUID != deployment.RevisionUUID.String() || reportedDeployment.ConfigHash != expectedHash:
+			d.recordDrift(ctx, gateway.UUID, deployment, models.DriftHashMismatch,
+				fmt.Sprintf("gateway reports configHash %s at revision %s, expected %s at revision %s",
+					reportedDeployment.ConfigHash, reportedDeployment.RevisionUUID, expectedHash, deployment.RevisionUUID))
+		case reportedDeployment.Status != deployment.Status:
+			d.recordDrift(ctx, gateway.UUID, deployment, models.DriftStatusMismatch,
+				fmt.Sprintf("gateway reports status %s, agent-manager recorded %s", reportedDeployment.Status, deployment.Status))
+		}
+	}
+
+	for apiID, reportedDeployment := range reportedByAPIID {
+		if seen[apiID] {
+			continue
+		}
+
+		providerUUID, err := uuid.Parse(apiID)
+		if err != nil {
+			d.logger.Warn("driftdetector: gateway reported a non-UUID apiID", "gateway", gateway.UUID, "apiID", apiID)
+			continue
+		}
+
+		d.recordDriftEvent(ctx, gateway.UUID, providerUUID, models.DriftUnexpectedOnGateway,
+			fmt.Sprintf("gateway reports provider %s (status %s) with no matching deployment record", apiID, reportedDeployment.Status))
+	}
+
+	return nil
+}
+
+// expectedConfigHash renders deployment's canonical configuration the same
+// way GetAPI/GetBundle do (via ConfigRenderer) and hashes the result, so it
+// can be compared against what the gateway self-reports as configHash.
+func (d *Detector) expectedConfigHash(ctx context.Context, deployment models.ProviderGatewayDeployment) (string, error) {
+	dbInstance := db.DB(ctx)
+
+	var provider models.LLMProvider
+	if err := dbInstance.Where("uuid = ?", deployment.ProviderUUID).First(&provider).Error; err != nil {
+		return "", fmt.Errorf("failed to load provider %s: %w", deployment.ProviderUUID, err)
+	}
+
+	var revision *models.ProviderRevision
+	if deployment.RevisionUUID != uuid.Nil {
+		var r models.ProviderRevision
+		if err := dbInstance.Where("uuid = ?", deployment.RevisionUUID).First(&r).Error; err != nil {
+			return "", fmt.Errorf("failed to load revision %s: %w", deployment.RevisionUUID, err)
+		}
+		revision = &r
+	}
+
+	config := d.render.RenderConfig(provider, revision, deployment)
+
+	// yaml.Marshal sorts map keys, giving the "keys sorted" stable encoding
+	// this hash needs regardless of Go's randomized map iteration order.
+	yamlData, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	sum := sha256.Sum256(yamlData)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// recordDrift writes a DeploymentDriftEvent for deployment and marks it
+// DRIFTED, so the next GetAPI/GetBundle call surfaces the problem instead
+// of continuing to report it as DEPLOYED.
+func (d *Detector) recordDrift(ctx context.Context, gatewayUUID uuid.UUID, deployment models.ProviderGatewayDeployment, kind models.DriftEventKind, summary string) {
+	d.recordDriftEvent(ctx, gatewayUUID, deployment.ProviderUUID, kind, summary)
+
+	dbInstance := db.DB(ctx)
+	if err := dbInstance.Model(&models.ProviderGatewayDeployment{}).
+		Where("id = ?", deployment.ID).
+		Updates(map[string]interface{}{
+			"status":        "DRIFTED",
+			"error_message": summary,
+		}).Error; err != nil {
+		d.logger.Error("driftdetector: failed to mark deployment drifted", "provider", deployment.ProviderUUID, "error", err)
+	}
+}
+
+// recordDriftEvent writes a DeploymentDriftEvent row without touching any
+// ProviderGatewayDeployment row — used for DriftUnexpectedOnGateway, which
+// has no corresponding recorded deployment to mark.
+func (d *Detector) recordDriftEvent(ctx context.Context, gatewayUUID uuid.UUID, providerUUID uuid.UUID, kind models.DriftEventKind, summary string) {
+	dbInstance := db.DB(ctx)
+
+	event := &models.DeploymentDriftEvent{
+		UUID:         uuid.New(),
+		GatewayUUID:  gatewayUUID,
+		ProviderUUID: providerUUID,
+		Kind:         kind,
+		Summary:      summary,
+		DetectedAt:   time.Now(),
+	}
+	if err := dbInstance.Create(event).Error; err != nil {
+		d.logger.Error("driftdetector: failed to record drift event", "gateway", gatewayUUID, "provider", providerUUID, "kind", kind, "error", err)
+	}
+}
+
+// ListEvents returns the most recent drift events for gatewayID, newest
+// first, capped at limit. gatewayID may be uuid.Nil to list across every
+// gateway.
+func (d *Detector) ListEvents(ctx context.Context, gatewayID uuid.UUID, limit int) ([]models.DeploymentDriftEvent, error) {
+	dbInstance := db.DB(ctx)
+
+	query := dbInstance.Order("detected_at DESC").Limit(limit)
+	if gatewayID != uuid.Nil {
+		query = query.Where("gateway_uuid = ?", gatewayID)
+	}
+
+	var events []models.DeploymentDriftEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list drift events: %w", err)
+	}
+
+	return events, nil
+}
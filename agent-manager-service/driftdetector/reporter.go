@@ -0,0 +1,84 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package driftdetector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// ReportedDeployment is one entry of a gateway's GET /status response: what
+// it believes is actually deployed and running right now, independent of
+// what agent-manager's ProviderGatewayDeployment rows say.
+type ReportedDeployment struct {
+	APIID        string `json:"apiID"`
+	RevisionUUID string `json:"revisionUUID"`
+	ConfigHash   string `json:"configHash"`
+	Status       string `json:"status"`
+}
+
+// StatusReporter fetches a gateway's current self-reported deployment
+// state. Declared as an interface, rather than Detector calling HTTP
+// directly, so a non-HTTP adapter (e.g. the WebSocket-based on-premise
+// gateway, which could answer a status query over its existing connection)
+// can satisfy it too, and so tests can exercise Detector against a fake.
+type StatusReporter interface {
+	FetchStatus(ctx context.Context, gateway models.Gateway) ([]ReportedDeployment, error)
+}
+
+// HTTPStatusReporter fetches status over HTTP from gateway.StatusURL +
+// "/status", the lightweight endpoint every gateway adapter is expected to
+// expose.
+type HTTPStatusReporter struct {
+	httpc *http.Client
+}
+
+// NewHTTPStatusReporter returns an HTTPStatusReporter bounding each request
+// to timeout.
+func NewHTTPStatusReporter(timeout time.Duration) *HTTPStatusReporter {
+	return &HTTPStatusReporter{httpc: &http.Client{Timeout: timeout}}
+}
+
+// FetchStatus implements StatusReporter.
+func (r *HTTPStatusReporter) FetchStatus(ctx context.Context, gateway models.Gateway) ([]ReportedDeployment, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gateway.StatusURL+"/status", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build status request: %w", err)
+	}
+
+	resp, err := r.httpc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach gateway status endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gateway status endpoint returned %d", resp.StatusCode)
+	}
+
+	var reported []ReportedDeployment
+	if err := json.NewDecoder(resp.Body).Decode(&reported); err != nil {
+		return nil, fmt.Errorf("failed to decode gateway status response: %w", err)
+	}
+
+	return reported, nil
+}
@@ -18,6 +18,7 @@ package onpremise
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -28,7 +29,9 @@ import (
 
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/gateway"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
 )
 
 // OnPremiseAdapter implements IGatewayAdapter for on-premise deployments
@@ -37,6 +40,7 @@ type OnPremiseAdapter struct {
 	db            *gorm.DB
 	config        gateway.AdapterConfig
 	eventsService services.GatewayEventsService
+	outboxRepo    repositories.OutboxRepository
 	logger        *slog.Logger
 }
 
@@ -45,18 +49,52 @@ func NewOnPremiseAdapter(
 	config gateway.AdapterConfig,
 	db *gorm.DB,
 	eventsService services.GatewayEventsService,
+	outboxRepo repositories.OutboxRepository,
 	logger *slog.Logger,
 ) (gateway.IGatewayAdapter, error) {
 	adapter := &OnPremiseAdapter{
 		config:        config,
 		db:            db,
 		eventsService: eventsService,
+		outboxRepo:    outboxRepo,
 		logger:        logger,
 	}
 
 	return adapter, nil
 }
 
+// enqueueOutboxEvent records event as a pending outbox row using tx, so it
+// commits atomically with the LLMProvider/ProviderGatewayDeployment write tx
+// already belongs to. OutboxDispatcher delivers it independently afterward,
+// so DeployProvider/UpdateProvider/UndeployProvider no longer block on (or
+// can lose an event to a crash racing) the WebSocket broadcast itself.
+func (a *OnPremiseAdapter) enqueueOutboxEvent(tx *gorm.DB, gatewayUUID uuid.UUID, eventType models.OutboxEventType, payload interface{}) error {
+	return enqueueOutboxEvent(a.outboxRepo, tx, gatewayUUID, eventType, payload)
+}
+
+// enqueueOutboxEvent records event as a pending outbox row using tx (or
+// repo's own db if tx is nil - see OutboxRepository.Create), read
+// independently by OutboxDispatcher. Shared between OnPremiseAdapter, which
+// enqueues from within a write transaction, and OnPremiseReconciler, which
+// enqueues corrections outside of one.
+func enqueueOutboxEvent(repo repositories.OutboxRepository, tx *gorm.DB, gatewayUUID uuid.UUID, eventType models.OutboxEventType, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox event payload: %w", err)
+	}
+
+	event := &models.OutboxEvent{
+		ID:             uuid.New(),
+		GatewayUUID:    gatewayUUID,
+		EventType:      eventType,
+		IdempotencyKey: utils.NewULID(),
+		Payload:        string(encoded),
+		Status:         models.OutboxEventPending,
+		CreatedAt:      time.Now(),
+	}
+	return repo.Create(tx, event)
+}
+
 // GetAdapterType returns the adapter type identifier
 func (a *OnPremiseAdapter) GetAdapterType() string {
 	return "on-premise"
@@ -76,13 +114,41 @@ func (a *OnPremiseAdapter) ValidateGatewayEndpoint(ctx context.Context, controlP
 	return nil
 }
 
-// CheckHealth returns gateway health status based on WebSocket connection
+// gatewayHeartbeatStaleAfter is how long since a gateway's last WebSocket
+// heartbeat before CheckHealth reports it STALE rather than ACTIVE, even
+// though the connection itself hasn't dropped.
+const gatewayHeartbeatStaleAfter = 90 * time.Second
+
+// providerStatusRequestTimeout bounds GetProviderStatus's synchronous
+// RequestStatus round trip, so an unresponsive gateway falls back to the
+// recorded DB row instead of blocking the caller indefinitely.
+const providerStatusRequestTimeout = 5 * time.Second
+
+// CheckHealth returns gateway health status based on its WebSocket connection.
+// On-premise gateways aren't addressed by URL, so controlPlaneURL is used as
+// the gateway identifier the same way DeployProvider's gatewayID is.
 func (a *OnPremiseAdapter) CheckHealth(ctx context.Context, controlPlaneURL string) (*gateway.HealthStatus, error) {
-	// For WebSocket-based communication, health is determined by active connections
-	// This is a simplified health check - in production, you would query the WebSocket manager
+	gatewayID := controlPlaneURL
+
+	if !a.eventsService.IsConnected(gatewayID) {
+		return &gateway.HealthStatus{
+			Status:    "DISCONNECTED",
+			CheckedAt: time.Now(),
+		}, nil
+	}
+
+	status := "ACTIVE"
+	var heartbeatAge time.Duration
+	if lastHeartbeat, ok := a.eventsService.LastHeartbeat(gatewayID); ok {
+		heartbeatAge = time.Since(lastHeartbeat)
+		if heartbeatAge > gatewayHeartbeatStaleAfter {
+			status = "STALE"
+		}
+	}
+
 	return &gateway.HealthStatus{
-		Status:       "ACTIVE",
-		ResponseTime: 0,
+		Status:       status,
+		ResponseTime: heartbeatAge,
 		CheckedAt:    time.Now(),
 	}, nil
 }
@@ -96,7 +162,6 @@ func (a *OnPremiseAdapter) DeployProvider(ctx context.Context, gatewayID string,
 		return nil, fmt.Errorf("invalid gateway ID: %w", err)
 	}
 
-	// 1. Create provider record in database
 	providerUUID := uuid.New()
 	provider := &models.LLMProvider{
 		UUID:          providerUUID,
@@ -107,25 +172,6 @@ func (a *OnPremiseAdapter) DeployProvider(ctx context.Context, gatewayID string,
 		Status:        "APPROVED",
 	}
 
-	if err := a.db.WithContext(ctx).Create(provider).Error; err != nil {
-		return nil, fmt.Errorf("failed to create provider: %w", err)
-	}
-
-	// 2. Create deployment record with PENDING status
-	deployment := &models.ProviderGatewayDeployment{
-		ProviderUUID:         providerUUID,
-		GatewayUUID:          gatewayUUID,
-		DeploymentID:         providerUUID.String(),
-		Environment:          "production",
-		ConfigurationVersion: 1,
-		Status:               "PENDING",
-	}
-
-	if err := a.db.WithContext(ctx).Create(deployment).Error; err != nil {
-		return nil, fmt.Errorf("failed to create deployment record: %w", err)
-	}
-
-	// 3. Broadcast api.deployed event (same as api-platform)
 	// Using api.deployed allows gateways to process LLM providers without code changes
 	event := &models.DeploymentEvent{
 		ApiId:        providerUUID.String(), // apiId is the provider UUID
@@ -134,9 +180,47 @@ func (a *OnPremiseAdapter) DeployProvider(ctx context.Context, gatewayID string,
 		Environment:  "production",
 	}
 
-	if err := a.eventsService.BroadcastLLMProviderDeployed(gatewayID, event); err != nil {
-		a.logger.Error("Failed to broadcast deployment event", "error", err)
-		return nil, fmt.Errorf("failed to broadcast deployment: %w", err)
+	// The provider, its first revision, the deployment record, and the
+	// outbox event recording the broadcast-to-be all commit atomically, so a
+	// crash after this transaction can never leave a deployment the gateway
+	// was never told about.
+	err = a.db.Transaction(func(tx *gorm.DB) error {
+		// 1. Create provider record in database
+		if err := tx.WithContext(ctx).Create(provider).Error; err != nil {
+			return fmt.Errorf("failed to create provider: %w", err)
+		}
+
+		// 2. Snapshot this configuration as the provider's first revision, so
+		// the deployment below can pin to it instead of the live provider row.
+		revision, err := services.CreateProviderRevision(ctx, tx, provider)
+		if err != nil {
+			return fmt.Errorf("failed to create provider revision: %w", err)
+		}
+
+		// 3. Create deployment record with PENDING status
+		deployment := &models.ProviderGatewayDeployment{
+			ProviderUUID: providerUUID,
+			GatewayUUID:  gatewayUUID,
+			DeploymentID: providerUUID.String(),
+			Environment:  "production",
+			RevisionUUID: revision.UUID,
+			Type:         models.DeploymentTypeDefault,
+			Status:       "PENDING",
+		}
+		if err := tx.WithContext(ctx).Create(deployment).Error; err != nil {
+			return fmt.Errorf("failed to create deployment record: %w", err)
+		}
+
+		// 4. Record the api.deployed broadcast in the outbox instead of
+		// sending it now - OutboxDispatcher delivers it once this commits.
+		if err := a.enqueueOutboxEvent(tx, gatewayUUID, models.OutboxEventLLMProviderDeployed, event); err != nil {
+			return fmt.Errorf("failed to enqueue deployment event: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &gateway.ProviderDeploymentResult{
@@ -154,22 +238,12 @@ func (a *OnPremiseAdapter) UpdateProvider(ctx context.Context, gatewayID string,
 	if err != nil {
 		return nil, fmt.Errorf("invalid provider ID: %w", err)
 	}
-
-	// 1. Update provider in database
-	updates := map[string]interface{}{
-		"display_name":  config.DisplayName,
-		"template":      config.Template,
-		"configuration": config.Configuration,
-	}
-
-	if err := a.db.WithContext(ctx).
-		Model(&models.LLMProvider{}).
-		Where("uuid = ?", providerUUID).
-		Updates(updates).Error; err != nil {
-		return nil, fmt.Errorf("failed to update provider: %w", err)
+	gatewayUUID, err := uuid.Parse(gatewayID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway ID: %w", err)
 	}
 
-	// 2. Broadcast api.deployed event (same as api-platform for updates)
+	// Broadcast api.deployed event (same as api-platform for updates)
 	event := &models.DeploymentEvent{
 		ApiId:        providerID,
 		DeploymentID: providerID,
@@ -177,8 +251,46 @@ func (a *OnPremiseAdapter) UpdateProvider(ctx context.Context, gatewayID string,
 		Environment:  "production",
 	}
 
-	if err := a.eventsService.BroadcastLLMProviderDeployed(gatewayID, event); err != nil {
-		return nil, fmt.Errorf("failed to broadcast update: %w", err)
+	// The provider update, its new revision, and the outbox event recording
+	// the broadcast-to-be all commit atomically.
+	err = a.db.Transaction(func(tx *gorm.DB) error {
+		// 1. Update provider in database
+		updates := map[string]interface{}{
+			"display_name":  config.DisplayName,
+			"template":      config.Template,
+			"configuration": config.Configuration,
+		}
+		if err := tx.WithContext(ctx).
+			Model(&models.LLMProvider{}).
+			Where("uuid = ?", providerUUID).
+			Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update provider: %w", err)
+		}
+
+		// 2. Snapshot the updated configuration as a new revision, so any
+		// deployment still pointing at the prior RevisionUUID keeps rendering
+		// the old configuration until it is explicitly redeployed or rolled
+		// forward onto this one.
+		revisedProvider := &models.LLMProvider{
+			UUID:          providerUUID,
+			DisplayName:   config.DisplayName,
+			Template:      config.Template,
+			Configuration: config.Configuration,
+		}
+		if _, err := services.CreateProviderRevision(ctx, tx, revisedProvider); err != nil {
+			return fmt.Errorf("failed to create provider revision: %w", err)
+		}
+
+		// 3. Record the api.deployed broadcast in the outbox instead of
+		// sending it now - OutboxDispatcher delivers it once this commits.
+		if err := a.enqueueOutboxEvent(tx, gatewayUUID, models.OutboxEventLLMProviderDeployed, event); err != nil {
+			return fmt.Errorf("failed to enqueue update event: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &gateway.ProviderDeploymentResult{
@@ -202,25 +314,32 @@ func (a *OnPremiseAdapter) UndeployProvider(ctx context.Context, gatewayID strin
 		return fmt.Errorf("invalid gateway ID: %w", err)
 	}
 
-	// 1. Delete deployment record
-	if err := a.db.WithContext(ctx).
-		Where("provider_uuid = ? AND gateway_uuid = ?", providerUUID, gatewayUUID).
-		Delete(&models.ProviderGatewayDeployment{}).Error; err != nil {
-		return fmt.Errorf("failed to delete deployment: %w", err)
-	}
-
-	// 2. Broadcast api.undeployed event (same as api-platform)
+	// api.undeployed event (same as api-platform)
 	event := &models.APIUndeploymentEvent{
 		ApiId:       providerID,
 		Vhost:       "",
 		Environment: "production",
 	}
 
-	if err := a.eventsService.BroadcastLLMProviderUndeployed(gatewayID, event); err != nil {
-		return fmt.Errorf("failed to broadcast undeployment: %w", err)
-	}
+	// The deployment-record delete and the outbox event recording the
+	// broadcast-to-be commit atomically, so a crash between them can't leave
+	// the gateway running a provider the control plane thinks is gone.
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		// 1. Delete deployment record
+		if err := tx.WithContext(ctx).
+			Where("provider_uuid = ? AND gateway_uuid = ?", providerUUID, gatewayUUID).
+			Delete(&models.ProviderGatewayDeployment{}).Error; err != nil {
+			return fmt.Errorf("failed to delete deployment: %w", err)
+		}
 
-	return nil
+		// 2. Record the api.undeployed broadcast in the outbox instead of
+		// sending it now - OutboxDispatcher delivers it once this commits.
+		if err := a.enqueueOutboxEvent(tx, gatewayUUID, models.OutboxEventLLMProviderUndeployed, event); err != nil {
+			return fmt.Errorf("failed to enqueue undeployment event: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // GetProviderStatus retrieves the status of a provider deployment on a gateway
@@ -259,6 +378,22 @@ func (a *OnPremiseAdapter) GetProviderStatus(ctx context.Context, gatewayID stri
 		status.DeployedAt = deployment.DeployedAt
 	}
 
+	// Prefer asking the gateway directly over its WebSocket connection for
+	// whether the provider is actually loaded; the DB row above only
+	// reflects what agent-manager last recorded, not reality. Fall back to
+	// it unmodified if the gateway is offline or doesn't answer in time.
+	if a.eventsService.IsConnected(gatewayID) {
+		report, err := a.eventsService.RequestStatus(ctx, gatewayID, providerID, providerStatusRequestTimeout)
+		if err != nil {
+			a.logger.Warn("Gateway did not answer provider status request, falling back to recorded status",
+				"gatewayID", gatewayID, "providerID", providerID, "error", err)
+		} else if report.Loaded {
+			status.Status = report.Status
+		} else {
+			status.Status = "MISSING"
+		}
+	}
+
 	return status, nil
 }
 
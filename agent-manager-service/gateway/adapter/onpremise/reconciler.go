@@ -0,0 +1,124 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package onpremise
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/driftdetector"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
+)
+
+// OnPremiseReconciler wraps a driftdetector.Detector configured with a
+// WebSocketStatusReporter: every cycle it diffs ProviderGatewayDeployment
+// rows against what each connected gateway reports, then, unlike Detector
+// on its own (which only records the disagreement), enqueues an
+// api.deployed/api.undeployed correction through the outbox for every drift
+// event the cycle just produced, so a gateway that missed an event gets
+// nudged back in sync without an operator re-running the deployment by
+// hand. WebSocketStatusReporter only asks about deployments recorded as
+// DEPLOYED, so a provider the gateway loaded without such a row is not
+// reported and not corrected.
+type OnPremiseReconciler struct {
+	detector   *driftdetector.Detector
+	outboxRepo repositories.OutboxRepository
+	interval   time.Duration
+	logger     *slog.Logger
+}
+
+// NewOnPremiseReconciler returns a reconciler that runs detector and
+// corrects whatever drift it finds every interval.
+func NewOnPremiseReconciler(detector *driftdetector.Detector, outboxRepo repositories.OutboxRepository, interval time.Duration, logger *slog.Logger) *OnPremiseReconciler {
+	return &OnPremiseReconciler{
+		detector:   detector,
+		outboxRepo: outboxRepo,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+// Run reconciles and corrects drift every r.interval until ctx is cancelled.
+func (r *OnPremiseReconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.ReconcileAndCorrect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileAndCorrect runs one detector cycle, then enqueues a correction
+// for every drift event the cycle just recorded. Events are identified by
+// having been detected no earlier than this cycle started, since Detector
+// doesn't return the events it wrote.
+func (r *OnPremiseReconciler) ReconcileAndCorrect(ctx context.Context) {
+	cycleStart := time.Now()
+	r.detector.ReconcileAll(ctx)
+
+	events, err := r.detector.ListEvents(ctx, uuid.Nil, 500)
+	if err != nil {
+		r.logger.Error("onpremise reconciler: failed to list drift events", "error", err)
+		return
+	}
+
+	for _, event := range events {
+		if event.DetectedAt.Before(cycleStart) {
+			continue
+		}
+		if err := r.correct(event); err != nil {
+			r.logger.Error("onpremise reconciler: failed to enqueue correction",
+				"event", event.UUID, "kind", event.Kind, "error", err)
+		}
+	}
+}
+
+// correct enqueues the outbox event that should bring the gateway back in
+// line with event's kind. A nil tx is fine here: unlike DeployProvider's
+// writes, there's no accompanying DB row for this event to commit with.
+func (r *OnPremiseReconciler) correct(event models.DeploymentDriftEvent) error {
+	providerID := event.ProviderUUID.String()
+
+	switch event.Kind {
+	case models.DriftMissingOnGateway, models.DriftHashMismatch, models.DriftStatusMismatch:
+		deployEvent := &models.DeploymentEvent{
+			ApiId:        providerID,
+			DeploymentID: providerID,
+			Environment:  "production",
+		}
+		return enqueueOutboxEvent(r.outboxRepo, nil, event.GatewayUUID, models.OutboxEventLLMProviderDeployed, deployEvent)
+	case models.DriftUnexpectedOnGateway:
+		undeployEvent := &models.APIUndeploymentEvent{
+			ApiId:       providerID,
+			Environment: "production",
+		}
+		return enqueueOutboxEvent(r.outboxRepo, nil, event.GatewayUUID, models.OutboxEventLLMProviderUndeployed, undeployEvent)
+	default:
+		return fmt.Errorf("unknown drift event kind: %q", event.Kind)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package onpremise
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/db"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/driftdetector"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/services"
+)
+
+// WebSocketStatusReporter implements driftdetector.StatusReporter for
+// on-premise gateways by asking each over its existing WebSocket connection,
+// one RequestStatus RPC per recorded deployment, instead of the single
+// GET /status response HTTPStatusReporter expects - on-premise gateways
+// don't expose one.
+type WebSocketStatusReporter struct {
+	eventsService services.GatewayEventsService
+	timeout       time.Duration
+}
+
+// NewWebSocketStatusReporter returns a WebSocketStatusReporter bounding each
+// provider's RequestStatus round trip to timeout.
+func NewWebSocketStatusReporter(eventsService services.GatewayEventsService, timeout time.Duration) *WebSocketStatusReporter {
+	return &WebSocketStatusReporter{eventsService: eventsService, timeout: timeout}
+}
+
+// FetchStatus implements driftdetector.StatusReporter.
+func (r *WebSocketStatusReporter) FetchStatus(ctx context.Context, gateway models.Gateway) ([]driftdetector.ReportedDeployment, error) {
+	gatewayID := gateway.UUID.String()
+	if !r.eventsService.IsConnected(gatewayID) {
+		return nil, fmt.Errorf("gateway %s is not connected", gatewayID)
+	}
+
+	var deployments []models.ProviderGatewayDeployment
+	if err := db.DB(ctx).
+		Where("gateway_uuid = ? AND status = ?", gateway.UUID, "DEPLOYED").
+		Find(&deployments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list recorded deployments: %w", err)
+	}
+
+	reported := make([]driftdetector.ReportedDeployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		providerID := deployment.ProviderUUID.String()
+		report, err := r.eventsService.RequestStatus(ctx, gatewayID, providerID, r.timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to request status for provider %s: %w", providerID, err)
+		}
+		if !report.Loaded {
+			continue
+		}
+		reported = append(reported, driftdetector.ReportedDeployment{
+			APIID:        report.ProviderID,
+			RevisionUUID: report.RevisionUUID,
+			ConfigHash:   report.ConfigHash,
+			Status:       report.Status,
+		})
+	}
+
+	return reported, nil
+}
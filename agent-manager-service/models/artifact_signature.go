@@ -0,0 +1,36 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+// ArtifactSignature is a detached signature over a packaged artifact's
+// canonical manifest, embedded in its ZIP (as signature.sig/signer.pub) so
+// a gateway can verify integrity before applying the YAML inside.
+type ArtifactSignature struct {
+	KID       string `json:"kid"`
+	Algorithm string `json:"algorithm"`
+	Signature string `json:"signature"` // base64-encoded
+}
+
+// PublicSigningKey is one entry in the GET /api/internal/v1/trust/keys
+// response: a key an organization has signed artifacts with, identified by
+// kid so gateways can verify artifacts signed before a rotation.
+type PublicSigningKey struct {
+	KID       string `json:"kid"`
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"publicKey"` // base64-encoded
+	Active    bool   `json:"active"`
+}
@@ -25,14 +25,18 @@ import (
 // CatalogEntry represents a resource in the catalog
 // This model maps to the artifacts table with in_catalog filter
 type CatalogEntry struct {
-	UUID             uuid.UUID `gorm:"column:uuid;primaryKey" json:"uuid"`
-	Handle           string    `gorm:"column:handle;not null" json:"handle"`
-	Name             string    `gorm:"column:name;not null" json:"name"`
-	Version          string    `gorm:"column:version;not null" json:"version"`
-	Kind             string    `gorm:"column:kind;not null" json:"kind"`
-	InCatalog        bool      `gorm:"column:in_catalog" json:"inCatalog"`
-	OrganizationUUID uuid.UUID `gorm:"column:organization_uuid;not null" json:"-"`
-	CreatedAt        time.Time `gorm:"column:created_at" json:"createdAt"`
+	UUID             uuid.UUID     `gorm:"column:uuid;primaryKey" json:"uuid"`
+	Handle           string        `gorm:"column:handle;not null" json:"handle"`
+	Name             string        `gorm:"column:name;not null" json:"name"`
+	Description      string        `gorm:"column:description" json:"description,omitempty"`
+	Version          string        `gorm:"column:version;not null" json:"version"`
+	Kind             string        `gorm:"column:kind;not null" json:"kind"`
+	Tags             []string      `gorm:"column:tags;serializer:json" json:"tags,omitempty"`
+	Publisher        string        `gorm:"column:publisher" json:"publisher,omitempty"`
+	Status           CatalogStatus `gorm:"column:status;not null;default:published" json:"status"`
+	InCatalog        bool          `gorm:"column:in_catalog" json:"inCatalog"`
+	OrganizationUUID uuid.UUID     `gorm:"column:organization_uuid;not null" json:"-"`
+	CreatedAt        time.Time     `gorm:"column:created_at" json:"createdAt"`
 }
 
 // TableName returns the table name for catalog queries
@@ -46,3 +50,13 @@ const (
 	CatalogKindAgent       = "agent"
 	CatalogKindMCP         = "mcp"
 )
+
+// CatalogStatus is the publication state of a catalog entry, used as a
+// facet/filter in CatalogRepository.Search.
+type CatalogStatus string
+
+const (
+	CatalogStatusPublished  CatalogStatus = "published"
+	CatalogStatusDeprecated CatalogStatus = "deprecated"
+	CatalogStatusDraft      CatalogStatus = "draft"
+)
@@ -0,0 +1,62 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DriftEventKind classifies how a gateway's self-reported deployment state
+// disagreed with its ProviderGatewayDeployment record. See the
+// driftdetector package, which produces these.
+type DriftEventKind string
+
+const (
+	// DriftMissingOnGateway means agent-manager has a DEPLOYED record the
+	// gateway did not report at all.
+	DriftMissingOnGateway DriftEventKind = "MISSING_ON_GATEWAY"
+	// DriftUnexpectedOnGateway means the gateway reported a provider with
+	// no matching deployment record.
+	DriftUnexpectedOnGateway DriftEventKind = "UNEXPECTED_ON_GATEWAY"
+	// DriftHashMismatch means the gateway is running a different revision
+	// or configuration than the one agent-manager recorded.
+	DriftHashMismatch DriftEventKind = "HASH_MISMATCH"
+	// DriftStatusMismatch means the gateway's reported status differs from
+	// the recorded one (e.g. the gateway reports FAILED for a row marked
+	// DEPLOYED).
+	DriftStatusMismatch DriftEventKind = "STATUS_MISMATCH"
+)
+
+// DeploymentDriftEvent records one disagreement the drift detector found
+// between a ProviderGatewayDeployment row and what that gateway's /status
+// endpoint actually reported, so an operator can see what drifted and when
+// without re-running the comparison themselves.
+type DeploymentDriftEvent struct {
+	UUID         uuid.UUID      `gorm:"column:uuid;primaryKey" json:"uuid"`
+	GatewayUUID  uuid.UUID      `gorm:"column:gateway_uuid;not null;index" json:"gatewayUuid"`
+	ProviderUUID uuid.UUID      `gorm:"column:provider_uuid;index" json:"providerUuid"`
+	Kind         DriftEventKind `gorm:"column:kind;not null" json:"kind"`
+	Summary      string         `gorm:"column:summary;not null" json:"summary"`
+	DetectedAt   time.Time      `gorm:"column:detected_at" json:"detectedAt"`
+}
+
+// TableName returns the table name for deployment drift events.
+func (DeploymentDriftEvent) TableName() string {
+	return "deployment_drift_events"
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import "fmt"
+
+// EnforcementAction controls how a monitor's evaluator scores are surfaced
+// once computed, letting a new evaluator be rolled out gradually instead of
+// alerting at full strength from day one.
+type EnforcementAction string
+
+const (
+	// EnforcementActionDryRun records the score but never surfaces it in
+	// alerts or the advisory warnings channel.
+	EnforcementActionDryRun EnforcementAction = "dryrun"
+	// EnforcementActionWarn contributes the score to warning annotations on
+	// /scores responses without triggering alerting/blocking.
+	EnforcementActionWarn EnforcementAction = "warn"
+	// EnforcementActionDeny triggers the existing alerting/blocking path.
+	// This is the default so upgrading existing rows leaves behavior
+	// unchanged.
+	EnforcementActionDeny EnforcementAction = "deny"
+)
+
+// IsValid reports whether a is one of the known enforcement actions.
+func (a EnforcementAction) IsValid() bool {
+	switch a {
+	case EnforcementActionDryRun, EnforcementActionWarn, EnforcementActionDeny:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseEnforcementAction validates and normalizes a raw action string, e.g.
+// from the `action` query parameter or the evaluator-action endpoint body.
+func ParseEnforcementAction(raw string) (EnforcementAction, error) {
+	a := EnforcementAction(raw)
+	if !a.IsValid() {
+		return "", fmt.Errorf("invalid enforcement action %q: must be one of dryrun, warn, deny", raw)
+	}
+	return a, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+// GatewayBundleEntry describes one artifact inside a gateway bundle's
+// manifest.json, so the gateway can diff against local state without
+// re-downloading unchanged entries.
+type GatewayBundleEntry struct {
+	Kind    string `json:"kind"`
+	UUID    string `json:"uuid"`
+	Version string `json:"version"`
+	SHA256  string `json:"sha256"`
+}
+
+// GatewayBundleManifest is the manifest.json entry of a gateway bundle ZIP.
+// Deletions lists the UUIDs of entries the gateway should remove locally.
+// GetBundleForGateway never populates it, with or without ?since=<etag>.
+type GatewayBundleManifest struct {
+	Entries   []GatewayBundleEntry `json:"entries"`
+	Deletions []string             `json:"deletions,omitempty"`
+}
@@ -130,3 +130,56 @@ type GatewayDeploymentResponse struct {
 	Message      string `json:"message"`
 	Created      bool   `json:"created"`
 }
+
+// Gateway artifact change actions published on the gateway event bus, see
+// GatewayArtifactChangeEvent.
+const (
+	GatewayArtifactActionCreated = "created"
+	GatewayArtifactActionUpdated = "updated"
+	GatewayArtifactActionRetired = "retired"
+)
+
+// GatewayArtifactChangeEvent is published on services.GatewayEventBus
+// whenever a deployment targeting a gateway is created, updated, or
+// retired, so a gateway subscribed to GET /api/internal/v1/events can issue
+// a conditional GET for just the affected artifact instead of re-polling
+// everything.
+type GatewayArtifactChangeEvent struct {
+	Kind    string `json:"kind"`
+	UUID    string `json:"uuid"`
+	Version string `json:"version"`
+	ETag    string `json:"etag"`
+	Action  string `json:"action"`
+}
+
+// ProviderStatusRequestDTO is sent over a gateway's WebSocket connection to
+// synchronously ask whether a provider is actually loaded, correlated back
+// to the caller's RequestStatus call via CorrelationID the same way
+// GatewayEventDTO is.
+type ProviderStatusRequestDTO struct {
+	Type          string `json:"type"`
+	ProviderID    string `json:"providerId"`
+	CorrelationID string `json:"correlationId"`
+}
+
+// ProviderStatusReport is a gateway's synchronous answer to a
+// ProviderStatusRequestDTO: what it actually has loaded for one provider,
+// independent of what ProviderGatewayDeployment records.
+type ProviderStatusReport struct {
+	ProviderID   string `json:"providerId"`
+	Loaded       bool   `json:"loaded"`
+	Status       string `json:"status"`
+	RevisionUUID string `json:"revisionUuid"`
+	ConfigHash   string `json:"configHash"`
+}
+
+// MonitorRunCompletedEventDTO is published on the gateway event bus and
+// mirrored to webhook subscribers whenever a MonitorRun finishes.
+type MonitorRunCompletedEventDTO struct {
+	MonitorID     string   `json:"monitorId"`
+	RunID         string   `json:"runId"`
+	Status        string   `json:"status"`
+	FailingChecks []string `json:"failingChecks,omitempty"`
+	DurationMs    int64    `json:"durationMs"`
+	CorrelationID string   `json:"correlationId"`
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GatewayWebhookEndpoint is the callback URL and shared HMAC secret a
+// gateway registers (typically at registration time), replacing its need to
+// poll GetAPIsByOrganization for configuration changes.
+type GatewayWebhookEndpoint struct {
+	ID        uuid.UUID `gorm:"column:id;primaryKey" json:"id"`
+	GatewayID uuid.UUID `gorm:"column:gateway_id;not null;uniqueIndex" json:"gatewayId"`
+	OrgName   string    `gorm:"column:org_name;not null" json:"orgName"`
+	URL       string    `gorm:"column:url;not null" json:"url"`
+	Secret    string    `gorm:"column:secret;not null" json:"-"`
+	Active    bool      `gorm:"column:active;not null;default:true" json:"active"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"createdAt"`
+}
+
+// TableName returns the table name for gateway webhook endpoints.
+func (GatewayWebhookEndpoint) TableName() string {
+	return "gateway_webhook_endpoints"
+}
+
+// GatewayWebhookEventType enumerates the events agent-manager pushes to a
+// GatewayWebhookEndpoint.
+type GatewayWebhookEventType string
+
+// GatewayWebhookConfigurationChanged fires whenever a provider or revision
+// in the gateway's organization changes, so the gateway can decide whether
+// to refetch GetAPIsByOrganization/GetAPI.
+const GatewayWebhookConfigurationChanged GatewayWebhookEventType = "configuration.changed"
+
+// GatewayWebhookDeliveryStatus is the outcome of one delivery attempt.
+type GatewayWebhookDeliveryStatus string
+
+const (
+	GatewayWebhookDeliveryPending    GatewayWebhookDeliveryStatus = "pending"
+	GatewayWebhookDeliverySucceeded  GatewayWebhookDeliveryStatus = "succeeded"
+	GatewayWebhookDeliveryFailed     GatewayWebhookDeliveryStatus = "failed"
+	GatewayWebhookDeliveryDeadLetter GatewayWebhookDeliveryStatus = "dead_letter"
+)
+
+// GatewayWebhookDelivery records one attempt (or series of attempts) to push
+// an event to a GatewayWebhookEndpoint. DeliveryID is the monotonic ULID
+// sent as X-AgentMgr-Delivery, letting a gateway that sees the same
+// delivery twice (e.g. after an operator-triggered redeliver) dedupe it.
+type GatewayWebhookDelivery struct {
+	ID            uuid.UUID                    `gorm:"column:id;primaryKey" json:"id"`
+	EndpointID    uuid.UUID                    `gorm:"column:endpoint_id;not null" json:"endpointId"`
+	DeliveryID    string                       `gorm:"column:delivery_id;not null;uniqueIndex" json:"deliveryId"`
+	EventType     GatewayWebhookEventType      `gorm:"column:event_type;not null" json:"eventType"`
+	Payload       string                       `gorm:"column:payload;not null" json:"payload"`
+	Status        GatewayWebhookDeliveryStatus `gorm:"column:status;not null" json:"status"`
+	AttemptCount  int                          `gorm:"column:attempt_count;not null;default:0" json:"attemptCount"`
+	LastError     string                       `gorm:"column:last_error" json:"lastError,omitempty"`
+	NextAttemptAt *time.Time                   `gorm:"column:next_attempt_at" json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time                    `gorm:"column:created_at" json:"createdAt"`
+}
+
+// TableName returns the table name for gateway webhook delivery attempt records.
+func (GatewayWebhookDelivery) TableName() string {
+	return "gateway_webhook_deliveries"
+}
+
+// GatewayConfigurationChangedEvent is the JSON body POSTed to a gateway's
+// webhook URL. Scope is "organization" when any provider in the org may
+// have changed, or "api" when APIID narrows it to a single provider.
+type GatewayConfigurationChangedEvent struct {
+	OrgName    string  `json:"orgName"`
+	Scope      string  `json:"scope"`
+	APIID      *string `json:"apiId,omitempty"`
+	RevisionID *string `json:"revisionId,omitempty"`
+	ChangedAt  string  `json:"changedAt"`
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MonitorRunDailySummary is the collapsed form a day's worth of MonitorRun
+// rows is rolled up into once they age out of the hot monitor_runs table, so
+// ListMonitorRuns can keep answering quickly as history grows into the
+// millions without losing status/duration trends for that day.
+type MonitorRunDailySummary struct {
+	MonitorID     uuid.UUID `gorm:"column:monitor_id;primaryKey" json:"monitorId"`
+	Day           time.Time `gorm:"column:day;primaryKey" json:"day"`
+	TotalCount    int       `gorm:"column:total_count" json:"totalCount"`
+	CountByStatus string    `gorm:"column:count_by_status" json:"countByStatus"` // JSON: {"succeeded": 10, "failed": 2}
+	P50DurationMs int64      `gorm:"column:p50_duration_ms" json:"p50DurationMs"`
+	P95DurationMs int64      `gorm:"column:p95_duration_ms" json:"p95DurationMs"`
+	FirstFailure  *time.Time `gorm:"column:first_failure" json:"firstFailure,omitempty"`
+	LastFailure   *time.Time `gorm:"column:last_failure" json:"lastFailure,omitempty"`
+}
+
+// TableName returns the table name for daily monitor run summaries.
+func (MonitorRunDailySummary) TableName() string {
+	return "monitor_run_daily_summaries"
+}
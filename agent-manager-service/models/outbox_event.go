@@ -0,0 +1,67 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEventType enumerates the WebSocket events OnPremiseAdapter records
+// through the transactional outbox instead of broadcasting directly.
+type OutboxEventType string
+
+const (
+	OutboxEventLLMProviderDeployed   OutboxEventType = "llmProvider.deployed"
+	OutboxEventLLMProviderUndeployed OutboxEventType = "llmProvider.undeployed"
+)
+
+// OutboxEventStatus is the delivery state of one outbox row.
+type OutboxEventStatus string
+
+const (
+	OutboxEventPending    OutboxEventStatus = "pending"
+	OutboxEventDispatched OutboxEventStatus = "dispatched"
+	OutboxEventDeadLetter OutboxEventStatus = "dead_letter"
+)
+
+// OutboxEvent is a gateway-bound WebSocket event written in the same GORM
+// transaction as the LLMProvider/ProviderGatewayDeployment row that caused
+// it, so a crash between the DB write and the WebSocket broadcast can never
+// lose the event - OutboxDispatcher polls and delivers these independently
+// of the request that created them. IdempotencyKey is a ULID (monotonic, so
+// ListSince can order on it as a tiebreaker) a gateway can use to dedupe a
+// redelivered event from one it already applied.
+type OutboxEvent struct {
+	ID             uuid.UUID         `gorm:"column:id;primaryKey" json:"id"`
+	GatewayUUID    uuid.UUID         `gorm:"column:gateway_uuid;not null" json:"gatewayUuid"`
+	EventType      OutboxEventType   `gorm:"column:event_type;not null" json:"eventType"`
+	IdempotencyKey string            `gorm:"column:idempotency_key;not null;uniqueIndex" json:"idempotencyKey"`
+	Payload        string            `gorm:"column:payload;not null" json:"payload"`
+	Status         OutboxEventStatus `gorm:"column:status;not null" json:"status"`
+	AttemptCount   int               `gorm:"column:attempt_count;not null;default:0" json:"attemptCount"`
+	LastError      string            `gorm:"column:last_error" json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time        `gorm:"column:next_attempt_at" json:"nextAttemptAt,omitempty"`
+	DispatchedAt   *time.Time        `gorm:"column:dispatched_at" json:"dispatchedAt,omitempty"`
+	CreatedAt      time.Time         `gorm:"column:created_at" json:"createdAt"`
+}
+
+// TableName returns the table name for outbox event records.
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProviderRevision is an immutable snapshot of an LLMProvider's
+// (Template, Configuration, DisplayName), taken every time a gateway
+// deployment is created or a provider is updated. A
+// ProviderGatewayDeployment's RevisionUUID points at one of these rather
+// than the live LLMProvider row, so editing a provider never changes what
+// an already-deployed gateway renders until that gateway is redeployed
+// against a newer (or, via RollbackDeployment, older) revision.
+type ProviderRevision struct {
+	UUID           uuid.UUID              `gorm:"column:uuid;primaryKey" json:"uuid"`
+	ProviderUUID   uuid.UUID              `gorm:"column:provider_uuid;not null;index" json:"providerUuid"`
+	RevisionNumber int64                  `gorm:"column:revision_number;not null" json:"revisionNumber"`
+	DisplayName    string                 `gorm:"column:display_name;not null" json:"displayName"`
+	Template       string                 `gorm:"column:template;not null" json:"template"`
+	Configuration  map[string]interface{} `gorm:"column:configuration;serializer:json" json:"configuration"`
+	CreatedAt      time.Time              `gorm:"column:created_at" json:"createdAt"`
+}
+
+// TableName returns the table name for provider revision snapshots.
+func (ProviderRevision) TableName() string {
+	return "provider_revisions"
+}
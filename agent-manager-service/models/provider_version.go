@@ -0,0 +1,36 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+// ProviderGatewayDeployment.Type identifies whether a deployment row is the
+// literal version a gateway reported, or one of the synthetic "latest
+// within this range" entries GatewayInternalService derives from it so a
+// gateway can route an unpinned request (e.g. POST /openai/1/chat) to the
+// newest concrete version deployed under that major or major.minor. See
+// services.DeriveVersionRangeDeployments.
+const (
+	// DeploymentTypeDefault is the concrete, literally-deployed version.
+	DeploymentTypeDefault = "DEFAULT_VERSION"
+	// DeploymentTypeMinorVersion is a synthetic entry pinned to a major
+	// version only (e.g. "openai-1"), routed to the highest minor.patch
+	// deployed under that major.
+	DeploymentTypeMinorVersion = "MINOR_VERSION"
+	// DeploymentTypePatchVersion is a synthetic entry pinned to a
+	// major.minor version (e.g. "openai-1.2"), routed to the highest patch
+	// deployed under that minor.
+	DeploymentTypePatchVersion = "PATCH_VERSION"
+)
@@ -0,0 +1,59 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoutingPolicy lets one logical VirtualModel name fan out across several
+// LLMProviders deployed to the same gateway — weighted, rate-shaped, and
+// ordered for fallback — instead of the default one provider per gateway
+// deployment. It is bound to a single GatewayUUID: GetAPIsByOrganization
+// groups every RoutingPolicy bound to a gateway into one kind:
+// LLMRoutingPolicy YAML doc alongside that gateway's per-provider
+// kind: LLMProvider docs.
+type RoutingPolicy struct {
+	UUID             uuid.UUID     `gorm:"column:uuid;primaryKey" json:"uuid"`
+	OrganizationName string        `gorm:"column:organization_name;not null;index" json:"organizationName"`
+	GatewayUUID      uuid.UUID     `gorm:"column:gateway_uuid;not null;index" json:"gatewayUuid"`
+	Name             string        `gorm:"column:name;not null" json:"name"`
+	VirtualModel     string        `gorm:"column:virtual_model;not null" json:"virtualModel"`
+	Rules            []RoutingRule `gorm:"column:rules;serializer:json" json:"rules"`
+	CreatedAt        time.Time     `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt        time.Time     `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+// TableName returns the table name for routing policies.
+func (RoutingPolicy) TableName() string {
+	return "routing_policies"
+}
+
+// RoutingRule is one weighted/fallback target within a RoutingPolicy,
+// routing a share of VirtualModel traffic to ModelName on the deployed
+// provider ProviderUUID. MatchTags, when non-empty, further restricts the
+// rule to requests carrying all of those tags.
+type RoutingRule struct {
+	ProviderUUID       uuid.UUID `json:"providerUuid"`
+	ModelName          string    `json:"modelName"`
+	Weight             int       `json:"weight"`
+	MaxTokensPerMinute int64     `json:"maxTokensPerMinute,omitempty"`
+	FallbackOrder      int       `json:"fallbackOrder"`
+	MatchTags          []string  `json:"matchTags,omitempty"`
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SigningKey is an organization-scoped Ed25519 key pair used to sign
+// packaged artifacts (LLM provider/proxy YAML, bundles) before they're
+// handed to a gateway. PrivateKey is stored encrypted at rest (see
+// utils.EncryptAtRest) and is never serialized to JSON.
+type SigningKey struct {
+	UUID                uuid.UUID  `gorm:"column:uuid;primaryKey" json:"uuid"`
+	OrganizationName    string     `gorm:"column:organization_name;not null" json:"organizationName"`
+	KID                 string     `gorm:"column:kid;not null" json:"kid"`
+	PublicKey           []byte     `gorm:"column:public_key;not null" json:"-"`
+	EncryptedPrivateKey []byte     `gorm:"column:encrypted_private_key;not null" json:"-"`
+	Active              bool       `gorm:"column:active" json:"active"`
+	CreatedAt           time.Time  `gorm:"column:created_at" json:"createdAt"`
+	RotatedAt           *time.Time `gorm:"column:rotated_at" json:"rotatedAt,omitempty"`
+}
+
+// TableName returns the table name for signing key queries
+func (SigningKey) TableName() string {
+	return "artifact_signing_keys"
+}
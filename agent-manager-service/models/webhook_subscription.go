@@ -0,0 +1,71 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription is an external system's subscription to MonitorRun
+// outcomes for a given org/agent, delivered as a signed HTTP callback instead
+// of requiring the subscriber to poll ListMonitorRuns.
+type WebhookSubscription struct {
+	ID        uuid.UUID `gorm:"column:id;primaryKey" json:"id"`
+	OrgName   string    `gorm:"column:org_name;not null" json:"orgName"`
+	AgentName string    `gorm:"column:agent_name" json:"agentName,omitempty"` // empty subscribes to all agents in the org
+	URL       string    `gorm:"column:url;not null" json:"url"`
+	Secret    string    `gorm:"column:secret;not null" json:"-"`
+	Active    bool      `gorm:"column:active;not null;default:true" json:"active"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"createdAt"`
+}
+
+// TableName returns the table name for webhook subscriptions.
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDeliveryStatus is the outcome of one delivery attempt.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded  WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed     WebhookDeliveryStatus = "failed"
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "dead_letter"
+)
+
+// WebhookDelivery records one attempt (or series of attempts) to deliver an
+// event to a WebhookSubscription, queryable for operators diagnosing a
+// subscriber that stopped receiving events.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `gorm:"column:id;primaryKey" json:"id"`
+	SubscriptionID uuid.UUID             `gorm:"column:subscription_id;not null" json:"subscriptionId"`
+	EventType      string                `gorm:"column:event_type;not null" json:"eventType"`
+	Payload        string                `gorm:"column:payload;not null" json:"payload"`
+	Status         WebhookDeliveryStatus `gorm:"column:status;not null" json:"status"`
+	AttemptCount   int                   `gorm:"column:attempt_count;not null;default:0" json:"attemptCount"`
+	LastError      string                `gorm:"column:last_error" json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time            `gorm:"column:next_attempt_at" json:"nextAttemptAt,omitempty"`
+	CreatedAt      time.Time             `gorm:"column:created_at" json:"createdAt"`
+}
+
+// TableName returns the table name for webhook delivery attempt records.
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -17,17 +17,130 @@
 package repositories
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+)
+
+// CatalogFilter narrows a ListPage query. Zero-valued fields are treated as
+// "don't filter on this". Kinds combines as an OR; the remaining fields
+// combine with each other and with Kinds as AND.
+type CatalogFilter struct {
+	Kinds         []string
+	HandlePrefix  string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// key returns a stable string encoding of the filter, used to fingerprint
+// cursors so one can't be replayed against a different filter set.
+func (f CatalogFilter) key() []string {
+	createdAfter, createdBefore := "", ""
+	if f.CreatedAfter != nil {
+		createdAfter = f.CreatedAfter.UTC().Format(time.RFC3339Nano)
+	}
+	if f.CreatedBefore != nil {
+		createdBefore = f.CreatedBefore.UTC().Format(time.RFC3339Nano)
+	}
+	kinds := make([]string, len(f.Kinds))
+	copy(kinds, f.Kinds)
+	return []string{strings.Join(kinds, ","), f.HandlePrefix, createdAfter, createdBefore}
+}
+
+// CatalogSort selects the ordering Search applies, which in turn determines
+// what a cursor's keyset position is measured against.
+type CatalogSort string
+
+const (
+	// CatalogSortRelevance orders by full-text match quality, best first.
+	// Only meaningful when Query.Terms is non-empty; Search falls back to
+	// CatalogSortRecent when Terms is empty and Sort is left at this value.
+	CatalogSortRelevance CatalogSort = "relevance"
+	CatalogSortRecent    CatalogSort = "recent"
+	CatalogSortName      CatalogSort = "name"
 )
 
+// CatalogQuery narrows and orders a Search call. Zero-valued slice/string
+// fields mean "don't filter on this"; Kinds/Tags/Publishers/Statuses each
+// combine as an OR internally and AND with each other and with Terms.
+type CatalogQuery struct {
+	Terms      string
+	Kinds      []string
+	Tags       []string
+	Publishers []string
+	Statuses   []models.CatalogStatus
+	Sort       CatalogSort
+}
+
+// key returns a stable string encoding of the query, used to fingerprint
+// cursors so one can't be replayed against a different query or sort order.
+func (q CatalogQuery) key() []string {
+	statuses := make([]string, len(q.Statuses))
+	for i, s := range q.Statuses {
+		statuses[i] = string(s)
+	}
+	return []string{
+		q.Terms,
+		strings.Join(q.Kinds, ","),
+		strings.Join(q.Tags, ","),
+		strings.Join(q.Publishers, ","),
+		strings.Join(statuses, ","),
+		string(q.Sort),
+	}
+}
+
+// FacetCount is how many matching entries carry a particular facet value.
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// CatalogFacets are facet counts computed over every entry matching a
+// Search's filters (not just the current page), so the UI can render
+// "Kind (12), Tag (5)..." style filter chips regardless of which page is
+// showing.
+type CatalogFacets struct {
+	Kinds      []FacetCount
+	Tags       []FacetCount
+	Publishers []FacetCount
+	Statuses   []FacetCount
+}
+
 // CatalogRepository defines the interface for catalog data access
 type CatalogRepository interface {
 	// ListByKind lists catalog entries filtered by kind with pagination
 	ListByKind(orgUUID, kind string, limit, offset int) ([]models.CatalogEntry, int64, error)
 	// ListAll lists all catalog entries with pagination
 	ListAll(orgUUID string, limit, offset int) ([]models.CatalogEntry, int64, error)
+	// ListPage lists catalog entries matching filter, ordered by
+	// (created_at DESC, uuid DESC). When cursor is non-empty it takes
+	// precedence over offset and is applied as a keyset predicate, so the
+	// page stays stable even as new rows are inserted at the head of the
+	// result set; otherwise offset is used, preserving the legacy
+	// limit/offset pagination contract. The returned cursor points past the
+	// last row of the page, or is empty once the result set is exhausted.
+	ListPage(orgUUID string, filter CatalogFilter, limit, offset int, cursor string) ([]models.CatalogEntry, int64, string, error)
+	// Search lists catalog entries matching query, ordered by query.Sort, using
+	// the same cursor-takes-precedence-over-offset contract as ListPage. On
+	// Postgres, non-empty query.Terms are matched against name/description/tags
+	// via tsvector + plainto_tsquery and (when Sort is CatalogSortRelevance)
+	// used to rank results; on other drivers Terms falls back to an ILIKE
+	// substring match and relevance sort degrades to recency. The returned
+	// CatalogFacets are counted across every entry matching query's filters
+	// and Terms, independent of pagination, so facet chips reflect the whole
+	// result set rather than just the current page.
+	Search(orgUUID string, query CatalogQuery, limit, offset int, cursor string) (entries []models.CatalogEntry, total int64, facets CatalogFacets, nextCursor string, err error)
+	// Create inserts a new catalog entry scoped to the given organization, e.g. one
+	// materialized from a pulled OCI artifact.
+	Create(orgUUID string, entry *models.CatalogEntry) error
+	// GetByUUID retrieves a single catalog entry scoped to the given organization
+	GetByUUID(orgUUID, entryUUID string) (*models.CatalogEntry, error)
 }
 
 // CatalogRepo implements CatalogRepository using GORM
@@ -65,6 +178,298 @@ func (r *CatalogRepo) ListByKind(orgUUID, kind string, limit, offset int) ([]mod
 	return entries, total, nil
 }
 
+// Create inserts a new catalog entry scoped to the given organization
+func (r *CatalogRepo) Create(orgUUID string, entry *models.CatalogEntry) error {
+	if entry.UUID == uuid.Nil {
+		entry.UUID = uuid.New()
+	}
+	orgID, err := uuid.Parse(orgUUID)
+	if err != nil {
+		return err
+	}
+	entry.OrganizationUUID = orgID
+	entry.InCatalog = true
+	return r.db.Create(entry).Error
+}
+
+// GetByUUID retrieves a single catalog entry scoped to the given organization
+func (r *CatalogRepo) GetByUUID(orgUUID, entryUUID string) (*models.CatalogEntry, error) {
+	var entry models.CatalogEntry
+	if err := r.db.
+		Where("organization_uuid = ? AND uuid = ? AND in_catalog = ?", orgUUID, entryUUID, true).
+		First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListPage lists catalog entries matching filter using keyset pagination.
+// See CatalogRepository.ListPage for the cursor/offset precedence rules.
+func (r *CatalogRepo) ListPage(orgUUID string, filter CatalogFilter, limit, offset int, cursor string) ([]models.CatalogEntry, int64, string, error) {
+	filterHash := utils.HashFilters(filter.key()...)
+
+	scope := r.db.Where("organization_uuid = ? AND in_catalog = ?", orgUUID, true)
+	if len(filter.Kinds) > 0 {
+		scope = scope.Where("kind IN ?", filter.Kinds)
+	}
+	if filter.HandlePrefix != "" {
+		scope = scope.Where("handle LIKE ? ESCAPE '\\\\'", escapeLikeWildcards(filter.HandlePrefix)+"%")
+	}
+	if filter.CreatedAfter != nil {
+		scope = scope.Where("created_at > ?", filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		scope = scope.Where("created_at < ?", filter.CreatedBefore)
+	}
+
+	var total int64
+	if err := scope.Session(&gorm.Session{}).Model(&models.CatalogEntry{}).Count(&total).Error; err != nil {
+		return nil, 0, "", err
+	}
+
+	page := scope.Session(&gorm.Session{}).Order("created_at DESC, uuid DESC")
+
+	if cursor != "" {
+		decoded, err := utils.DecodeCursor(cursor)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		if decoded.FilterHash != filterHash {
+			return nil, 0, "", utils.ErrInvalidCursor
+		}
+		page = page.Where("(created_at < ?) OR (created_at = ? AND uuid < ?)",
+			decoded.LastSortKey, decoded.LastSortKey, decoded.LastUUID)
+	} else {
+		page = page.Offset(offset)
+	}
+
+	var entries []models.CatalogEntry
+	if err := page.Limit(limit).Find(&entries).Error; err != nil {
+		return nil, 0, "", err
+	}
+
+	nextCursor := ""
+	if len(entries) == limit && limit > 0 {
+		last := entries[len(entries)-1]
+		encoded, err := utils.EncodeCursor(utils.Cursor{
+			LastSortKey: last.CreatedAt.Format(time.RFC3339Nano),
+			LastUUID:    last.UUID.String(),
+			FilterHash:  filterHash,
+		})
+		if err != nil {
+			return nil, 0, "", err
+		}
+		nextCursor = encoded
+	}
+
+	return entries, total, nextCursor, nil
+}
+
+// isPostgres reports whether r is backed by Postgres, which gates whether
+// Search uses tsvector ranking/ILIKE or can run ts_rank ordering at all.
+func (r *CatalogRepo) isPostgres() bool {
+	return r.db.Dialector.Name() == "postgres"
+}
+
+// searchScope applies query's filters (but not Terms ranking/ordering) to db,
+// shared between Search's count, facet, and page queries.
+func (r *CatalogRepo) searchScope(orgUUID string, query CatalogQuery) *gorm.DB {
+	scope := r.db.Where("organization_uuid = ? AND in_catalog = ?", orgUUID, true)
+	if len(query.Kinds) > 0 {
+		scope = scope.Where("kind IN ?", query.Kinds)
+	}
+	if len(query.Publishers) > 0 {
+		scope = scope.Where("publisher IN ?", query.Publishers)
+	}
+	if len(query.Statuses) > 0 {
+		scope = scope.Where("status IN ?", query.Statuses)
+	}
+	if len(query.Tags) > 0 {
+		if r.isPostgres() {
+			scope = scope.Where("tags::jsonb ?| array[?]", query.Tags)
+		} else {
+			// No portable "array contains any of" operator outside Postgres;
+			// fall back to substring matching against the serialized column.
+			tagConds := r.db
+			for i, tag := range query.Tags {
+				like := "%\"" + escapeLikeWildcards(tag) + "\"%"
+				if i == 0 {
+					tagConds = tagConds.Where("tags LIKE ?", like)
+				} else {
+					tagConds = tagConds.Or("tags LIKE ?", like)
+				}
+			}
+			scope = scope.Where(tagConds)
+		}
+	}
+	if strings.TrimSpace(query.Terms) != "" {
+		if r.isPostgres() {
+			scope = scope.Where(
+				"to_tsvector('english', name || ' ' || coalesce(description, '') || ' ' || coalesce(array_to_string(tags, ' '), '')) @@ plainto_tsquery('english', ?)",
+				query.Terms)
+		} else {
+			like := "%" + escapeLikeWildcards(query.Terms) + "%"
+			scope = scope.Where("name ILIKE ? OR description ILIKE ? OR tags LIKE ?", like, like, like)
+		}
+	}
+	return scope
+}
+
+// searchRank is the Postgres ts_rank expression Search orders by under
+// CatalogSortRelevance; it's recomputed from the same tsvector/tsquery
+// searchScope already filtered on, so ranking and matching stay consistent.
+func searchRank(terms string) string {
+	return fmt.Sprintf(
+		"ts_rank(to_tsvector('english', name || ' ' || coalesce(description, '') || ' ' || coalesce(array_to_string(tags, ' '), '')), plainto_tsquery('english', %s))",
+		gormQuote(terms))
+}
+
+// gormQuote escapes a value for direct interpolation into an ORDER BY
+// expression, where placeholder binding isn't available. Search only ever
+// calls this with query.Terms, which searchScope has already bound safely
+// via a placeholder in the WHERE clause - this is strictly for the ORDER BY.
+func gormQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Search lists catalog entries matching query with full-text ranking,
+// faceted filters, and keyset pagination. See CatalogRepository.Search.
+func (r *CatalogRepo) Search(orgUUID string, query CatalogQuery, limit, offset int, cursor string) ([]models.CatalogEntry, int64, CatalogFacets, string, error) {
+	sort := query.Sort
+	if sort == "" || (sort == CatalogSortRelevance && strings.TrimSpace(query.Terms) == "") {
+		sort = CatalogSortRecent
+	}
+	if sort == CatalogSortRelevance && !r.isPostgres() {
+		sort = CatalogSortRecent
+	}
+
+	filterHash := utils.HashFilters(query.key()...)
+	baseScope := r.searchScope(orgUUID, query)
+
+	var total int64
+	if err := baseScope.Session(&gorm.Session{}).Model(&models.CatalogEntry{}).Count(&total).Error; err != nil {
+		return nil, 0, CatalogFacets{}, "", err
+	}
+
+	facets, err := r.searchFacets(baseScope)
+	if err != nil {
+		return nil, 0, CatalogFacets{}, "", err
+	}
+
+	page := baseScope.Session(&gorm.Session{})
+	var sortKeyOf func(models.CatalogEntry) string
+	switch sort {
+	case CatalogSortName:
+		page = page.Order("name ASC, uuid ASC")
+		sortKeyOf = func(e models.CatalogEntry) string { return e.Name }
+	case CatalogSortRelevance:
+		rank := searchRank(query.Terms)
+		page = page.Order(rank + " DESC, uuid DESC")
+		sortKeyOf = func(models.CatalogEntry) string { return "" } // set from the raw rank below
+	default:
+		page = page.Order("created_at DESC, uuid DESC")
+		sortKeyOf = func(e models.CatalogEntry) string { return e.CreatedAt.Format(time.RFC3339Nano) }
+	}
+
+	if cursor != "" {
+		decoded, err := utils.DecodeCursor(cursor)
+		if err != nil {
+			return nil, 0, CatalogFacets{}, "", err
+		}
+		if decoded.FilterHash != filterHash {
+			return nil, 0, CatalogFacets{}, "", utils.ErrInvalidCursor
+		}
+		switch sort {
+		case CatalogSortName:
+			page = page.Where("(name > ?) OR (name = ? AND uuid > ?)", decoded.LastSortKey, decoded.LastSortKey, decoded.LastUUID)
+		case CatalogSortRelevance:
+			rank := searchRank(query.Terms)
+			page = page.Where("("+rank+" < ?) OR ("+rank+" = ? AND uuid < ?)", decoded.LastSortKey, decoded.LastSortKey, decoded.LastUUID)
+		default:
+			page = page.Where("(created_at < ?) OR (created_at = ? AND uuid < ?)", decoded.LastSortKey, decoded.LastSortKey, decoded.LastUUID)
+		}
+	} else {
+		page = page.Offset(offset)
+	}
+
+	var entries []models.CatalogEntry
+	if err := page.Limit(limit).Find(&entries).Error; err != nil {
+		return nil, 0, CatalogFacets{}, "", err
+	}
+
+	nextCursor := ""
+	if len(entries) == limit && limit > 0 {
+		last := entries[len(entries)-1]
+		lastSortKey := sortKeyOf(last)
+		if sort == CatalogSortRelevance {
+			var rank float64
+			if err := r.db.Raw("SELECT "+searchRank(query.Terms)+" FROM artifacts WHERE uuid = ?", last.UUID).Scan(&rank).Error; err != nil {
+				return nil, 0, CatalogFacets{}, "", err
+			}
+			lastSortKey = fmt.Sprintf("%.10f", rank)
+		}
+		encoded, err := utils.EncodeCursor(utils.Cursor{
+			LastSortKey: lastSortKey,
+			LastUUID:    last.UUID.String(),
+			FilterHash:  filterHash,
+		})
+		if err != nil {
+			return nil, 0, CatalogFacets{}, "", err
+		}
+		nextCursor = encoded
+	}
+
+	return entries, total, facets, nextCursor, nil
+}
+
+// searchFacets computes facet counts for kind/publisher/status with one
+// GROUP BY query per facet, three round trips in all; the counts are not
+// combined into a single query. Tags aren't included: they're stored as a serialized array rather than a
+// column GROUP BY can aggregate over portably.
+func (r *CatalogRepo) searchFacets(scope *gorm.DB) (CatalogFacets, error) {
+	kinds, err := groupCounts(scope, "kind")
+	if err != nil {
+		return CatalogFacets{}, err
+	}
+	publishers, err := groupCounts(scope, "publisher")
+	if err != nil {
+		return CatalogFacets{}, err
+	}
+	statuses, err := groupCounts(scope, "status")
+	if err != nil {
+		return CatalogFacets{}, err
+	}
+	return CatalogFacets{Kinds: kinds, Publishers: publishers, Statuses: statuses}, nil
+}
+
+// groupCounts runs "GROUP BY column, COUNT(*)" against scope's filters.
+func groupCounts(scope *gorm.DB, column string) ([]FacetCount, error) {
+	var rows []struct {
+		Value string
+		Count int64
+	}
+	err := scope.Session(&gorm.Session{}).Model(&models.CatalogEntry{}).
+		Select(column+" AS value, COUNT(*) AS count").
+		Where(column + " IS NOT NULL AND " + column + " != ''").
+		Group(column).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]FacetCount, len(rows))
+	for i, row := range rows {
+		counts[i] = FacetCount{Value: row.Value, Count: row.Count}
+	}
+	return counts, nil
+}
+
+// escapeLikeWildcards escapes LIKE metacharacters (\, %, _) in s so it can be
+// safely embedded in a LIKE pattern without a caller-controlled value being
+// interpreted as a wildcard.
+func escapeLikeWildcards(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 // ListAll lists all catalog entries with pagination
 func (r *CatalogRepo) ListAll(orgUUID string, limit, offset int) ([]models.CatalogEntry, int64, error) {
 	var entries []models.CatalogEntry
@@ -0,0 +1,131 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// GatewayWebhookRepository persists gateway webhook endpoints and the
+// configuration.changed delivery attempts made against them.
+type GatewayWebhookRepository interface {
+	// GetEndpointByGatewayID loads the webhook endpoint registered by a
+	// specific gateway, if any.
+	GetEndpointByGatewayID(gatewayID uuid.UUID) (*models.GatewayWebhookEndpoint, error)
+	// GetEndpointByID loads a single endpoint, used by the delivery loop to
+	// look up where/how to sign a due delivery.
+	GetEndpointByID(id uuid.UUID) (*models.GatewayWebhookEndpoint, error)
+	// CreateEndpoint inserts a newly registered endpoint.
+	CreateEndpoint(endpoint *models.GatewayWebhookEndpoint) error
+	// UpdateEndpoint saves a rotated URL/secret for an existing endpoint.
+	UpdateEndpoint(endpoint *models.GatewayWebhookEndpoint) error
+	// ListActiveEndpoints returns every active endpoint for orgName, for
+	// fanning out a configuration.changed event.
+	ListActiveEndpoints(orgName string) ([]models.GatewayWebhookEndpoint, error)
+
+	// CreateDelivery records a new pending delivery attempt.
+	CreateDelivery(delivery *models.GatewayWebhookDelivery) error
+	// UpdateDelivery saves the outcome of an attempt (status, error, next
+	// retry time).
+	UpdateDelivery(delivery *models.GatewayWebhookDelivery) error
+	// GetDeliveryByID loads a single delivery, for the admin redeliver
+	// endpoint.
+	GetDeliveryByID(id uuid.UUID) (*models.GatewayWebhookDelivery, error)
+	// ListDueDeliveries returns pending/failed deliveries whose next attempt
+	// is due, for the dispatcher's retry loop to pick up.
+	ListDueDeliveries(limit int) ([]models.GatewayWebhookDelivery, error)
+}
+
+// GatewayWebhookRepo implements GatewayWebhookRepository using GORM.
+type GatewayWebhookRepo struct {
+	db *gorm.DB
+}
+
+// NewGatewayWebhookRepo creates a new gateway webhook repository.
+func NewGatewayWebhookRepo(db *gorm.DB) GatewayWebhookRepository {
+	return &GatewayWebhookRepo{db: db}
+}
+
+// GetEndpointByGatewayID loads the webhook endpoint registered by gatewayID.
+func (r *GatewayWebhookRepo) GetEndpointByGatewayID(gatewayID uuid.UUID) (*models.GatewayWebhookEndpoint, error) {
+	var endpoint models.GatewayWebhookEndpoint
+	if err := r.db.Where("gateway_id = ?", gatewayID).First(&endpoint).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// GetEndpointByID loads a single endpoint by ID.
+func (r *GatewayWebhookRepo) GetEndpointByID(id uuid.UUID) (*models.GatewayWebhookEndpoint, error) {
+	var endpoint models.GatewayWebhookEndpoint
+	if err := r.db.Where("id = ?", id).First(&endpoint).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// CreateEndpoint inserts a newly registered endpoint.
+func (r *GatewayWebhookRepo) CreateEndpoint(endpoint *models.GatewayWebhookEndpoint) error {
+	return r.db.Create(endpoint).Error
+}
+
+// UpdateEndpoint saves a rotated URL/secret for an existing endpoint.
+func (r *GatewayWebhookRepo) UpdateEndpoint(endpoint *models.GatewayWebhookEndpoint) error {
+	return r.db.Save(endpoint).Error
+}
+
+// ListActiveEndpoints returns every active endpoint for orgName.
+func (r *GatewayWebhookRepo) ListActiveEndpoints(orgName string) ([]models.GatewayWebhookEndpoint, error) {
+	var endpoints []models.GatewayWebhookEndpoint
+	err := r.db.Where("org_name = ? AND active = true", orgName).Find(&endpoints).Error
+	return endpoints, err
+}
+
+// CreateDelivery records a new pending delivery attempt.
+func (r *GatewayWebhookRepo) CreateDelivery(delivery *models.GatewayWebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// UpdateDelivery saves the outcome of an attempt.
+func (r *GatewayWebhookRepo) UpdateDelivery(delivery *models.GatewayWebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
+
+// GetDeliveryByID loads a single delivery by ID.
+func (r *GatewayWebhookRepo) GetDeliveryByID(id uuid.UUID) (*models.GatewayWebhookDelivery, error) {
+	var delivery models.GatewayWebhookDelivery
+	if err := r.db.Where("id = ?", id).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDueDeliveries returns pending/failed deliveries whose next attempt is due.
+func (r *GatewayWebhookRepo) ListDueDeliveries(limit int) ([]models.GatewayWebhookDelivery, error) {
+	var deliveries []models.GatewayWebhookDelivery
+	err := r.db.Where("status IN ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)",
+		[]models.GatewayWebhookDeliveryStatus{models.GatewayWebhookDeliveryPending, models.GatewayWebhookDeliveryFailed}, time.Now()).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
@@ -17,10 +17,13 @@
 package repositories
 
 import (
+	"encoding/json"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
 )
@@ -41,6 +44,14 @@ type MonitorRepository interface {
 	UpdateNextRunTime(monitorID uuid.UUID, nextRunTime *time.Time) error
 	ListDueMonitors(monitorType string, dueBy time.Time) ([]models.Monitor, error)
 
+	// ArchiveMonitorRuns moves runs for monitorID older than olderThan out of
+	// the hot monitor_runs table, collapsing each affected day into a
+	// MonitorRunDailySummary row. ListMonitorRuns does not yet merge these
+	// summaries back in for offsets that cross the archive boundary; callers
+	// needing archived history should query MonitorRunDailySummary directly
+	// until that merge lands.
+	ArchiveMonitorRuns(monitorID uuid.UUID, olderThan time.Time) error
+
 	// MonitorRun CRUD
 	CreateMonitorRun(run *models.MonitorRun) error
 	GetMonitorRunByID(runID, monitorID uuid.UUID) (*models.MonitorRun, error)
@@ -51,6 +62,18 @@ type MonitorRepository interface {
 	GetLatestMonitorRuns(monitorIDs []uuid.UUID) (map[uuid.UUID]models.MonitorRun, error)
 	UpdateMonitorRun(run *models.MonitorRun, updates map[string]interface{}) error
 	ListPendingOrRunningRuns(limit int) ([]models.MonitorRun, error)
+
+	// ClaimPendingRuns atomically claims up to limit pending runs for
+	// workerID, marking them running with a lease expiring after
+	// leaseDuration, using SELECT ... FOR UPDATE SKIP LOCKED so concurrent
+	// worker replicas never claim the same run twice.
+	ClaimPendingRuns(workerID string, leaseDuration time.Duration, limit int) ([]models.MonitorRun, error)
+	// RenewLease extends a claimed run's lease, failing if it's no longer
+	// held by workerID (e.g. because it already expired and was reclaimed).
+	RenewLease(runID uuid.UUID, workerID string, leaseDuration time.Duration) error
+	// ReleasePendingRuns resets runs whose lease has expired back to pending
+	// so another worker can claim them.
+	ReleasePendingRuns() (int64, error)
 }
 
 // MonitorRepo implements MonitorRepository using GORM
@@ -131,6 +154,92 @@ func (r *MonitorRepo) ListDueMonitors(monitorType string, dueBy time.Time) ([]mo
 	return monitors, err
 }
 
+// ArchiveMonitorRuns rolls up runs for monitorID older than olderThan into
+// one MonitorRunDailySummary per day, then deletes the archived rows, all
+// within a single transaction so a crash mid-archive can't lose runs.
+func (r *MonitorRepo) ArchiveMonitorRuns(monitorID uuid.UUID, olderThan time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var runs []models.MonitorRun
+		if err := tx.Where("monitor_id = ? AND created_at < ?", monitorID, olderThan).
+			Order("created_at ASC").Find(&runs).Error; err != nil {
+			return err
+		}
+		if len(runs) == 0 {
+			return nil
+		}
+
+		for day, dayRuns := range groupRunsByDay(runs) {
+			summary := summarizeMonitorRuns(monitorID, day, dayRuns)
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "monitor_id"}, {Name: "day"}},
+				DoUpdates: clause.AssignmentColumns([]string{"total_count", "count_by_status", "p50_duration_ms", "p95_duration_ms", "first_failure", "last_failure"}),
+			}).Create(&summary).Error; err != nil {
+				return err
+			}
+		}
+
+		ids := make([]uuid.UUID, len(runs))
+		for i, run := range runs {
+			ids[i] = run.ID
+		}
+		return tx.Where("id IN ?", ids).Delete(&models.MonitorRun{}).Error
+	})
+}
+
+// groupRunsByDay buckets runs by the UTC midnight their CreatedAt falls on.
+func groupRunsByDay(runs []models.MonitorRun) map[time.Time][]models.MonitorRun {
+	byDay := make(map[time.Time][]models.MonitorRun)
+	for _, run := range runs {
+		day := run.CreatedAt.UTC().Truncate(24 * time.Hour)
+		byDay[day] = append(byDay[day], run)
+	}
+	return byDay
+}
+
+// summarizeMonitorRuns collapses one day's runs into a MonitorRunDailySummary.
+func summarizeMonitorRuns(monitorID uuid.UUID, day time.Time, runs []models.MonitorRun) models.MonitorRunDailySummary {
+	counts := make(map[string]int)
+	durations := make([]int64, 0, len(runs))
+	var firstFailure, lastFailure *time.Time
+
+	for _, run := range runs {
+		counts[run.Status]++
+		durations = append(durations, run.TraceEnd.Sub(run.TraceStart).Milliseconds())
+		if run.Status == models.RunStatusFailed {
+			createdAt := run.CreatedAt
+			if firstFailure == nil {
+				firstFailure = &createdAt
+			}
+			lastFailure = &createdAt
+		}
+	}
+
+	countsJSON, _ := json.Marshal(counts)
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	return models.MonitorRunDailySummary{
+		MonitorID:     monitorID,
+		Day:           day,
+		TotalCount:    len(runs),
+		CountByStatus: string(countsJSON),
+		P50DurationMs: percentile(durations, 0.5),
+		P95DurationMs: percentile(durations, 0.95),
+		FirstFailure:  firstFailure,
+		LastFailure:   lastFailure,
+	}
+}
+
+// percentile returns the value at quantile q (0-1) of a sorted slice: the
+// element at index floor(q*(len-1)), with no interpolation between
+// neighbours. Returns 0 for an empty slice.
+func percentile(sorted []int64, q float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(q * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
 // --- MonitorRun operations ---
 
 // CreateMonitorRun creates a new monitor run record
@@ -221,3 +330,69 @@ func (r *MonitorRepo) ListPendingOrRunningRuns(limit int) ([]models.MonitorRun,
 		Find(&runs).Error
 	return runs, err
 }
+
+// ClaimPendingRuns atomically claims up to limit pending runs for workerID.
+// The inner SELECT ... FOR UPDATE SKIP LOCKED picks rows no other
+// transaction currently holds, so concurrent worker replicas calling this
+// never claim the same run.
+func (r *MonitorRepo) ClaimPendingRuns(workerID string, leaseDuration time.Duration, limit int) ([]models.MonitorRun, error) {
+	var runs []models.MonitorRun
+	leaseExpiresAt := time.Now().Add(leaseDuration)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var candidates []models.MonitorRun
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.RunStatusPending).
+			Order("created_at ASC").
+			Limit(limit).
+			Find(&candidates).Error; err != nil {
+			return err
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(candidates))
+		for i, run := range candidates {
+			ids[i] = run.ID
+		}
+
+		if err := tx.Model(&models.MonitorRun{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":           models.RunStatusRunning,
+			"locked_by":        workerID,
+			"lease_expires_at": leaseExpiresAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id IN ?", ids).Find(&runs).Error
+	})
+	return runs, err
+}
+
+// RenewLease extends a claimed run's lease, failing with
+// gorm.ErrRecordNotFound if workerID no longer holds it.
+func (r *MonitorRepo) RenewLease(runID uuid.UUID, workerID string, leaseDuration time.Duration) error {
+	result := r.db.Model(&models.MonitorRun{}).
+		Where("id = ? AND locked_by = ?", runID, workerID).
+		Update("lease_expires_at", time.Now().Add(leaseDuration))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ReleasePendingRuns resets runs whose lease has expired back to pending so
+// another worker can claim them, and returns how many were reclaimed.
+func (r *MonitorRepo) ReleasePendingRuns() (int64, error) {
+	result := r.db.Model(&models.MonitorRun{}).
+		Where("status = ? AND lease_expires_at < ?", models.RunStatusRunning, time.Now()).
+		Updates(map[string]interface{}{
+			"status":    models.RunStatusPending,
+			"locked_by": nil,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// OutboxRepository persists outbox events recorded alongside gateway
+// deployment writes and polled by OutboxDispatcher for delivery.
+type OutboxRepository interface {
+	// Create inserts event using tx, so it commits atomically with whatever
+	// LLMProvider/ProviderGatewayDeployment write tx is already part of. A
+	// nil tx falls back to the repository's own db, for callers outside a
+	// transaction (e.g. tests).
+	Create(tx *gorm.DB, event *models.OutboxEvent) error
+	// Update saves the outcome of a dispatch attempt (status, error, next
+	// retry time).
+	Update(event *models.OutboxEvent) error
+	// ListDue returns pending events whose next attempt is due, in
+	// created_at order, for OutboxDispatcher's poll loop.
+	ListDue(limit int) ([]models.OutboxEvent, error)
+	// ListSince returns every event recorded for gatewayUUID after since, in
+	// created_at order, for Replay to hand a reconnecting gateway whatever it
+	// missed.
+	ListSince(gatewayUUID uuid.UUID, since time.Time) ([]models.OutboxEvent, error)
+}
+
+// OutboxRepo implements OutboxRepository using GORM.
+type OutboxRepo struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepo creates a new outbox repository.
+func NewOutboxRepo(db *gorm.DB) OutboxRepository {
+	return &OutboxRepo{db: db}
+}
+
+// Create inserts event using tx (or r.db if tx is nil).
+func (r *OutboxRepo) Create(tx *gorm.DB, event *models.OutboxEvent) error {
+	if tx == nil {
+		tx = r.db
+	}
+	return tx.Create(event).Error
+}
+
+// Update saves the outcome of a dispatch attempt.
+func (r *OutboxRepo) Update(event *models.OutboxEvent) error {
+	return r.db.Save(event).Error
+}
+
+// ListDue returns pending events whose next attempt is due.
+func (r *OutboxRepo) ListDue(limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.
+		Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", models.OutboxEventPending, time.Now()).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// ListSince returns every event recorded for gatewayUUID after since.
+func (r *OutboxRepo) ListSince(gatewayUUID uuid.UUID, since time.Time) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.
+		Where("gateway_uuid = ? AND created_at > ?", gatewayUUID, since).
+		Order("created_at ASC, idempotency_key ASC").
+		Find(&events).Error
+	return events, err
+}
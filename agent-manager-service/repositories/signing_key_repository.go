@@ -0,0 +1,97 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// SigningKeyRepository defines the interface for artifact signing key data access
+type SigningKeyRepository interface {
+	// GetActiveKey returns the organization's current signing key, i.e. the
+	// one new artifacts should be signed with.
+	GetActiveKey(orgName string) (*models.SigningKey, error)
+	// ListKeys returns every key the organization has ever signed with,
+	// most recently created first, so rotated-out keys stay available for
+	// verifying artifacts signed in the past.
+	ListKeys(orgName string) ([]models.SigningKey, error)
+	// Create persists a new signing key for the organization.
+	Create(orgName string, key *models.SigningKey) error
+	// DeactivateActiveKeys marks every currently active key for the
+	// organization as rotated out, without deleting it.
+	DeactivateActiveKeys(orgName string) error
+}
+
+// SigningKeyRepo implements SigningKeyRepository using GORM
+type SigningKeyRepo struct {
+	db *gorm.DB
+}
+
+// NewSigningKeyRepo creates a new signing key repository
+func NewSigningKeyRepo(db *gorm.DB) SigningKeyRepository {
+	return &SigningKeyRepo{db: db}
+}
+
+// GetActiveKey returns the organization's current signing key
+func (r *SigningKeyRepo) GetActiveKey(orgName string) (*models.SigningKey, error) {
+	var key models.SigningKey
+	if err := r.db.
+		Where("organization_name = ? AND active = ?", orgName, true).
+		Order("created_at DESC").
+		First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListKeys returns every key the organization has ever signed with
+func (r *SigningKeyRepo) ListKeys(orgName string) ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	if err := r.db.
+		Where("organization_name = ?", orgName).
+		Order("created_at DESC").
+		Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Create persists a new signing key for the organization
+func (r *SigningKeyRepo) Create(orgName string, key *models.SigningKey) error {
+	if key.UUID == uuid.Nil {
+		key.UUID = uuid.New()
+	}
+	key.OrganizationName = orgName
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+	return r.db.Create(key).Error
+}
+
+// DeactivateActiveKeys marks every currently active key for the
+// organization as rotated out
+func (r *SigningKeyRepo) DeactivateActiveKeys(orgName string) error {
+	now := time.Now()
+	return r.db.Model(&models.SigningKey{}).
+		Where("organization_name = ? AND active = ?", orgName, true).
+		Updates(map[string]interface{}{"active": false, "rotated_at": now}).Error
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// WebhookDeliveryRepository persists webhook subscriptions and the delivery
+// attempts made against them.
+type WebhookDeliveryRepository interface {
+	// ListActiveSubscriptions returns active subscriptions matching orgName,
+	// including org-wide subscriptions (AgentName == "") and ones scoped to
+	// agentName specifically.
+	ListActiveSubscriptions(orgName, agentName string) ([]models.WebhookSubscription, error)
+	// GetSubscriptionByID loads a single subscription, used by the delivery
+	// loop to look up where/how to sign a due delivery.
+	GetSubscriptionByID(id uuid.UUID) (*models.WebhookSubscription, error)
+
+	// CreateDelivery records a new pending delivery attempt.
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	// UpdateDelivery saves the outcome of an attempt (status, error, next
+	// retry time).
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+	// ListDueDeliveries returns pending/failed deliveries whose next attempt
+	// is due, for the dispatcher's retry loop to pick up.
+	ListDueDeliveries(limit int) ([]models.WebhookDelivery, error)
+	// ListDeadLetters returns deliveries that exhausted their retry budget,
+	// for operator inspection.
+	ListDeadLetters(subscriptionID uuid.UUID, limit, offset int) ([]models.WebhookDelivery, error)
+}
+
+// WebhookDeliveryRepo implements WebhookDeliveryRepository using GORM.
+type WebhookDeliveryRepo struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepo creates a new webhook delivery repository.
+func NewWebhookDeliveryRepo(db *gorm.DB) WebhookDeliveryRepository {
+	return &WebhookDeliveryRepo{db: db}
+}
+
+// ListActiveSubscriptions returns active subscriptions matching orgName,
+// including org-wide subscriptions (AgentName == "") and ones scoped to
+// agentName specifically.
+func (r *WebhookDeliveryRepo) ListActiveSubscriptions(orgName, agentName string) ([]models.WebhookSubscription, error) {
+	var subs []models.WebhookSubscription
+	err := r.db.Where("org_name = ? AND active = true AND (agent_name = '' OR agent_name = ?)", orgName, agentName).
+		Find(&subs).Error
+	return subs, err
+}
+
+// GetSubscriptionByID loads a single subscription by ID.
+func (r *WebhookDeliveryRepo) GetSubscriptionByID(id uuid.UUID) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	if err := r.db.Where("id = ?", id).First(&sub).Error; err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// CreateDelivery records a new pending delivery attempt.
+func (r *WebhookDeliveryRepo) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// UpdateDelivery saves the outcome of an attempt.
+func (r *WebhookDeliveryRepo) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
+
+// ListDueDeliveries returns pending/failed deliveries whose next attempt is due.
+func (r *WebhookDeliveryRepo) ListDueDeliveries(limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("status IN ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)",
+		[]models.WebhookDeliveryStatus{models.WebhookDeliveryPending, models.WebhookDeliveryFailed}, time.Now()).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ListDeadLetters returns deliveries that exhausted their retry budget.
+func (r *WebhookDeliveryRepo) ListDeadLetters(subscriptionID uuid.UUID, limit, offset int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("subscription_id = ? AND status = ?", subscriptionID, models.WebhookDeliveryDeadLetter).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&deliveries).Error
+	return deliveries, err
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils/retry"
+)
+
+// artifactSignatureAlgorithm identifies the signature scheme in
+// ArtifactSignature/PublicSigningKey so a gateway (or a future scheme
+// change) can tell what it's verifying.
+const artifactSignatureAlgorithm = "Ed25519"
+
+// Op names for the signing-key repository calls, used to label the
+// agent_manager_repo_retries_total/agent_manager_circuit_state metrics and
+// to key each call site's own CircuitBreaker.
+const (
+	opGetActiveKey         = "signing_key.get_active"
+	opDeactivateActiveKeys = "signing_key.deactivate_active"
+	opCreateKey            = "signing_key.create"
+	opListKeys             = "signing_key.list"
+)
+
+// signingKeyRepoBreakers holds one CircuitBreaker per signing-key repo
+// operation, trained to trip after 5 consecutive failures and cool down for
+// 30s, shared across calls so failures accumulate across invocations.
+var signingKeyRepoBreakers = map[string]*retry.CircuitBreaker{
+	opGetActiveKey:         retry.NewCircuitBreaker(5, 30*time.Second),
+	opDeactivateActiveKeys: retry.NewCircuitBreaker(5, 30*time.Second),
+	opCreateKey:            retry.NewCircuitBreaker(5, 30*time.Second),
+	opListKeys:             retry.NewCircuitBreaker(5, 30*time.Second),
+}
+
+// ArtifactSigningService signs packaged artifacts with an organization-
+// scoped key, so a gateway can verify an LLM provider/proxy ZIP's manifest
+// before applying the YAML inside it.
+type ArtifactSigningService interface {
+	// Sign produces a detached signature over manifest using the
+	// organization's active signing key, generating one first if the
+	// organization has never signed anything before.
+	Sign(ctx context.Context, orgName string, manifest []byte) (*models.ArtifactSignature, error)
+	// RotateKey generates a new active signing key for the organization,
+	// deactivating (but not deleting) the previous one so artifacts it
+	// already signed remain verifiable against the published key set.
+	RotateKey(ctx context.Context, orgName string) (*models.PublicSigningKey, error)
+	// PublicKeys returns every key the organization has ever signed with,
+	// active or rotated-out, for GET /api/internal/v1/trust/keys.
+	PublicKeys(ctx context.Context, orgName string) ([]models.PublicSigningKey, error)
+}
+
+type artifactSigningService struct {
+	logger  *slog.Logger
+	keyRepo repositories.SigningKeyRepository
+}
+
+// NewArtifactSigningService creates a new artifact signing service
+func NewArtifactSigningService(logger *slog.Logger, keyRepo repositories.SigningKeyRepository) ArtifactSigningService {
+	return &artifactSigningService{logger: logger, keyRepo: keyRepo}
+}
+
+// Sign produces a detached signature over manifest using the
+// organization's active signing key
+func (s *artifactSigningService) Sign(ctx context.Context, orgName string, manifest []byte) (*models.ArtifactSignature, error) {
+	key, err := s.activeKey(ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := s.decryptPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := ed25519.Sign(privateKey, manifest)
+
+	return &models.ArtifactSignature{
+		KID:       key.KID,
+		Algorithm: artifactSignatureAlgorithm,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// RotateKey generates a new active signing key for the organization
+func (s *artifactSigningService) RotateKey(ctx context.Context, orgName string) (*models.PublicSigningKey, error) {
+	err := retry.DoWithBreaker(ctx, opDeactivateActiveKeys, signingKeyRepoBreakers[opDeactivateActiveKeys], retry.DefaultPolicy, retry.DefaultIsRetryable, func() error {
+		return s.keyRepo.DeactivateActiveKeys(orgName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to deactivate existing signing keys: %w", err)
+	}
+
+	key, err := s.generateKey(ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Rotated artifact signing key", "orgName", orgName, "kid", key.KID)
+
+	return &models.PublicSigningKey{
+		KID:       key.KID,
+		Algorithm: artifactSignatureAlgorithm,
+		PublicKey: base64.StdEncoding.EncodeToString(key.PublicKey),
+		Active:    true,
+	}, nil
+}
+
+// PublicKeys returns every key the organization has ever signed with
+func (s *artifactSigningService) PublicKeys(ctx context.Context, orgName string) ([]models.PublicSigningKey, error) {
+	var keys []models.SigningKey
+	err := retry.DoWithBreaker(ctx, opListKeys, signingKeyRepoBreakers[opListKeys], retry.DefaultPolicy, retry.DefaultIsRetryable, func() error {
+		var err error
+		keys, err = s.keyRepo.ListKeys(orgName)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	publicKeys := make([]models.PublicSigningKey, 0, len(keys))
+	for _, key := range keys {
+		publicKeys = append(publicKeys, models.PublicSigningKey{
+			KID:       key.KID,
+			Algorithm: artifactSignatureAlgorithm,
+			PublicKey: base64.StdEncoding.EncodeToString(key.PublicKey),
+			Active:    key.Active,
+		})
+	}
+	return publicKeys, nil
+}
+
+// activeKey returns the organization's active signing key, generating one
+// the first time an organization signs anything.
+func (s *artifactSigningService) activeKey(ctx context.Context, orgName string) (*models.SigningKey, error) {
+	var key *models.SigningKey
+	err := retry.DoWithBreaker(ctx, opGetActiveKey, signingKeyRepoBreakers[opGetActiveKey], retry.DefaultPolicy, retry.DefaultIsRetryable, func() error {
+		var err error
+		key, err = s.keyRepo.GetActiveKey(orgName)
+		return err
+	})
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get active signing key: %w", err)
+	}
+	return s.generateKey(ctx, orgName)
+}
+
+// generateKey creates, encrypts, and persists a fresh Ed25519 signing key
+// for the organization.
+func (s *artifactSigningService) generateKey(ctx context.Context, orgName string) (*models.SigningKey, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	encryptedPrivateKey, err := utils.EncryptAtRest(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	key := &models.SigningKey{
+		KID:                 uuid.New().String(),
+		PublicKey:           publicKey,
+		EncryptedPrivateKey: encryptedPrivateKey,
+		Active:              true,
+	}
+	err = retry.DoWithBreaker(ctx, opCreateKey, signingKeyRepoBreakers[opCreateKey], retry.DefaultPolicy, retry.DefaultIsRetryable, func() error {
+		return s.keyRepo.Create(orgName, key)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist signing key: %w", err)
+	}
+
+	s.logger.Info("Generated new artifact signing key", "orgName", orgName, "kid", key.KID)
+	return key, nil
+}
+
+// SigningKeyRetryAfter returns how long a caller acting on behalf of
+// orgName should wait before retrying a signing-key operation, for a
+// controller that got a circuit-open error back from Sign/RotateKey/
+// PublicKeys and needs a Retry-After value to put on its 503. ok is false
+// if none of this service's repo breakers are currently open for orgName.
+func SigningKeyRetryAfter(orgName string) (time.Duration, bool) {
+	for op, cb := range signingKeyRepoBreakers {
+		if wait, ok := retry.RetryAfter(op, cb, orgName); ok {
+			return wait, true
+		}
+	}
+	return 0, false
+}
+
+// decryptPrivateKey decrypts key's at-rest private key material.
+func (s *artifactSigningService) decryptPrivateKey(key *models.SigningKey) (ed25519.PrivateKey, error) {
+	decrypted, err := utils.DecryptAtRest(key.EncryptedPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key %s: %w", key.KID, err)
+	}
+	return ed25519.PrivateKey(decrypted), nil
+}
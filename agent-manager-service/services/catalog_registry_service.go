@@ -0,0 +1,359 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
+)
+
+// Media types used to package catalog entries as OCI artifacts.
+const (
+	// CatalogArtifactMediaType is the OCI artifact type for a published catalog entry.
+	CatalogArtifactMediaType = "application/vnd.wso2.agent-manager.catalog.v1+json"
+	// CatalogManifestLayerMediaType is the layer media type for the entry's template/manifest YAML.
+	CatalogManifestLayerMediaType = "application/vnd.wso2.agent-manager.catalog.manifest.v1+yaml"
+	// CatalogSchemaLayerMediaType is the layer media type for the entry's config schema.
+	CatalogSchemaLayerMediaType = "application/vnd.wso2.agent-manager.catalog.schema.v1+json"
+	// CatalogIconLayerMediaType is the layer media type for the entry's icon asset.
+	CatalogIconLayerMediaType = "application/vnd.wso2.agent-manager.catalog.icon.v1"
+)
+
+// RegistryCredentials carries the authentication material needed to talk to an OCI registry.
+// Exactly one of the three authentication modes should be populated.
+type RegistryCredentials struct {
+	// Anonymous disables authentication entirely (public registries).
+	Anonymous bool
+	// Username/Password are used for HTTP basic auth.
+	Username string
+	Password string
+	// DockerConfigJSON is the raw contents of a ~/.docker/config.json-style credential store.
+	DockerConfigJSON []byte
+}
+
+// CatalogArtifact is the set of blobs that make up a published catalog entry.
+type CatalogArtifact struct {
+	Manifest []byte // template YAML
+	Schema   []byte // optional JSON schema
+	Icon     []byte // optional icon asset
+}
+
+// catalogArtifactConfig is the OCI config blob content for a published catalog entry,
+// used to round-trip enough metadata to recreate the entry on Pull.
+type catalogArtifactConfig struct {
+	UUID             string `json:"uuid"`
+	OrganizationUUID string `json:"organizationUuid"`
+	Handle           string `json:"handle"`
+	Name             string `json:"name"`
+	Version          string `json:"version"`
+	Kind             string `json:"kind"`
+}
+
+// CatalogRegistryService publishes catalog entries to, and pulls them back from,
+// OCI-compliant registries so operators can distribute catalog content across environments.
+type CatalogRegistryService interface {
+	// Publish packages entry into an OCI image manifest and pushes it to ref
+	// (e.g. "registry.example.com/repo:tag") using creds.
+	Publish(ctx context.Context, orgUUID string, entry *models.CatalogEntry, ref string, artifact CatalogArtifact, creds RegistryCredentials) (string, error)
+	// Pull fetches the OCI artifact at ref, validates its media type, fetches its
+	// manifest/schema/icon layers back, and inserts the entry into the
+	// organization's catalog via CatalogRepository.Create.
+	Pull(ctx context.Context, orgUUID string, ref string, creds RegistryCredentials) (*models.CatalogEntry, CatalogArtifact, error)
+}
+
+type catalogRegistryService struct {
+	logger      *slog.Logger
+	catalogRepo repositories.CatalogRepository
+}
+
+// NewCatalogRegistryService creates a new OCI-backed catalog registry service.
+func NewCatalogRegistryService(logger *slog.Logger, catalogRepo repositories.CatalogRepository) CatalogRegistryService {
+	return &catalogRegistryService{
+		logger:      logger,
+		catalogRepo: catalogRepo,
+	}
+}
+
+// Publish packages the entry's manifest, schema and icon blobs into an OCI image
+// manifest and pushes it to the target registry reference.
+func (s *catalogRegistryService) Publish(ctx context.Context, orgUUID string, entry *models.CatalogEntry, ref string, artifact CatalogArtifact, creds RegistryCredentials) (string, error) {
+	s.logger.Info("Publishing catalog entry to registry", "orgUUID", orgUUID, "uuid", entry.UUID, "ref", ref)
+
+	repo, err := s.newRemoteRepository(ref, creds)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve registry reference: %w", err)
+	}
+
+	store := memory.New()
+
+	layers := make([]ocispec.Descriptor, 0, 3)
+	if len(artifact.Manifest) > 0 {
+		desc, err := pushBlob(ctx, store, CatalogManifestLayerMediaType, artifact.Manifest)
+		if err != nil {
+			return "", fmt.Errorf("failed to push manifest layer: %w", err)
+		}
+		layers = append(layers, desc)
+	}
+	if len(artifact.Schema) > 0 {
+		desc, err := pushBlob(ctx, store, CatalogSchemaLayerMediaType, artifact.Schema)
+		if err != nil {
+			return "", fmt.Errorf("failed to push schema layer: %w", err)
+		}
+		layers = append(layers, desc)
+	}
+	if len(artifact.Icon) > 0 {
+		desc, err := pushBlob(ctx, store, CatalogIconLayerMediaType, artifact.Icon)
+		if err != nil {
+			return "", fmt.Errorf("failed to push icon layer: %w", err)
+		}
+		layers = append(layers, desc)
+	}
+
+	config := catalogArtifactConfig{
+		UUID:             entry.UUID.String(),
+		OrganizationUUID: orgUUID,
+		Handle:           entry.Handle,
+		Name:             entry.Name,
+		Version:          entry.Version,
+		Kind:             entry.Kind,
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal artifact config: %w", err)
+	}
+
+	_, tag, _ := splitRegistryRef(ref)
+	packOpts := oras.PackManifestOptions{
+		Layers: layers,
+		ConfigDescriptor: &ocispec.Descriptor{
+			MediaType: CatalogArtifactMediaType,
+			Digest:    digestOf(configBytes),
+			Size:      int64(len(configBytes)),
+		},
+	}
+	if err := store.Push(ctx, *packOpts.ConfigDescriptor, bytesReader(configBytes)); err != nil {
+		return "", fmt.Errorf("failed to push config blob: %w", err)
+	}
+
+	manifestDesc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, CatalogArtifactMediaType, packOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to pack OCI manifest: %w", err)
+	}
+
+	if err := store.Tag(ctx, manifestDesc, tag); err != nil {
+		return "", fmt.Errorf("failed to tag manifest: %w", err)
+	}
+
+	if _, err := oras.Copy(ctx, store, tag, repo, tag, oras.DefaultCopyOptions); err != nil {
+		return "", fmt.Errorf("failed to push artifact to %s: %w", ref, err)
+	}
+
+	s.logger.Info("Published catalog entry", "uuid", entry.UUID, "ref", ref, "digest", manifestDesc.Digest.String())
+	return manifestDesc.Digest.String(), nil
+}
+
+// Pull fetches the artifact at ref, validates its media type, fetches its
+// manifest/schema/icon layers back, and materializes the catalog entry into
+// the given organization's catalog.
+func (s *catalogRegistryService) Pull(ctx context.Context, orgUUID string, ref string, creds RegistryCredentials) (*models.CatalogEntry, CatalogArtifact, error) {
+	s.logger.Info("Pulling catalog entry from registry", "orgUUID", orgUUID, "ref", ref)
+
+	repo, err := s.newRemoteRepository(ref, creds)
+	if err != nil {
+		return nil, CatalogArtifact{}, fmt.Errorf("failed to resolve registry reference: %w", err)
+	}
+
+	store := memory.New()
+	_, tag, _ := splitRegistryRef(ref)
+	manifestDesc, err := oras.Copy(ctx, repo, tag, store, tag, oras.DefaultCopyOptions)
+	if err != nil {
+		return nil, CatalogArtifact{}, fmt.Errorf("failed to pull artifact from %s: %w", ref, err)
+	}
+	if manifestDesc.ArtifactType != "" && manifestDesc.ArtifactType != CatalogArtifactMediaType {
+		return nil, CatalogArtifact{}, fmt.Errorf("unexpected artifact type %q for catalog entry", manifestDesc.ArtifactType)
+	}
+
+	manifestBytes, err := content.FetchAll(ctx, store, manifestDesc)
+	if err != nil {
+		return nil, CatalogArtifact{}, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, CatalogArtifact{}, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	configBytes, err := content.FetchAll(ctx, store, manifest.Config)
+	if err != nil {
+		return nil, CatalogArtifact{}, fmt.Errorf("failed to fetch artifact config: %w", err)
+	}
+	var config catalogArtifactConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return nil, CatalogArtifact{}, fmt.Errorf("failed to decode artifact config: %w", err)
+	}
+
+	artifact, err := fetchCatalogArtifactLayers(ctx, store, manifest.Layers)
+	if err != nil {
+		return nil, CatalogArtifact{}, fmt.Errorf("failed to fetch artifact layers: %w", err)
+	}
+
+	entry := &models.CatalogEntry{
+		Handle:  config.Handle,
+		Name:    config.Name,
+		Version: config.Version,
+		Kind:    config.Kind,
+	}
+	if err := s.catalogRepo.Create(orgUUID, entry); err != nil {
+		return nil, CatalogArtifact{}, fmt.Errorf("failed to insert imported catalog entry: %w", err)
+	}
+
+	s.logger.Info("Pulled catalog entry from registry", "orgUUID", orgUUID, "ref", ref, "digest", manifestDesc.Digest.String())
+	return entry, artifact, nil
+}
+
+// fetchCatalogArtifactLayers fetches every layer in layers back from store,
+// sorting each blob into the CatalogArtifact field matching its media type,
+// so Pull returns the same CatalogArtifact shape Publish was given -
+// otherwise a publish/pull round trip would silently drop the template
+// YAML/schema/icon and only recreate the bare metadata row.
+func fetchCatalogArtifactLayers(ctx context.Context, store content.Fetcher, layers []ocispec.Descriptor) (CatalogArtifact, error) {
+	var artifact CatalogArtifact
+	for _, layer := range layers {
+		data, err := content.FetchAll(ctx, store, layer)
+		if err != nil {
+			return CatalogArtifact{}, fmt.Errorf("failed to fetch layer %s: %w", layer.Digest, err)
+		}
+		switch layer.MediaType {
+		case CatalogManifestLayerMediaType:
+			artifact.Manifest = data
+		case CatalogSchemaLayerMediaType:
+			artifact.Schema = data
+		case CatalogIconLayerMediaType:
+			artifact.Icon = data
+		}
+	}
+	return artifact, nil
+}
+
+// newRemoteRepository resolves an OCI registry reference (e.g. "registry.example.com/repo:tag")
+// and attaches the appropriate credential mode.
+func (s *catalogRegistryService) newRemoteRepository(ref string, creds RegistryCredentials) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if creds.Anonymous {
+		return repo, nil
+	}
+
+	client := &auth.Client{
+		Client: retry.DefaultClient,
+		Cache:  auth.NewCache(),
+	}
+
+	if len(creds.DockerConfigJSON) > 0 {
+		cred, err := credentialFromDockerConfig(repo.Reference.Registry, creds.DockerConfigJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse docker config credentials: %w", err)
+		}
+		client.Credential = auth.StaticCredential(repo.Reference.Registry, cred)
+	} else {
+		client.Credential = auth.StaticCredential(repo.Reference.Registry, auth.Credential{
+			Username: creds.Username,
+			Password: creds.Password,
+		})
+	}
+
+	repo.Client = client
+	return repo, nil
+}
+
+func credentialFromDockerConfig(registry string, dockerConfigJSON []byte) (auth.Credential, error) {
+	var cfg struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(dockerConfigJSON, &cfg); err != nil {
+		return auth.Credential{}, err
+	}
+	entry, ok := cfg.Auths[registry]
+	if !ok {
+		return auth.Credential{}, fmt.Errorf("no credentials found for registry %q in docker config", registry)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return auth.Credential{}, fmt.Errorf("failed to decode docker config auth for registry %q: %w", registry, err)
+	}
+	user, pass, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return auth.Credential{}, fmt.Errorf("malformed docker config auth for registry %q", registry)
+	}
+	return auth.Credential{Username: user, Password: pass}, nil
+}
+
+// pushBlob pushes a single content-addressed blob to store and returns its descriptor.
+func pushBlob(ctx context.Context, store *memory.Store, mediaType string, data []byte) (ocispec.Descriptor, error) {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digestOf(data),
+		Size:      int64(len(data)),
+	}
+	if err := store.Push(ctx, desc, bytesReader(data)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// digestOf computes the content digest used to address a blob in the OCI store.
+func digestOf(data []byte) digest.Digest {
+	return digest.FromBytes(data)
+}
+
+func bytesReader(data []byte) io.Reader {
+	return bytes.NewReader(data)
+}
+
+// splitRegistryRef splits a "registry/repo:tag" reference into its registry+repo
+// portion and the tag, defaulting to "latest" when no tag is present.
+func splitRegistryRef(ref string) (repoPart string, tag string, err error) {
+	idx := strings.LastIndex(ref, ":")
+	// Guard against matching a port separator (e.g. "registry.example.com:5000/repo").
+	if idx < 0 || strings.Contains(ref[idx:], "/") {
+		return ref, "latest", nil
+	}
+	return ref[:idx], ref[idx+1:], nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -27,62 +28,186 @@ import (
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
 )
 
+// ListCatalogParams filters and pages a ListCatalog query. Cursor, when set,
+// takes precedence over Offset; Offset is kept so existing offset/limit
+// callers keep working unchanged while new callers migrate to cursors.
+type ListCatalogParams struct {
+	Kinds         []string
+	HandlePrefix  string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Limit         int
+	Offset        int
+	Cursor        string
+}
+
+// CatalogPage is a single page of a ListCatalog query, plus the cursor for
+// fetching the next page (empty once the result set is exhausted).
+type CatalogPage struct {
+	Entries    []models.CatalogEntry
+	Total      int64
+	NextCursor string
+}
+
+// SearchCatalogParams filters, facets, sorts, and pages a SearchCatalog
+// query. Cursor, when set, takes precedence over Offset, same as
+// ListCatalogParams.
+type SearchCatalogParams struct {
+	Terms      string
+	Kinds      []string
+	Tags       []string
+	Publishers []string
+	Statuses   []models.CatalogStatus
+	Sort       repositories.CatalogSort
+	Limit      int
+	Offset     int
+	Cursor     string
+}
+
+// CatalogSearchPage is a single page of a SearchCatalog query, plus facet
+// counts over the whole matching result set and the cursor for the next
+// page.
+type CatalogSearchPage struct {
+	Entries    []models.CatalogEntry
+	Total      int64
+	Facets     repositories.CatalogFacets
+	NextCursor string
+}
+
 // CatalogService defines the interface for catalog operations
 type CatalogService interface {
-	ListCatalog(ctx context.Context, orgUUID string, kind string, limit, offset int) ([]models.CatalogEntry, int64, error)
+	ListCatalog(ctx context.Context, orgUUID string, params ListCatalogParams) (CatalogPage, error)
+	// SearchCatalog lists catalog entries for orgUUID matching params, with
+	// full-text ranking over name/description/tags, faceted filters, and
+	// facet counts over the whole matching set (not just the page).
+	SearchCatalog(ctx context.Context, orgUUID string, params SearchCatalogParams) (CatalogSearchPage, error)
+	// InstantiateLLMProvider renders the catalog's LLM provider template identified by
+	// handle against values and inserts the concretized provider into the organization's
+	// catalog. This lets a single parameterized template (e.g. "openai-compatible")
+	// serve many gateways without duplicating catalog entries.
+	InstantiateLLMProvider(ctx context.Context, orgUUID, handle string, values map[string]any) (*models.CatalogEntry, error)
 }
 
 type catalogService struct {
-	logger      *slog.Logger
-	catalogRepo repositories.CatalogRepository
+	logger        *slog.Logger
+	catalogRepo   repositories.CatalogRepository
+	templateStore *LLMTemplateStore
 }
 
 // NewCatalogService creates a new catalog service
-func NewCatalogService(logger *slog.Logger, catalogRepo repositories.CatalogRepository) CatalogService {
+func NewCatalogService(logger *slog.Logger, catalogRepo repositories.CatalogRepository, templateStore *LLMTemplateStore) CatalogService {
 	return &catalogService{
-		logger:      logger,
-		catalogRepo: catalogRepo,
+		logger:        logger,
+		catalogRepo:   catalogRepo,
+		templateStore: templateStore,
 	}
 }
 
-// ListCatalog retrieves catalog entries filtered by kind and organization
-func (s *catalogService) ListCatalog(ctx context.Context, orgUUID string, kind string, limit, offset int) ([]models.CatalogEntry, int64, error) {
+// ListCatalog retrieves a page of catalog entries for orgUUID matching params.
+func (s *catalogService) ListCatalog(ctx context.Context, orgUUID string, params ListCatalogParams) (CatalogPage, error) {
 	s.logger.Info("Listing catalog entries",
 		"orgUUID", orgUUID,
-		"kind", kind,
-		"limit", limit,
-		"offset", offset)
+		"kinds", params.Kinds,
+		"handlePrefix", params.HandlePrefix,
+		"limit", params.Limit,
+		"offset", params.Offset,
+		"hasCursor", params.Cursor != "")
 
 	// Validate orgUUID
 	if _, err := uuid.Parse(orgUUID); err != nil {
 		s.logger.Error("Invalid organization UUID", "orgUUID", orgUUID, "error", err)
-		return nil, 0, fmt.Errorf("invalid organization UUID: %w", err)
+		return CatalogPage{}, fmt.Errorf("invalid organization UUID: %w", err)
 	}
 
-	var entries []models.CatalogEntry
-	var total int64
-	var err error
-
-	// Query based on kind filter
-	if kind == "" {
-		// No kind filter - return all catalog entries
-		entries, total, err = s.catalogRepo.ListAll(orgUUID, limit, offset)
-	} else {
-		// Filter by specific kind
-		entries, total, err = s.catalogRepo.ListByKind(orgUUID, kind, limit, offset)
+	filter := repositories.CatalogFilter{
+		Kinds:         params.Kinds,
+		HandlePrefix:  params.HandlePrefix,
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
 	}
 
+	entries, total, nextCursor, err := s.catalogRepo.ListPage(orgUUID, filter, params.Limit, params.Offset, params.Cursor)
 	if err != nil {
 		s.logger.Error("Failed to list catalog entries",
 			"orgUUID", orgUUID,
-			"kind", kind,
+			"kinds", params.Kinds,
 			"error", err)
-		return nil, 0, fmt.Errorf("failed to list catalog entries: %w", err)
+		return CatalogPage{}, fmt.Errorf("failed to list catalog entries: %w", err)
 	}
 
 	s.logger.Info("Successfully listed catalog entries",
 		"count", len(entries),
 		"total", total)
 
-	return entries, total, nil
+	return CatalogPage{Entries: entries, Total: total, NextCursor: nextCursor}, nil
+}
+
+// SearchCatalog retrieves a page of catalog entries for orgUUID matching
+// params, plus facet counts over the whole matching result set.
+func (s *catalogService) SearchCatalog(ctx context.Context, orgUUID string, params SearchCatalogParams) (CatalogSearchPage, error) {
+	s.logger.Info("Searching catalog entries",
+		"orgUUID", orgUUID,
+		"terms", params.Terms,
+		"kinds", params.Kinds,
+		"sort", params.Sort,
+		"limit", params.Limit,
+		"offset", params.Offset,
+		"hasCursor", params.Cursor != "")
+
+	if _, err := uuid.Parse(orgUUID); err != nil {
+		s.logger.Error("Invalid organization UUID", "orgUUID", orgUUID, "error", err)
+		return CatalogSearchPage{}, fmt.Errorf("invalid organization UUID: %w", err)
+	}
+
+	query := repositories.CatalogQuery{
+		Terms:      params.Terms,
+		Kinds:      params.Kinds,
+		Tags:       params.Tags,
+		Publishers: params.Publishers,
+		Statuses:   params.Statuses,
+		Sort:       params.Sort,
+	}
+
+	entries, total, facets, nextCursor, err := s.catalogRepo.Search(orgUUID, query, params.Limit, params.Offset, params.Cursor)
+	if err != nil {
+		s.logger.Error("Failed to search catalog entries",
+			"orgUUID", orgUUID,
+			"terms", params.Terms,
+			"error", err)
+		return CatalogSearchPage{}, fmt.Errorf("failed to search catalog entries: %w", err)
+	}
+
+	s.logger.Info("Successfully searched catalog entries",
+		"count", len(entries),
+		"total", total)
+
+	return CatalogSearchPage{Entries: entries, Total: total, Facets: facets, NextCursor: nextCursor}, nil
+}
+
+// InstantiateLLMProvider renders the named LLM provider template against values
+// and persists the resulting provider as a catalog entry for the organization.
+func (s *catalogService) InstantiateLLMProvider(ctx context.Context, orgUUID, handle string, values map[string]any) (*models.CatalogEntry, error) {
+	s.logger.Info("Instantiating LLM provider from template", "orgUUID", orgUUID, "handle", handle)
+
+	if _, err := uuid.Parse(orgUUID); err != nil {
+		return nil, fmt.Errorf("invalid organization UUID: %w", err)
+	}
+
+	rendered, err := s.templateStore.Render(handle, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template %q: %w", handle, err)
+	}
+
+	entry := &models.CatalogEntry{
+		Handle:  rendered.Handle,
+		Name:    rendered.Handle,
+		Version: "1",
+		Kind:    models.CatalogKindLLMProvider,
+	}
+	if err := s.catalogRepo.Create(orgUUID, entry); err != nil {
+		return nil, fmt.Errorf("failed to persist instantiated provider: %w", err)
+	}
+
+	s.logger.Info("Instantiated LLM provider", "orgUUID", orgUUID, "handle", handle, "entryUUID", entry.UUID)
+	return entry, nil
 }
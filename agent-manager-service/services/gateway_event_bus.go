@@ -0,0 +1,133 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"sync"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// gatewayEventRingBufferSize bounds how many past events per gateway
+// GatewayEventBus keeps around for Last-Event-ID replay; old events fall
+// off once a gateway has been reconnecting for a while.
+const gatewayEventRingBufferSize = 200
+
+// gatewaySubscriberBufferSize is how many unread events a single SSE
+// subscriber channel can hold before Publish starts dropping for it,
+// trading a slow subscriber's freshness for never blocking the publisher.
+const gatewaySubscriberBufferSize = 32
+
+// GatewayEventEnvelope pairs a published event with the monotonically
+// increasing ID a client can later resume from via Last-Event-ID.
+type GatewayEventEnvelope struct {
+	ID    int64
+	Event models.GatewayArtifactChangeEvent
+}
+
+// GatewayEventBus fans out gateway artifact-change events to per-gateway
+// subscribers (the GetEvents SSE handler), keeping a short in-memory ring
+// buffer per gateway so a client reconnecting with Last-Event-ID after a
+// brief disconnect doesn't miss events published while it was away.
+//
+// This deliberately has no message broker behind it: events are best-effort
+// and lost on process restart, the same tradeoff utils.ZipArtifactCache
+// makes for not requiring a write-path invalidation hook.
+type GatewayEventBus interface {
+	// Publish appends event to gatewayID's ring buffer and delivers it to
+	// every currently-subscribed channel for that gateway.
+	Publish(gatewayID string, event models.GatewayArtifactChangeEvent)
+
+	// Subscribe registers a new subscriber channel for gatewayID and
+	// returns it, any buffered events with id > lastEventID (0 replays
+	// nothing, i.e. start from now), and an unsubscribe func the caller
+	// must call once it stops listening.
+	Subscribe(gatewayID string, lastEventID int64) (events <-chan GatewayEventEnvelope, replay []GatewayEventEnvelope, unsubscribe func())
+}
+
+type gatewayEventBus struct {
+	mu          sync.Mutex
+	nextID      int64
+	ringBuffers map[string][]GatewayEventEnvelope
+	subscribers map[string]map[chan GatewayEventEnvelope]struct{}
+}
+
+// NewGatewayEventBus creates a new, empty in-memory gateway event bus.
+func NewGatewayEventBus() GatewayEventBus {
+	return &gatewayEventBus{
+		ringBuffers: make(map[string][]GatewayEventEnvelope),
+		subscribers: make(map[string]map[chan GatewayEventEnvelope]struct{}),
+	}
+}
+
+// Publish appends event to gatewayID's ring buffer and delivers it to every
+// currently-subscribed channel for that gateway.
+func (b *gatewayEventBus) Publish(gatewayID string, event models.GatewayArtifactChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	envelope := GatewayEventEnvelope{ID: b.nextID, Event: event}
+
+	buf := append(b.ringBuffers[gatewayID], envelope)
+	if len(buf) > gatewayEventRingBufferSize {
+		buf = buf[len(buf)-gatewayEventRingBufferSize:]
+	}
+	b.ringBuffers[gatewayID] = buf
+
+	for ch := range b.subscribers[gatewayID] {
+		select {
+		case ch <- envelope:
+		default:
+			// Slow subscriber; drop rather than block Publish. It can
+			// still catch up on reconnect via the ring buffer replay.
+		}
+	}
+}
+
+// Subscribe registers a new subscriber channel for gatewayID
+func (b *gatewayEventBus) Subscribe(gatewayID string, lastEventID int64) (<-chan GatewayEventEnvelope, []GatewayEventEnvelope, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []GatewayEventEnvelope
+	if lastEventID > 0 {
+		for _, envelope := range b.ringBuffers[gatewayID] {
+			if envelope.ID > lastEventID {
+				replay = append(replay, envelope)
+			}
+		}
+	}
+
+	ch := make(chan GatewayEventEnvelope, gatewaySubscriberBufferSize)
+	if b.subscribers[gatewayID] == nil {
+		b.subscribers[gatewayID] = make(map[chan GatewayEventEnvelope]struct{})
+	}
+	b.subscribers[gatewayID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers[gatewayID], ch)
+		if len(b.subscribers[gatewayID]) == 0 {
+			delete(b.subscribers, gatewayID)
+		}
+		close(ch)
+	}
+
+	return ch, replay, unsubscribe
+}
@@ -17,7 +17,10 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -29,6 +32,8 @@ import (
 
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/db"
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
 )
 
 // GatewayInternalService handles internal API operations for gateways
@@ -37,7 +42,11 @@ type GatewayInternalService interface {
 	// GetAPIsByOrganization retrieves all LLM providers for an organization as YAML map
 	// Endpoint: GET /api/internal/v1/apis
 	// Returns: map of API ID to YAML content (for ZIP creation)
-	GetAPIsByOrganization(ctx context.Context, orgID string) (map[string]string, error)
+	// gatewayID identifies the calling gateway, used only to group and
+	// validate any RoutingPolicy docs emitted alongside the per-provider
+	// ones; every provider in the organization is still returned regardless
+	// of which gateway it's deployed to, as before.
+	GetAPIsByOrganization(ctx context.Context, orgID string, gatewayID string) (map[string]string, error)
 
 	// GetAPI returns an LLM provider as an API configuration (for gateway compatibility)
 	// Endpoint: GET /api/internal/v1/apis/{apiId}
@@ -48,21 +57,69 @@ type GatewayInternalService interface {
 	// Endpoint: POST /api/internal/v1/apis/{apiId}/gateway-deployments
 	// Returns: GatewayDeploymentResponse with API ID, deployment ID, message, and created flag
 	CreateGatewayDeployment(ctx context.Context, apiID string, orgID string, gatewayID string, notification *models.GatewayDeploymentNotification, revisionID *string) (*models.GatewayDeploymentResponse, error)
+
+	// GetBundleForGateway builds the single bundle of every active LLM
+	// provider deployed to gatewayID, plus the organization's agent/mcp
+	// catalog entries when orgID is a UUID, so a gateway can sync in one
+	// request instead of fetching each artifact individually. LLM proxies
+	// are not included.
+	// Endpoint: GET /api/internal/v1/bundle
+	// When since equals the bundle's manifest digest (its ETag), the
+	// manifest and files are returned empty; any other since value yields
+	// the full bundle. No per-entry diff or deletions list is computed.
+	GetBundleForGateway(ctx context.Context, orgID string, gatewayID string, since string) (*GatewayBundle, error)
+
+	// ListRevisions returns every ProviderRevision snapshot for providerID,
+	// newest first, so an operator can see what a rollback would target.
+	ListRevisions(ctx context.Context, providerID string) ([]models.ProviderRevision, error)
+
+	// GetRevision returns the snapshot for providerID numbered revisionNum.
+	GetRevision(ctx context.Context, providerID string, revisionNum int64) (*models.ProviderRevision, error)
+
+	// RollbackDeployment points gatewayID's deployment of providerID at the
+	// snapshot numbered revisionNum instead of whatever revision is
+	// currently deployed, so an operator can revert a bad rollout without
+	// the gateway re-sending its original deployment notification.
+	RollbackDeployment(ctx context.Context, gatewayID string, providerID string, revisionNum int64) (*models.GatewayDeploymentResponse, error)
+
+	// RepushConfig re-publishes providerID's currently-deployed
+	// configuration to gatewayID on the event bus, for an operator to
+	// force after a driftdetector.DeploymentDriftEvent shows the gateway
+	// fell out of sync, without waiting for the gateway's next poll.
+	RepushConfig(ctx context.Context, gatewayID string, providerID string) error
+
+	// CreateRoutingPolicy validates that every rule in policy references a
+	// provider actually DEPLOYED to policy.GatewayUUID, then creates it.
+	CreateRoutingPolicy(ctx context.Context, policy *models.RoutingPolicy) error
+}
+
+// GatewayBundle is the built ZIP body for GetBundleForGateway, plus the
+// overall ETag so the controller can honor If-None-Match without rebuilding
+// the archive.
+type GatewayBundle struct {
+	ETag string
+	Data []byte
 }
 
 type gatewayInternalService struct {
-	logger *slog.Logger
+	logger         *slog.Logger
+	catalogRepo    repositories.CatalogRepository
+	signingService ArtifactSigningService
+	eventBus       GatewayEventBus
 }
 
 // NewGatewayInternalService creates a new gateway internal service
-func NewGatewayInternalService(logger *slog.Logger) GatewayInternalService {
+func NewGatewayInternalService(logger *slog.Logger, catalogRepo repositories.CatalogRepository, signingService ArtifactSigningService, eventBus GatewayEventBus) GatewayInternalService {
 	return &gatewayInternalService{
-		logger: logger,
+		logger:         logger,
+		catalogRepo:    catalogRepo,
+		signingService: signingService,
+		eventBus:       eventBus,
 	}
 }
 
 // GetAPIsByOrganization retrieves all LLM providers for an organization as YAML map
-func (s *gatewayInternalService) GetAPIsByOrganization(ctx context.Context, orgID string) (map[string]string, error) {
+func (s *gatewayInternalService) GetAPIsByOrganization(ctx context.Context, orgID string, gatewayID string) (map[string]string, error) {
 	dbInstance := db.DB(ctx)
 
 	// Get all providers for the organization
@@ -76,8 +133,15 @@ func (s *gatewayInternalService) GetAPIsByOrganization(ctx context.Context, orgI
 
 	apiYamlMap := make(map[string]string)
 	for _, provider := range providers {
-		// Convert LLM provider to API configuration format
-		apiConfig := s.convertLLMProviderToAPIConfig(&provider, nil)
+		// Render the latest accepted snapshot rather than the live row, so
+		// an edit in progress never leaks to a gateway before it has its own
+		// RevisionUUID to pin to.
+		revision, err := s.latestRevision(ctx, provider.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load latest revision for provider %s: %w", provider.UUID, err)
+		}
+
+		apiConfig := s.convertLLMProviderToAPIConfig(&provider, revision, nil)
 
 		// Marshal to YAML
 		yamlData, err := yaml.Marshal(apiConfig)
@@ -88,9 +152,105 @@ func (s *gatewayInternalService) GetAPIsByOrganization(ctx context.Context, orgI
 		apiYamlMap[provider.UUID.String()] = string(yamlData)
 	}
 
+	// Emit the synthetic minor/major-range routes (see
+	// DeriveVersionRangeDeployments) alongside the concrete providers so a
+	// gateway syncing this organization's full API set can route unpinned
+	// requests to the newest version in each range without a separate call
+	// per gateway.
+	pairs := make([]VersionedProviderDeployment, 0, len(providers))
+	for i := range providers {
+		pairs = append(pairs, VersionedProviderDeployment{Provider: providers[i]})
+	}
+	for _, virtual := range DeriveVersionRangeDeployments(pairs) {
+		apiConfig := s.convertLLMProviderToAPIConfig(&virtual.Provider, nil, virtual.Deployment)
+		yamlData, err := yaml.Marshal(apiConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal version-range route %s: %w", virtual.Provider.Handle, err)
+		}
+		apiYamlMap[virtual.Provider.UUID.String()] = string(yamlData)
+	}
+
+	if err := s.appendRoutingPolicies(ctx, orgID, gatewayID, apiYamlMap); err != nil {
+		return nil, err
+	}
+
 	return apiYamlMap, nil
 }
 
+// appendRoutingPolicies loads every RoutingPolicy bound to gatewayID,
+// drops any rule whose provider isn't currently DEPLOYED there (logging a
+// warning rather than failing the whole response — a stale rule is an
+// authoring error in one policy, not a reason to withhold the rest of the
+// gateway's config), and merges the survivors into apiYamlMap as a single
+// kind: LLMRoutingPolicy YAML doc alongside the per-provider ones.
+func (s *gatewayInternalService) appendRoutingPolicies(ctx context.Context, orgID string, gatewayID string, apiYamlMap map[string]string) error {
+	gatewayUUID, err := uuid.Parse(gatewayID)
+	if err != nil {
+		return fmt.Errorf("invalid gateway ID: %w", err)
+	}
+
+	dbInstance := db.DB(ctx)
+
+	var policies []models.RoutingPolicy
+	if err := dbInstance.
+		Where("organization_name = ? AND gateway_uuid = ?", orgID, gatewayUUID).
+		Find(&policies).Error; err != nil {
+		return fmt.Errorf("failed to list routing policies: %w", err)
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	deployed, err := DeployedProviderUUIDs(ctx, dbInstance, gatewayUUID)
+	if err != nil {
+		return fmt.Errorf("failed to list deployed providers for gateway %s: %w", gatewayID, err)
+	}
+
+	var policyDocs []map[string]interface{}
+	for _, policy := range policies {
+		rules := make([]models.RoutingRule, 0, len(policy.Rules))
+		for _, rule := range policy.Rules {
+			if !deployed[rule.ProviderUUID] {
+				s.logger.Warn("appendRoutingPolicies: dropping stale rule, provider not deployed to gateway",
+					"policy", policy.UUID, "provider", rule.ProviderUUID, "gateway", gatewayID)
+				continue
+			}
+			rules = append(rules, rule)
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		policyDocs = append(policyDocs, map[string]interface{}{
+			"name":         policy.Name,
+			"virtualModel": policy.VirtualModel,
+			"rules":        rules,
+		})
+	}
+	if len(policyDocs) == 0 {
+		return nil
+	}
+
+	routingConfig := map[string]interface{}{
+		"apiVersion": "gateway.agent-manager.wso2.com/v1alpha1",
+		"kind":       "LLMRoutingPolicy",
+		"metadata": map[string]interface{}{
+			"gatewayUid": gatewayID,
+		},
+		"spec": map[string]interface{}{
+			"policies": policyDocs,
+		},
+	}
+
+	yamlData, err := yaml.Marshal(routingConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal routing policies for gateway %s: %w", gatewayID, err)
+	}
+
+	apiYamlMap["routing-policy:"+gatewayID] = string(yamlData)
+	return nil
+}
+
 // GetAPI returns an LLM provider configuration in api-platform compatible format
 // The apiID parameter is actually the provider UUID
 func (s *gatewayInternalService) GetAPI(ctx context.Context, apiID string, gatewayID string) (map[string]string, error) {
@@ -127,8 +287,16 @@ func (s *gatewayInternalService) GetAPI(ctx context.Context, apiID string, gatew
 		return nil, fmt.Errorf("failed to get provider: %w", err)
 	}
 
+	// Render the exact snapshot this gateway's deployment points at, rather
+	// than the live provider row, so an in-progress edit to provider never
+	// reaches a gateway until it is redeployed against a newer revision.
+	revision, err := s.loadRevision(ctx, deployment.RevisionUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deployed revision: %w", err)
+	}
+
 	// Convert LLM provider to API configuration format (compatible with api-platform)
-	apiConfig := s.convertLLMProviderToAPIConfig(&provider, &deployment)
+	apiConfig := s.convertLLMProviderToAPIConfig(&provider, revision, &deployment)
 
 	// Marshal to YAML
 	yamlData, err := yaml.Marshal(apiConfig)
@@ -141,32 +309,166 @@ func (s *gatewayInternalService) GetAPI(ctx context.Context, apiID string, gatew
 		providerUUID.String(): string(yamlData),
 	}
 
+	// Also emit the synthetic minor/major-range routes currently topped by
+	// this gateway's deployments sharing provider.Handle (see
+	// DeriveVersionRangeDeployments), so the gateway can route e.g.
+	// POST /openai/1/chat to the newest 1.x version without a dedicated
+	// call per range. A failure here only drops the synthetic routes from
+	// this response; the concrete provider above is still returned.
+	siblings, err := s.loadSiblingDeployments(ctx, gatewayUUID, provider.Handle)
+	if err != nil {
+		s.logger.Warn("GetAPI: failed to load sibling deployments for version-range routing",
+			"provider", provider.UUID, "gateway", gatewayUUID, "error", err)
+		return apiYamlMap, nil
+	}
+
+	for _, virtual := range DeriveVersionRangeDeployments(siblings) {
+		var virtualRevision *models.ProviderRevision
+		if virtual.Deployment != nil {
+			virtualRevision, err = s.loadRevision(ctx, virtual.Deployment.RevisionUUID)
+			if err != nil {
+				s.logger.Warn("GetAPI: failed to load revision for version-range route", "handle", virtual.Provider.Handle, "error", err)
+			}
+		}
+
+		apiConfig := s.convertLLMProviderToAPIConfig(&virtual.Provider, virtualRevision, virtual.Deployment)
+		virtualYAML, err := yaml.Marshal(apiConfig)
+		if err != nil {
+			s.logger.Warn("GetAPI: failed to marshal version-range route", "handle", virtual.Provider.Handle, "error", err)
+			continue
+		}
+		apiYamlMap[virtual.Provider.UUID.String()] = string(virtualYAML)
+	}
+
 	return apiYamlMap, nil
 }
 
-// convertLLMProviderToAPIConfig converts an LLM provider to api-platform's APIConfiguration format
-func (s *gatewayInternalService) convertLLMProviderToAPIConfig(provider *models.LLMProvider, deployment *models.ProviderGatewayDeployment) map[string]interface{} {
+// loadSiblingDeployments loads every DEPLOYED provider sharing handle on
+// gatewayUUID, for DeriveVersionRangeDeployments to group into version
+// ranges. Mirrors GetBundleForGateway's pattern of fetching deployments
+// then resolving each one's provider, rather than a SQL join, since the two
+// tables are otherwise queried independently throughout this file.
+func (s *gatewayInternalService) loadSiblingDeployments(ctx context.Context, gatewayUUID uuid.UUID, handle string) ([]VersionedProviderDeployment, error) {
+	dbInstance := db.DB(ctx)
+
+	var deployments []models.ProviderGatewayDeployment
+	if err := dbInstance.
+		Where("gateway_uuid = ? AND status = ?", gatewayUUID, "DEPLOYED").
+		Find(&deployments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list gateway deployments: %w", err)
+	}
+
+	pairs := make([]VersionedProviderDeployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		var sibling models.LLMProvider
+		if err := dbInstance.Where("uuid = ?", deployment.ProviderUUID).First(&sibling).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to load provider %s: %w", deployment.ProviderUUID, err)
+		}
+		if sibling.Handle != handle {
+			continue
+		}
+
+		d := deployment
+		pairs = append(pairs, VersionedProviderDeployment{Provider: sibling, Deployment: &d})
+	}
+
+	return pairs, nil
+}
+
+// loadRevision loads the exact ProviderRevision snapshot revisionUUID
+// points at, so what's rendered to a gateway reflects what was actually
+// deployed rather than whatever the live provider row has since become.
+func (s *gatewayInternalService) loadRevision(ctx context.Context, revisionUUID uuid.UUID) (*models.ProviderRevision, error) {
+	dbInstance := db.DB(ctx)
+
+	var revision models.ProviderRevision
+	if err := dbInstance.Where("uuid = ?", revisionUUID).First(&revision).Error; err != nil {
+		return nil, fmt.Errorf("failed to load revision %s: %w", revisionUUID, err)
+	}
+
+	return &revision, nil
+}
+
+// latestRevision returns the highest-numbered ProviderRevision snapshot for
+// providerUUID, or nil if the provider has none yet (e.g. it predates this
+// subsystem). Used where there is no specific deployment, and therefore no
+// RevisionUUID, to pin to — GetAPIsByOrganization's organization-wide list.
+func (s *gatewayInternalService) latestRevision(ctx context.Context, providerUUID uuid.UUID) (*models.ProviderRevision, error) {
+	dbInstance := db.DB(ctx)
+
+	var revision models.ProviderRevision
+	err := dbInstance.
+		Where("provider_uuid = ?", providerUUID).
+		Order("revision_number DESC").
+		First(&revision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load latest revision for provider %s: %w", providerUUID, err)
+	}
+
+	return &revision, nil
+}
+
+// convertLLMProviderToAPIConfig converts an LLM provider to api-platform's
+// APIConfiguration format. The displayed template/configuration/displayName
+// come from revision when present, rather than the live provider row, so
+// an edit in progress never leaks to a gateway before it is deployed as a
+// new revision; revision is nil for contexts with no applicable snapshot
+// (a provider created before this subsystem existed, or a synthetic
+// version-range route), in which case the live provider fields are used as
+// before. deployment is nil where no single deployment applies
+// (GetAPIsByOrganization); environment and deploymentType are then left
+// out of spec.
+func (s *gatewayInternalService) convertLLMProviderToAPIConfig(provider *models.LLMProvider, revision *models.ProviderRevision, deployment *models.ProviderGatewayDeployment) map[string]interface{} {
+	displayName := provider.DisplayName
+	template := provider.Template
+	configuration := provider.Configuration
+	if revision != nil {
+		displayName = revision.DisplayName
+		template = revision.Template
+		configuration = revision.Configuration
+	}
+
+	spec := map[string]interface{}{
+		"template":      template,
+		"configuration": configuration,
+	}
+	if deployment != nil {
+		spec["environment"] = deployment.Environment
+		spec["deploymentType"] = deployment.Type
+	}
+
 	// This structure must match api-platform's APIConfiguration format
 	return map[string]interface{}{
 		"apiVersion": "gateway.agent-manager.wso2.com/v1alpha1",
 		"kind":       "LLMProvider",
 		"metadata": map[string]interface{}{
 			"name":        provider.Handle,
-			"displayName": provider.DisplayName,
+			"displayName": displayName,
 			"uid":         provider.UUID.String(),
+			"version":     provider.Version,
 		},
-		"spec": map[string]interface{}{
-			"template":      provider.Template,
-			"configuration": provider.Configuration,
-			"environment":   deployment.Environment,
-		},
+		"spec": spec,
 	}
 }
 
 // CreateGatewayDeployment handles the registration of an LLM provider deployment from a gateway
 // Compatible with api-platform's CreateGatewayDeployment logic
 func (s *gatewayInternalService) CreateGatewayDeployment(ctx context.Context, apiID string, orgID string, gatewayID string, notification *models.GatewayDeploymentNotification, revisionID *string) (*models.GatewayDeploymentResponse, error) {
-	// Note: revisionID parameter is reserved for future use
+	// Note: revisionID is the gateway's own external revision tag (e.g. a CI
+	// pipeline's commit SHA) and is distinct from the ProviderRevision
+	// snapshot created below, which is this service's own immutable
+	// (Template, Configuration, DisplayName) record. revisionID itself
+	// remains reserved for future use: once deployment history tracks the
+	// previously deployed external tag, this should call
+	// gitprovider.Provider.CompareCommits(previousRevisionID, revisionID) so
+	// the response can describe what changed instead of just the new opaque
+	// tag.
 	_ = revisionID
 
 	dbInstance := db.DB(ctx)
@@ -197,6 +499,7 @@ func (s *gatewayInternalService) CreateGatewayDeployment(ctx context.Context, ap
 	providerCreated := false
 	now := time.Now()
 	var providerUUID string
+	var provider models.LLMProvider
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		// Create new provider from notification
@@ -218,12 +521,14 @@ func (s *gatewayInternalService) CreateGatewayDeployment(ctx context.Context, ap
 			return nil, fmt.Errorf("failed to create provider: %w", err)
 		}
 
+		provider = *newProvider
 		providerUUID = newProvider.UUID.String()
 		providerCreated = true
 	} else if err != nil {
 		return nil, fmt.Errorf("failed to check existing provider: %w", err)
 	} else {
 		// Provider exists
+		provider = existingProvider
 		providerUUID = existingProvider.UUID.String()
 	}
 
@@ -243,19 +548,30 @@ func (s *gatewayInternalService) CreateGatewayDeployment(ctx context.Context, ap
 		return nil, fmt.Errorf("failed to check deployment status: %w", err)
 	}
 
+	// Snapshot the provider's current configuration as a new revision. A
+	// brand-new provider gets revision 1; an existing one otherwise
+	// deployed before gets the next number, even if its configuration
+	// happens to be unchanged — CreateGatewayDeployment only runs when a
+	// gateway is actually (re)registering this deployment.
+	revision, err := CreateProviderRevision(ctx, dbInstance, &provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot provider revision: %w", err)
+	}
+
 	// Create deployment record
 	deployment := &models.ProviderGatewayDeployment{
-		ProviderUUID:         uuid.MustParse(providerUUID),
-		GatewayUUID:          uuid.MustParse(gatewayID),
-		DeploymentID:         notification.ID,
-		Environment:          notification.Configuration.Spec.Context, // Use context as environment
-		ConfigurationVersion: 1,
-		GatewayOverrides:     nil,
-		Status:               notification.Status,
-		DeployedAt:           nil,
-		ErrorMessage:         nil,
-		CreatedAt:            now,
-		UpdatedAt:            now,
+		ProviderUUID:     uuid.MustParse(providerUUID),
+		GatewayUUID:      uuid.MustParse(gatewayID),
+		DeploymentID:     notification.ID,
+		Environment:      notification.Configuration.Spec.Context, // Use context as environment
+		RevisionUUID:     revision.UUID,
+		GatewayOverrides: nil,
+		Type:             models.DeploymentTypeDefault,
+		Status:           notification.Status,
+		DeployedAt:       nil,
+		ErrorMessage:     nil,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}
 
 	if notification.Status == "DEPLOYED" {
@@ -274,6 +590,8 @@ func (s *gatewayInternalService) CreateGatewayDeployment(ctx context.Context, ap
 		"created", providerCreated,
 	)
 
+	s.publishArtifactChange(ctx, gatewayID, &provider, deployment, providerCreated)
+
 	return &models.GatewayDeploymentResponse{
 		APIId:        providerUUID,
 		DeploymentId: int64(deployment.ID),
@@ -282,6 +600,400 @@ func (s *gatewayInternalService) CreateGatewayDeployment(ctx context.Context, ap
 	}, nil
 }
 
+// publishArtifactChange emits a GatewayArtifactChangeEvent on the event bus
+// for gatewayID so a gateway streaming GET /api/internal/v1/events learns
+// about this deployment without having to re-poll GetAPI/GetBundle. The
+// event's ETag is the same content digest GetAPI would compute for this
+// provider, so the gateway can skip the conditional GET if it already has
+// that exact version. Publishing never fails the deployment request itself:
+// a dropped event just means the gateway falls back to its next poll.
+func (s *gatewayInternalService) publishArtifactChange(ctx context.Context, gatewayID string, provider *models.LLMProvider, deployment *models.ProviderGatewayDeployment, created bool) {
+	if s.eventBus == nil {
+		return
+	}
+
+	revision, err := s.loadRevision(ctx, deployment.RevisionUUID)
+	if err != nil {
+		s.logger.Error("publishArtifactChange: failed to load deployed revision", "provider", provider.UUID, "error", err)
+		return
+	}
+
+	apiConfig := s.convertLLMProviderToAPIConfig(provider, revision, deployment)
+	yamlData, err := yaml.Marshal(apiConfig)
+	if err != nil {
+		s.logger.Error("publishArtifactChange: failed to marshal provider for ETag", "provider", provider.UUID, "error", err)
+		return
+	}
+
+	action := models.GatewayArtifactActionUpdated
+	if created {
+		action = models.GatewayArtifactActionCreated
+	}
+
+	s.eventBus.Publish(gatewayID, models.GatewayArtifactChangeEvent{
+		Kind:    models.CatalogKindLLMProvider,
+		UUID:    provider.UUID.String(),
+		Version: fmt.Sprintf("%d", revision.RevisionNumber),
+		ETag:    utils.SHA256Hex(yamlData),
+		Action:  action,
+	})
+}
+
+// ListRevisions returns every ProviderRevision snapshot for providerID,
+// newest first, so an operator can see what a rollback would target.
+func (s *gatewayInternalService) ListRevisions(ctx context.Context, providerID string) ([]models.ProviderRevision, error) {
+	providerUUID, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider ID: %w", err)
+	}
+
+	dbInstance := db.DB(ctx)
+
+	var revisions []models.ProviderRevision
+	if err := dbInstance.
+		Where("provider_uuid = ?", providerUUID).
+		Order("revision_number DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetRevision returns the snapshot for providerID numbered revisionNum.
+func (s *gatewayInternalService) GetRevision(ctx context.Context, providerID string, revisionNum int64) (*models.ProviderRevision, error) {
+	providerUUID, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider ID: %w", err)
+	}
+
+	dbInstance := db.DB(ctx)
+
+	var revision models.ProviderRevision
+	err = dbInstance.
+		Where("provider_uuid = ? AND revision_number = ?", providerUUID, revisionNum).
+		First(&revision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("revision %d not found for provider %s", revisionNum, providerID)
+		}
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	return &revision, nil
+}
+
+// RollbackDeployment points gatewayID's deployment of providerID at the
+// revisionNum snapshot instead of whatever revision is currently deployed.
+// If a deployment already exists for this (provider, gateway) pair it is
+// updated in place — matching CreateGatewayDeployment's one-row-per-pair
+// invariant — otherwise a new DEPLOYED row is created directly, since a
+// rollback is an operator action rather than something a gateway notifies
+// about after the fact.
+func (s *gatewayInternalService) RollbackDeployment(ctx context.Context, gatewayID string, providerID string, revisionNum int64) (*models.GatewayDeploymentResponse, error) {
+	providerUUID, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider ID: %w", err)
+	}
+
+	gatewayUUID, err := uuid.Parse(gatewayID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway ID: %w", err)
+	}
+
+	revision, err := s.GetRevision(ctx, providerID, revisionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInstance := db.DB(ctx)
+
+	var provider models.LLMProvider
+	if err := dbInstance.Where("uuid = ?", providerUUID).First(&provider).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrProviderNotFound
+		}
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	now := time.Now()
+
+	var existingDeployment models.ProviderGatewayDeployment
+	err = dbInstance.
+		Where("provider_uuid = ? AND gateway_uuid = ?", providerUUID, gatewayUUID).
+		First(&existingDeployment).Error
+
+	var deployment *models.ProviderGatewayDeployment
+	switch {
+	case err == nil:
+		existingDeployment.RevisionUUID = revision.UUID
+		existingDeployment.Status = "DEPLOYED"
+		existingDeployment.DeployedAt = &now
+		existingDeployment.UpdatedAt = now
+		if err := dbInstance.Save(&existingDeployment).Error; err != nil {
+			return nil, fmt.Errorf("failed to roll back deployment: %w", err)
+		}
+		deployment = &existingDeployment
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		deployment = &models.ProviderGatewayDeployment{
+			ProviderUUID: providerUUID,
+			GatewayUUID:  gatewayUUID,
+			DeploymentID: uuid.New().String(),
+			Environment:  "production",
+			RevisionUUID: revision.UUID,
+			Type:         models.DeploymentTypeDefault,
+			Status:       "DEPLOYED",
+			DeployedAt:   &now,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if err := dbInstance.Create(deployment).Error; err != nil {
+			return nil, fmt.Errorf("failed to create rollback deployment: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to check existing deployment: %w", err)
+	}
+
+	s.logger.Info("Rolled back provider deployment",
+		"providerId", providerID,
+		"gatewayId", gatewayID,
+		"revisionNumber", revisionNum,
+	)
+
+	s.publishArtifactChange(ctx, gatewayID, &provider, deployment, false)
+
+	return &models.GatewayDeploymentResponse{
+		APIId:        providerID,
+		DeploymentId: int64(deployment.ID),
+		Message:      fmt.Sprintf("Rolled back to revision %d", revisionNum),
+		Created:      false,
+	}, nil
+}
+
+// RenderConfig implements driftdetector.ConfigRenderer, so the drift
+// detector computes its expected config hash from exactly the same YAML
+// GetAPI/GetBundle would serve, without driftdetector importing this
+// package's unexported convertLLMProviderToAPIConfig.
+func (s *gatewayInternalService) RenderConfig(provider models.LLMProvider, revision *models.ProviderRevision, deployment models.ProviderGatewayDeployment) map[string]interface{} {
+	return s.convertLLMProviderToAPIConfig(&provider, revision, &deployment)
+}
+
+// RepushConfig re-publishes the configuration currently deployed for
+// (providerID, gatewayID) on the event bus, without creating a new revision
+// or changing the deployment's recorded status.
+func (s *gatewayInternalService) RepushConfig(ctx context.Context, gatewayID string, providerID string) error {
+	providerUUID, err := uuid.Parse(providerID)
+	if err != nil {
+		return fmt.Errorf("invalid provider ID: %w", err)
+	}
+
+	gatewayUUID, err := uuid.Parse(gatewayID)
+	if err != nil {
+		return fmt.Errorf("invalid gateway ID: %w", err)
+	}
+
+	dbInstance := db.DB(ctx)
+
+	var deployment models.ProviderGatewayDeployment
+	if err := dbInstance.
+		Where("provider_uuid = ? AND gateway_uuid = ?", providerUUID, gatewayUUID).
+		First(&deployment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrProviderNotFound
+		}
+		return fmt.Errorf("failed to load deployment: %w", err)
+	}
+
+	var provider models.LLMProvider
+	if err := dbInstance.Where("uuid = ?", providerUUID).First(&provider).Error; err != nil {
+		return fmt.Errorf("failed to load provider: %w", err)
+	}
+
+	s.publishArtifactChange(ctx, gatewayID, &provider, &deployment, false)
+
+	return nil
+}
+
+// CreateRoutingPolicy implements GatewayInternalService by delegating to
+// the standalone services.CreateRoutingPolicy, which also backs any
+// non-service caller that needs to create a policy directly.
+func (s *gatewayInternalService) CreateRoutingPolicy(ctx context.Context, policy *models.RoutingPolicy) error {
+	return CreateRoutingPolicy(ctx, db.DB(ctx), policy)
+}
+
+// maxBundleCatalogEntries bounds how many agent/mcp catalog entries
+// GetBundleForGateway pulls in per request; large enough for any real
+// organization's catalog, small enough to keep one bundle request bounded.
+const maxBundleCatalogEntries = 500
+
+// GetBundleForGateway builds a single ZIP of every active LLM provider
+// deployed to gatewayID, plus, best-effort, the organization's agent/mcp
+// catalog entries (see the in-function note on why that part is
+// organization- rather than gateway-scoped).
+func (s *gatewayInternalService) GetBundleForGateway(ctx context.Context, orgID string, gatewayID string, since string) (*GatewayBundle, error) {
+	gatewayUUID, err := uuid.Parse(gatewayID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway ID: %w", err)
+	}
+
+	dbInstance := db.DB(ctx)
+
+	var deployments []models.ProviderGatewayDeployment
+	if err := dbInstance.
+		Where("gateway_uuid = ? AND status = ?", gatewayUUID, "DEPLOYED").
+		Find(&deployments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list provider deployments: %w", err)
+	}
+
+	var entries []models.GatewayBundleEntry
+	var files []utils.BundleFile
+
+	for _, deployment := range deployments {
+		var provider models.LLMProvider
+		if err := dbInstance.Where("uuid = ?", deployment.ProviderUUID).First(&provider).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// Deployment outlived its provider; skip rather than fail
+				// the whole bundle over one stale row.
+				continue
+			}
+			return nil, fmt.Errorf("failed to load provider %s: %w", deployment.ProviderUUID, err)
+		}
+
+		revision, err := s.loadRevision(ctx, deployment.RevisionUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load revision for deployment %s: %w", deployment.DeploymentID, err)
+		}
+
+		apiConfig := s.convertLLMProviderToAPIConfig(&provider, revision, &deployment)
+		yamlData, err := yaml.Marshal(apiConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal provider %s: %w", provider.UUID, err)
+		}
+
+		entries = append(entries, models.GatewayBundleEntry{
+			Kind:    models.CatalogKindLLMProvider,
+			UUID:    provider.UUID.String(),
+			Version: fmt.Sprintf("%d", revision.RevisionNumber),
+			SHA256:  utils.SHA256Hex(yamlData),
+		})
+		files = append(files, utils.BundleFile{
+			Name:    fmt.Sprintf("llm-providers/%s.yaml", provider.UUID),
+			Content: yamlData,
+		})
+	}
+
+	// LLM proxies are deployed to gateways the same way LLM providers are,
+	// but this snapshot has no models.LLMProxy type to query or render, so
+	// only LLM providers are included here until that model exists.
+
+	// Catalog entries are keyed by organization UUID, while everything else
+	// in this service is keyed by organization name, and there is no
+	// per-gateway assignment table for catalog entries either. Absent a
+	// mapping between the two identifier spaces, agent/mcp entries are
+	// included only when orgID itself happens to be a valid UUID, and are
+	// necessarily organization-wide rather than gateway-specific.
+	if s.catalogRepo != nil {
+		if _, err := uuid.Parse(orgID); err == nil {
+			catalogEntries, _, _, err := s.catalogRepo.ListPage(orgID, repositories.CatalogFilter{
+				Kinds: []string{models.CatalogKindAgent, models.CatalogKindMCP},
+			}, maxBundleCatalogEntries, 0, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to list catalog entries: %w", err)
+			}
+
+			for _, entry := range catalogEntries {
+				entryData, err := yaml.Marshal(entry)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal catalog entry %s: %w", entry.UUID, err)
+				}
+
+				entries = append(entries, models.GatewayBundleEntry{
+					Kind:    entry.Kind,
+					UUID:    entry.UUID.String(),
+					Version: entry.Version,
+					SHA256:  utils.SHA256Hex(entryData),
+				})
+				files = append(files, utils.BundleFile{
+					Name:    fmt.Sprintf("catalog/%s.yaml", entry.UUID),
+					Content: entryData,
+				})
+			}
+		} else {
+			s.logger.Warn("GetBundleForGateway: organization identifier is not a UUID, skipping catalog entries", "orgID", orgID)
+		}
+	}
+
+	manifest := models.GatewayBundleManifest{Entries: entries}
+
+	// The manifest digest doubles as the bundle's logical ETag: it only
+	// changes when an entry's content or set membership changes, not when
+	// the archive happens to be rebuilt. Without a persisted record of what
+	// a gateway last saw, true incremental reconciliation (returning only
+	// changed entries plus a deletions list) isn't possible here, so
+	// ?since=<etag> can only short-circuit to "nothing changed" rather than
+	// compute a real diff.
+	digestInput, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute manifest digest: %w", err)
+	}
+	manifestDigest := utils.SHA256Hex(digestInput)
+
+	if since != "" && since == manifestDigest {
+		manifest = models.GatewayBundleManifest{}
+		files = nil
+	}
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	zipOpts := s.signManifest(ctx, orgID, manifestJSON)
+
+	var buf bytes.Buffer
+	if err := utils.WriteBundleZip(&buf, manifestJSON, files, zipOpts); err != nil {
+		return nil, fmt.Errorf("failed to build bundle ZIP: %w", err)
+	}
+
+	return &GatewayBundle{ETag: manifestDigest, Data: buf.Bytes()}, nil
+}
+
+// signManifest signs manifest with orgID's active signing key and returns
+// the ZipOptions WriteBundleZip should use to embed that signature. Signing
+// failures are logged rather than propagated: an unsigned bundle is still
+// useful to a gateway that doesn't enforce verification yet.
+func (s *gatewayInternalService) signManifest(ctx context.Context, orgID string, manifest []byte) utils.ZipOptions {
+	if s.signingService == nil {
+		return utils.ZipOptions{}
+	}
+
+	signature, err := s.signingService.Sign(ctx, orgID, manifest)
+	if err != nil {
+		s.logger.Error("Failed to sign bundle manifest", "orgID", orgID, "error", err)
+		return utils.ZipOptions{}
+	}
+
+	keys, err := s.signingService.PublicKeys(ctx, orgID)
+	if err != nil {
+		s.logger.Error("Failed to load signer public key", "orgID", orgID, "error", err)
+		return utils.ZipOptions{}
+	}
+	for _, key := range keys {
+		if key.KID != signature.KID {
+			continue
+		}
+		publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey)
+		if err != nil {
+			s.logger.Error("Failed to decode signer public key", "orgID", orgID, "kid", key.KID, "error", err)
+			return utils.ZipOptions{}
+		}
+		return utils.ZipOptions{Signature: signature, SignerPublicKey: publicKey}
+	}
+
+	s.logger.Error("Signed bundle manifest but could not find matching public key", "orgID", orgID, "kid", signature.KID)
+	return utils.ZipOptions{}
+}
+
 // convertConfigToMap converts APIConfiguration to map[string]interface{}
 func convertConfigToMap(config models.APIConfiguration) map[string]interface{} {
 	return map[string]interface{}{
@@ -0,0 +1,285 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+)
+
+// gatewayWebhookBackoffSchedule is the fixed delay before each successive
+// redelivery attempt of a configuration.changed event. Unlike
+// WebhookDispatchService's doubling MonitorRun backoff, this schedule is
+// fixed per the gateway sync contract: gateways are expected to recover
+// within seconds, so early retries are much tighter.
+var gatewayWebhookBackoffSchedule = [6]time.Duration{
+	1 * time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+	1 * time.Hour,
+}
+
+// maxGatewayWebhookAttempts is the attempt budget before a delivery is moved
+// to the dead-letter status: the first attempt plus one retry per entry of
+// gatewayWebhookBackoffSchedule, so every scheduled backoff gets used once
+// before giving up.
+const maxGatewayWebhookAttempts = len(gatewayWebhookBackoffSchedule) + 1
+
+var (
+	// ErrGatewayWebhookEndpointNotFound is returned when a delivery's
+	// endpoint has since been deleted or deregistered.
+	ErrGatewayWebhookEndpointNotFound = errors.New("gateway webhook endpoint not found")
+	// ErrGatewayWebhookDeliveryNotFound is returned by Redeliver when no
+	// delivery matches the given ID.
+	ErrGatewayWebhookDeliveryNotFound = errors.New("gateway webhook delivery not found")
+)
+
+// GatewayWebhookDispatchService lets a gateway register a push-notification
+// endpoint (in place of polling GetAPIsByOrganization) and delivers signed
+// configuration.changed events to it whenever a provider or revision in its
+// organization changes.
+type GatewayWebhookDispatchService interface {
+	// RegisterEndpoint creates or rotates the webhook endpoint for
+	// gatewayID, called at gateway registration or whenever the gateway
+	// rotates its callback URL/secret.
+	RegisterEndpoint(ctx context.Context, gatewayID uuid.UUID, orgName, url, secret string) (*models.GatewayWebhookEndpoint, error)
+
+	// NotifyConfigurationChanged queues a configuration.changed delivery to
+	// every active endpoint in orgName. apiID narrows the event to a single
+	// provider; nil means the whole org's catalog may have changed.
+	NotifyConfigurationChanged(ctx context.Context, orgName string, apiID, revisionID *string) error
+
+	// DeliverDue attempts every delivery whose next retry is due, advancing
+	// it to succeeded, failed (with backoff), or dead_letter.
+	DeliverDue(ctx context.Context, limit int) error
+
+	// Redeliver resets deliveryID to be picked up by the next DeliverDue
+	// pass immediately, backing POST /api/internal/v1/deliveries/:id/redeliver.
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) error
+}
+
+type gatewayWebhookDispatchService struct {
+	repo       repositories.GatewayWebhookRepository
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewGatewayWebhookDispatchService creates a new gateway webhook dispatch service.
+func NewGatewayWebhookDispatchService(repo repositories.GatewayWebhookRepository, logger *slog.Logger) GatewayWebhookDispatchService {
+	return &gatewayWebhookDispatchService{
+		repo:       repo,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+	}
+}
+
+// RegisterEndpoint creates or rotates the webhook endpoint for gatewayID.
+func (s *gatewayWebhookDispatchService) RegisterEndpoint(ctx context.Context, gatewayID uuid.UUID, orgName, url, secret string) (*models.GatewayWebhookEndpoint, error) {
+	if url == "" || secret == "" {
+		return nil, fmt.Errorf("webhook url and secret are required")
+	}
+
+	existing, err := s.repo.GetEndpointByGatewayID(gatewayID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to look up existing webhook endpoint: %w", err)
+	}
+
+	if existing != nil {
+		existing.OrgName = orgName
+		existing.URL = url
+		existing.Secret = secret
+		existing.Active = true
+		if err := s.repo.UpdateEndpoint(existing); err != nil {
+			return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+		}
+		return existing, nil
+	}
+
+	endpoint := &models.GatewayWebhookEndpoint{
+		ID:        uuid.New(),
+		GatewayID: gatewayID,
+		OrgName:   orgName,
+		URL:       url,
+		Secret:    secret,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateEndpoint(endpoint); err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// NotifyConfigurationChanged queues a configuration.changed delivery to
+// every active endpoint in orgName.
+func (s *gatewayWebhookDispatchService) NotifyConfigurationChanged(ctx context.Context, orgName string, apiID, revisionID *string) error {
+	endpoints, err := s.repo.ListActiveEndpoints(orgName)
+	if err != nil {
+		return fmt.Errorf("failed to list gateway webhook endpoints: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	scope := "organization"
+	if apiID != nil {
+		scope = "api"
+	}
+	event := models.GatewayConfigurationChangedEvent{
+		OrgName:    orgName,
+		Scope:      scope,
+		APIID:      apiID,
+		RevisionID: revisionID,
+		ChangedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration.changed event: %w", err)
+	}
+
+	for _, endpoint := range endpoints {
+		delivery := &models.GatewayWebhookDelivery{
+			ID:         uuid.New(),
+			EndpointID: endpoint.ID,
+			DeliveryID: utils.NewULID(),
+			EventType:  models.GatewayWebhookConfigurationChanged,
+			Payload:    string(raw),
+			Status:     models.GatewayWebhookDeliveryPending,
+			CreatedAt:  time.Now(),
+		}
+		if err := s.repo.CreateDelivery(delivery); err != nil {
+			s.logger.Error("Failed to queue gateway webhook delivery", "endpointId", endpoint.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// DeliverDue attempts every delivery whose next retry is due.
+func (s *gatewayWebhookDispatchService) DeliverDue(ctx context.Context, limit int) error {
+	due, err := s.repo.ListDueDeliveries(limit)
+	if err != nil {
+		return fmt.Errorf("failed to list due gateway webhook deliveries: %w", err)
+	}
+
+	for i := range due {
+		delivery := &due[i]
+		endpoint, err := s.repo.GetEndpointByID(delivery.EndpointID)
+		if err != nil {
+			s.logger.Error("Failed to load gateway webhook endpoint for delivery", "deliveryId", delivery.ID, "error", err)
+			continue
+		}
+
+		attemptErr := s.attempt(ctx, endpoint, delivery)
+		delivery.AttemptCount++
+		if attemptErr == nil {
+			delivery.Status = models.GatewayWebhookDeliverySucceeded
+			delivery.LastError = ""
+			delivery.NextAttemptAt = nil
+		} else {
+			delivery.LastError = attemptErr.Error()
+			if delivery.AttemptCount >= maxGatewayWebhookAttempts {
+				delivery.Status = models.GatewayWebhookDeliveryDeadLetter
+				delivery.NextAttemptAt = nil
+			} else {
+				delivery.Status = models.GatewayWebhookDeliveryFailed
+				next := time.Now().Add(gatewayWebhookBackoffFor(delivery.AttemptCount))
+				delivery.NextAttemptAt = &next
+			}
+		}
+
+		if err := s.repo.UpdateDelivery(delivery); err != nil {
+			s.logger.Error("Failed to update gateway webhook delivery", "deliveryId", delivery.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// attempt signs delivery.Payload and POSTs it to endpoint.URL.
+func (s *gatewayWebhookDispatchService) attempt(ctx context.Context, endpoint *models.GatewayWebhookEndpoint, delivery *models.GatewayWebhookDelivery) error {
+	if endpoint == nil {
+		return ErrGatewayWebhookEndpointNotFound
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build gateway webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(utils.WebhookSignatureHeader, utils.SignWebhookPayload(endpoint.Secret, body))
+	req.Header.Set(utils.WebhookDeliveryHeader, delivery.DeliveryID)
+	req.Header.Set(utils.WebhookTimestampHeader, strconv.FormatInt(time.Now().Unix(), 10))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("gateway webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("gateway webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Redeliver clears delivery's backoff so the next DeliverDue pass retries it
+// immediately, for an operator unblocking a gateway that came back online
+// sooner than its scheduled backoff.
+func (s *gatewayWebhookDispatchService) Redeliver(ctx context.Context, deliveryID uuid.UUID) error {
+	delivery, err := s.repo.GetDeliveryByID(deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrGatewayWebhookDeliveryNotFound
+		}
+		return fmt.Errorf("failed to look up gateway webhook delivery: %w", err)
+	}
+
+	now := time.Now()
+	delivery.Status = models.GatewayWebhookDeliveryPending
+	delivery.NextAttemptAt = &now
+	return s.repo.UpdateDelivery(delivery)
+}
+
+// gatewayWebhookBackoffFor returns the delay before the attempt-th retry,
+// clamped to the last entry of gatewayWebhookBackoffSchedule once attempt
+// exceeds it.
+func gatewayWebhookBackoffFor(attempt int) time.Duration {
+	idx := attempt - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(gatewayWebhookBackoffSchedule) {
+		idx = len(gatewayWebhookBackoffSchedule) - 1
+	}
+	return gatewayWebhookBackoffSchedule[idx]
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/clients/guanceyun"
+)
+
+// GuanceyunCheckResultStatus mirrors the pass/fail a Guanceyun check monitor
+// records on its MonitorRun.
+type GuanceyunCheckResultStatus string
+
+const (
+	GuanceyunCheckPassed GuanceyunCheckResultStatus = "passed"
+	GuanceyunCheckFailed GuanceyunCheckResultStatus = "failed"
+)
+
+// GuanceyunCheckResult is the JSON shape stored in a MonitorRun's result
+// column for a models.MonitorTypeGuanceyunCheck run.
+type GuanceyunCheckResult struct {
+	Status         GuanceyunCheckResultStatus `json:"status"`
+	OffendingRules []guanceyun.AlertRule      `json:"offendingRules,omitempty"`
+}
+
+// SerializeGuanceyunCheckResult converts a raw Guanceyun poll into the
+// MonitorRun.result JSON payload, failing the run when any rule is alerting.
+func SerializeGuanceyunCheckResult(check *guanceyun.CheckResult) (json.RawMessage, error) {
+	result := GuanceyunCheckResult{Status: GuanceyunCheckPassed}
+	if check.Alerting {
+		result.Status = GuanceyunCheckFailed
+		result.OffendingRules = check.OffendingRules
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize guanceyun check result: %w", err)
+	}
+	return raw, nil
+}
+
+// RunGuanceyunCheck polls cfg for alerting rules over lookback and returns
+// the serialized MonitorRun result for a single due monitor.
+//
+// Nothing in this tree calls it per due MonitorTypeGuanceyunCheck monitor:
+// models.Monitor carries no guanceyun.Config (connection endpoint/API
+// key/workspace), so there is no scheduler that fans out over
+// MonitorRepository.ListDueMonitors and writes the result via
+// CreateMonitorRun.
+func RunGuanceyunCheck(ctx context.Context, cfg guanceyun.Config, lookback time.Duration) (json.RawMessage, error) {
+	client := guanceyun.NewClient(cfg)
+	check, err := client.CheckAlerts(ctx, lookback)
+	if err != nil {
+		return nil, err
+	}
+	return SerializeGuanceyunCheckResult(check)
+}
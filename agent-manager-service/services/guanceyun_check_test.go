@@ -0,0 +1,61 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/clients/guanceyun"
+)
+
+func TestSerializeGuanceyunCheckResultPassed(t *testing.T) {
+	raw, err := SerializeGuanceyunCheckResult(&guanceyun.CheckResult{Alerting: false})
+	if err != nil {
+		t.Fatalf("SerializeGuanceyunCheckResult() unexpected error = %v", err)
+	}
+
+	var result GuanceyunCheckResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	if result.Status != GuanceyunCheckPassed {
+		t.Errorf("Status = %v, want %v", result.Status, GuanceyunCheckPassed)
+	}
+}
+
+func TestSerializeGuanceyunCheckResultFailed(t *testing.T) {
+	check := &guanceyun.CheckResult{
+		Alerting:       true,
+		OffendingRules: []guanceyun.AlertRule{{RuleName: "high-latency"}},
+	}
+	raw, err := SerializeGuanceyunCheckResult(check)
+	if err != nil {
+		t.Fatalf("SerializeGuanceyunCheckResult() unexpected error = %v", err)
+	}
+
+	var result GuanceyunCheckResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	if result.Status != GuanceyunCheckFailed {
+		t.Errorf("Status = %v, want %v", result.Status, GuanceyunCheckFailed)
+	}
+	if len(result.OffendingRules) != 1 || result.OffendingRules[0].RuleName != "high-latency" {
+		t.Errorf("OffendingRules = %v, want one rule named high-latency", result.OffendingRules)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// renderFuncs are the Helm-style helpers available to templates in addition to
+// the text/template builtins.
+var renderFuncs = template.FuncMap{
+	"default": func(def, val interface{}) interface{} {
+		if val == nil || val == "" {
+			return def
+		}
+		return val
+	},
+	"required": func(msg string, val interface{}) (interface{}, error) {
+		if val == nil || val == "" {
+			return nil, fmt.Errorf("%s", msg)
+		}
+		return val, nil
+	},
+	"env": func(name string) string {
+		return os.Getenv(name)
+	},
+	"quote": func(val interface{}) string {
+		return fmt.Sprintf("%q", fmt.Sprintf("%v", val))
+	},
+}
+
+// Render renders the template identified by handle against values, validating
+// values against the template's embedded values.schema.json (if any) before
+// executing the Go template placeholders ({{ .Values.xxx }}) in its string fields.
+func (s *LLMTemplateStore) Render(handle string, values map[string]any) (*models.LLMProviderTemplate, error) {
+	t := s.Get(handle)
+	if t == nil {
+		return nil, fmt.Errorf("template %q not found", handle)
+	}
+
+	if len(t.ValuesSchema) > 0 {
+		if err := validateValuesAgainstSchema(t.ValuesSchema, values); err != nil {
+			return nil, fmt.Errorf("values failed schema validation: %w", err)
+		}
+	}
+
+	rendered, err := renderTemplateFields(t, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template %q: %w", handle, err)
+	}
+
+	return rendered, nil
+}
+
+// validateValuesAgainstSchema validates the supplied values map against the
+// template's JSON schema (values.schema.json contents).
+func validateValuesAgainstSchema(schema []byte, values map[string]any) error {
+	schemaLoader := gojsonschema.NewBytesLoader(schema)
+
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal values: %w", err)
+	}
+	documentLoader := gojsonschema.NewBytesLoader(valuesJSON)
+
+	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
+	if err != nil {
+		return fmt.Errorf("failed to run schema validation: %w", err)
+	}
+	if !result.Valid() {
+		var msgs []string
+		for _, e := range result.Errors() {
+			msgs = append(msgs, e.String())
+		}
+		return fmt.Errorf("%v", msgs)
+	}
+	return nil
+}
+
+// renderTemplateFields serializes t to YAML, executes it as a text/template with
+// {{ .Values.xxx }} placeholders, and decodes the result back into a template.
+// Serializing the whole struct (rather than rendering each field individually)
+// keeps the renderer in sync automatically as new templated fields are added.
+func renderTemplateFields(t *models.LLMProviderTemplate, values map[string]any) (*models.LLMProviderTemplate, error) {
+	raw, err := yaml.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal template: %w", err)
+	}
+
+	tmpl, err := template.New(t.Handle).Funcs(renderFuncs).Parse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template placeholders: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any{"Values": values}); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	var rendered models.LLMProviderTemplate
+	if err := yaml.Unmarshal(buf.Bytes(), &rendered); err != nil {
+		return nil, fmt.Errorf("failed to decode rendered template: %w", err)
+	}
+
+	return &rendered, nil
+}
@@ -17,21 +17,28 @@
 package services
 
 import (
+	"log/slog"
 	"sync"
 
 	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
 )
 
-// LLMTemplateStore provides thread-safe in-memory storage for built-in LLM provider templates
+// LLMTemplateStore provides thread-safe in-memory storage for built-in LLM provider templates.
+// It can be seeded once at startup via Load, or kept in sync with a directory of
+// template files via LoadFromDir/Watch.
 type LLMTemplateStore struct {
-	templates map[string]*models.LLMProviderTemplate // key: handle
-	mu        sync.RWMutex
+	templates   map[string]*models.LLMProviderTemplate // key: handle
+	versions    map[string]string                      // key: handle, value: content-derived version/etag
+	subscribers []chan TemplateChangeEvent
+	log         *slog.Logger
+	mu          sync.RWMutex
 }
 
 // NewLLMTemplateStore creates a new in-memory template store
 func NewLLMTemplateStore() *LLMTemplateStore {
 	return &LLMTemplateStore{
 		templates: make(map[string]*models.LLMProviderTemplate),
+		versions:  make(map[string]string),
 	}
 }
 
@@ -42,6 +49,7 @@ func (s *LLMTemplateStore) Load(templates []*models.LLMProviderTemplate) {
 	for _, t := range templates {
 		if t != nil && t.Handle != "" {
 			s.templates[t.Handle] = t
+			s.versions[t.Handle] = computeTemplateVersion(t)
 		}
 	}
 }
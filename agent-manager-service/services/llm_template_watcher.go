@@ -0,0 +1,251 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// debounceInterval batches rapid filesystem events (e.g. editors that write a file
+// in several steps) into a single reload.
+const debounceInterval = 500 * time.Millisecond
+
+// TemplateChangeEvent describes a single template addition, update, or removal
+// detected while watching a template directory.
+type TemplateChangeEvent struct {
+	Handle string
+	Kind   TemplateChangeKind
+}
+
+// TemplateChangeKind identifies the nature of a TemplateChangeEvent.
+type TemplateChangeKind string
+
+const (
+	TemplateChangeAdded   TemplateChangeKind = "added"
+	TemplateChangeUpdated TemplateChangeKind = "updated"
+	TemplateChangeRemoved TemplateChangeKind = "removed"
+)
+
+// LoadFromDir reads template YAML/JSON files from path, validates them, and swaps
+// them atomically into the store. Existing templates not present in path are left
+// untouched; callers that want directory contents to be authoritative should pair
+// this with file removal events via Watch.
+func (s *LLMTemplateStore) LoadFromDir(path string) error {
+	templates, err := readTemplateDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template directory %s: %w", path, err)
+	}
+
+	s.mu.Lock()
+	for handle, t := range templates {
+		s.templates[handle] = t
+		s.versions[handle] = computeTemplateVersion(t)
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Watch starts an fsnotify watch on path and hot-reloads templates as files are
+// created, modified, or removed. Reloads are debounced by debounceInterval so a
+// burst of filesystem events only triggers a single swap. Watch blocks until ctx
+// is cancelled.
+func (s *LLMTemplateStore) Watch(ctx context.Context, path string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create filesystem watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(path); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", path, err)
+	}
+
+	var debounce *time.Timer
+	reload := func() {
+		if err := s.reloadAndDiff(path); err != nil {
+			s.logger().Error("Failed to hot-reload LLM provider templates", "path", path, "error", err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if debounce != nil {
+				debounce.Stop()
+			}
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+			if debounce != nil {
+				debounce.Stop()
+			}
+			debounce = time.AfterFunc(debounceInterval, reload)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			s.logger().Error("Template watcher error", "path", path, "error", err)
+		}
+	}
+}
+
+// reloadAndDiff re-reads path, swaps the result into the store atomically, and
+// publishes a TemplateChangeEvent for each handle that was added, updated, or removed.
+func (s *LLMTemplateStore) reloadAndDiff(path string) error {
+	templates, err := readTemplateDir(path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	var events []TemplateChangeEvent
+	for handle, t := range templates {
+		newVersion := computeTemplateVersion(t)
+		if oldVersion, existed := s.versions[handle]; !existed {
+			events = append(events, TemplateChangeEvent{Handle: handle, Kind: TemplateChangeAdded})
+		} else if oldVersion != newVersion {
+			events = append(events, TemplateChangeEvent{Handle: handle, Kind: TemplateChangeUpdated})
+		}
+		s.templates[handle] = t
+		s.versions[handle] = newVersion
+	}
+	for handle := range s.templates {
+		if _, stillPresent := templates[handle]; !stillPresent {
+			delete(s.templates, handle)
+			delete(s.versions, handle)
+			events = append(events, TemplateChangeEvent{Handle: handle, Kind: TemplateChangeRemoved})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, ev := range events {
+		s.publish(ev)
+	}
+	return nil
+}
+
+// Subscribe returns a channel that receives a TemplateChangeEvent whenever a
+// watched template is added, updated, or removed. The channel is buffered;
+// slow consumers may miss events rather than block the watcher loop.
+func (s *LLMTemplateStore) Subscribe() <-chan TemplateChangeEvent {
+	ch := make(chan TemplateChangeEvent, 16)
+	s.mu.Lock()
+	s.subscribers = append(s.subscribers, ch)
+	s.mu.Unlock()
+	return ch
+}
+
+func (s *LLMTemplateStore) publish(ev TemplateChangeEvent) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ch := range s.subscribers {
+		select {
+		case ch <- ev:
+		default:
+			// Drop the event rather than block the watch loop on a slow subscriber.
+		}
+	}
+}
+
+// GetVersion returns the current content-derived version/etag for the template
+// identified by handle, or an empty string if the handle is not found.
+func (s *LLMTemplateStore) GetVersion(handle string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.versions[handle]
+}
+
+func (s *LLMTemplateStore) logger() *slog.Logger {
+	if s.log != nil {
+		return s.log
+	}
+	return slog.Default()
+}
+
+// readTemplateDir reads every .yaml/.yml/.json file in path and decodes it into a
+// models.LLMProviderTemplate, keyed by handle.
+func readTemplateDir(path string) (map[string]*models.LLMProviderTemplate, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]*models.LLMProviderTemplate)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(path, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		var t models.LLMProviderTemplate
+		if ext == ".json" {
+			err = json.Unmarshal(data, &t)
+		} else {
+			err = yaml.Unmarshal(data, &t)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+		}
+		if t.Handle == "" {
+			return nil, fmt.Errorf("template %s is missing a handle", name)
+		}
+
+		templates[t.Handle] = &t
+	}
+
+	return templates, nil
+}
+
+// computeTemplateVersion derives a stable content hash used as the template's
+// version/etag. Two templates with identical content always produce the same version.
+func computeTemplateVersion(t *models.LLMProviderTemplate) string {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -20,13 +20,46 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	traceobserver "github.com/wso2/ai-agent-management-platform/agent-manager-service/clients/trace_observer"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
 )
 
+// traceListOverfetchFactor over-fetches from TraceObserverClient so that
+// MinDurationMs/MaxDurationMs/Status filtering and keyset cursoring, both
+// applied in-memory on top of the client's offset-based results, still
+// return a full page when some of the underlying rows get filtered out.
+// Mirrors the over-fetch pattern traces-observer-service itself uses for
+// post-aggregation filtering.
+const traceListOverfetchFactor = 5
+
+// TraceListFilter narrows and cursors a ListTraces query on top of what
+// TraceObserverClient's own params support.
+type TraceListFilter struct {
+	MinDurationMs int64
+	MaxDurationMs int64
+	Status        string // "" (any) | "error"
+	// Attributes is accepted for forward compatibility with callers but not
+	// yet enforced: TraceObserverClient's overview response doesn't expose
+	// span attributes, only full TraceDetailsById does. Enforcing this
+	// would require an N+1 detail fetch per candidate trace, so it's left
+	// for when the client gains attribute-level overview filtering.
+	Attributes map[string]string
+	// Cursor, when set, takes precedence over params.Offset and is applied
+	// as a keyset predicate over (startTime DESC, traceId DESC), the same
+	// order TraceObserverClient already returns results in.
+	Cursor string
+}
+
 type ObservabilityManagerService interface {
-	ListTraces(ctx context.Context, params traceobserver.ListTracesParams) (*traceobserver.TraceOverviewResponse, error)
+	// ListTraces returns a page of trace overviews matching params and
+	// filter, plus the cursor for the next page (empty once exhausted).
+	ListTraces(ctx context.Context, params traceobserver.ListTracesParams, filter TraceListFilter) (*traceobserver.TraceOverviewResponse, string, error)
 	GetTraceDetails(ctx context.Context, params traceobserver.TraceDetailsByIdParams) (*traceobserver.TraceResponse, error)
+	// TailTraces streams newly observed traces matching params on the returned channel
+	// until ctx is cancelled. The channel is closed when tailing stops.
+	TailTraces(ctx context.Context, params TailParams) (<-chan TraceEvent, error)
 }
 
 type observabilityManagerService struct {
@@ -44,18 +77,97 @@ func NewObservabilityManager(
 	}
 }
 
-// ListTraces retrieves trace overviews from the trace observer service
-func (s *observabilityManagerService) ListTraces(ctx context.Context, params traceobserver.ListTracesParams) (*traceobserver.TraceOverviewResponse, error) {
-	s.logger.Info("Listing traces", "serviceName", params.ServiceName, "limit", params.Limit, "offset", params.Offset)
+// ListTraces retrieves a filtered, cursored page of trace overviews from the
+// trace observer service. See TraceListFilter for the filter/cursor contract.
+func (s *observabilityManagerService) ListTraces(ctx context.Context, params traceobserver.ListTracesParams, filter TraceListFilter) (*traceobserver.TraceOverviewResponse, string, error) {
+	s.logger.Info("Listing traces",
+		"serviceName", params.ServiceName,
+		"limit", params.Limit,
+		"offset", params.Offset,
+		"status", filter.Status,
+		"hasCursor", filter.Cursor != "")
 
-	response, err := s.TraceObserverClient.ListTraces(ctx, params)
+	filterHash := utils.HashFilters(params.ServiceName, filter.Status,
+		fmt.Sprintf("%d", filter.MinDurationMs), fmt.Sprintf("%d", filter.MaxDurationMs))
+
+	var cursorPos *utils.Cursor
+	if filter.Cursor != "" {
+		decoded, err := utils.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if decoded.FilterHash != filterHash {
+			return nil, "", utils.ErrInvalidCursor
+		}
+		cursorPos = &decoded
+	}
+
+	requestedLimit := params.Limit
+
+	fetchParams := params
+	fetchParams.Limit = params.Limit * traceListOverfetchFactor
+	fetchParams.Offset = 0 // keyset filtering below replaces offset-based paging once a cursor is in play
+
+	response, err := s.TraceObserverClient.ListTraces(ctx, fetchParams)
 	if err != nil {
 		s.logger.Error("Failed to list traces", "serviceName", params.ServiceName, "error", err)
-		return nil, fmt.Errorf("failed to list traces: %w", err)
+		return nil, "", fmt.Errorf("failed to list traces: %w", err)
 	}
 
-	s.logger.Info("Retrieved traces successfully", "serviceName", params.ServiceName, "totalCount", response.TotalCount)
-	return response, nil
+	candidates := response.Traces
+	if cursorPos != nil {
+		candidates = applyCursorPredicate(candidates, *cursorPos)
+	} else if params.Offset > 0 && params.Offset < len(candidates) {
+		candidates = candidates[params.Offset:]
+	}
+
+	var page []traceobserver.TraceOverview
+	for _, overview := range candidates {
+		if filter.MinDurationMs > 0 && overview.DurationInNanos < filter.MinDurationMs*int64(time.Millisecond) {
+			continue
+		}
+		if filter.MaxDurationMs > 0 && overview.DurationInNanos > filter.MaxDurationMs*int64(time.Millisecond) {
+			continue
+		}
+		if filter.Status == "error" && (overview.Status == nil || !overview.Status.IsError) {
+			continue
+		}
+		page = append(page, overview)
+		if len(page) == requestedLimit {
+			break
+		}
+	}
+
+	nextCursor := ""
+	if len(page) == requestedLimit {
+		last := page[len(page)-1]
+		encoded, err := utils.EncodeCursor(utils.Cursor{
+			LastSortKey: last.StartTime,
+			LastUUID:    last.TraceID,
+			FilterHash:  filterHash,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		nextCursor = encoded
+	}
+
+	response.Traces = page
+	s.logger.Info("Retrieved traces successfully", "serviceName", params.ServiceName, "totalCount", response.TotalCount, "pageSize", len(page))
+	return response, nextCursor, nil
+}
+
+// applyCursorPredicate drops every overview at or after cursor's position,
+// keeping the keyset invariant: overviews are ordered (startTime DESC,
+// traceId DESC), so the next page starts strictly after the cursor.
+func applyCursorPredicate(overviews []traceobserver.TraceOverview, cursor utils.Cursor) []traceobserver.TraceOverview {
+	for i, overview := range overviews {
+		if overview.StartTime < cursor.LastSortKey ||
+			(overview.StartTime == cursor.LastSortKey && overview.TraceID < cursor.LastUUID) {
+			return overviews[i:]
+		}
+	}
+	return nil
 }
 
 // GetTraceDetails retrieves detailed trace information by trace ID
@@ -0,0 +1,163 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"container/list"
+	"context"
+	"time"
+
+	traceobserver "github.com/wso2/ai-agent-management-platform/agent-manager-service/clients/trace_observer"
+)
+
+// defaultTailPollInterval is how often TailTraces re-polls the trace observer
+// when callers don't specify one.
+const defaultTailPollInterval = 2 * time.Second
+
+// dedupWindowSize bounds the number of recently-seen trace IDs kept in memory
+// so a long-running tail doesn't grow unbounded.
+const dedupWindowSize = 10000
+
+// TailParams configures a TailTraces call.
+type TailParams struct {
+	ServiceName   string
+	MinDurationMs int64
+	Status        string // "" (any) | "error"
+	PollInterval  time.Duration
+}
+
+// TraceEvent is a single new trace emitted on a tail stream.
+type TraceEvent struct {
+	Trace traceobserver.TraceResponse
+}
+
+// TailTraces periodically polls TraceObserverClient.ListTraces with a monotonically
+// increasing sinceTraceStartTime cursor, deduplicates by trace ID within a bounded
+// window of recently seen IDs (oldest evicted first), and emits newly observed traces on the returned channel. The caller
+// is responsible for draining the channel until ctx is cancelled.
+func (s *observabilityManagerService) TailTraces(ctx context.Context, params TailParams) (<-chan TraceEvent, error) {
+	interval := params.PollInterval
+	if interval <= 0 {
+		interval = defaultTailPollInterval
+	}
+
+	events := make(chan TraceEvent)
+
+	go func() {
+		defer close(events)
+
+		seen := newLRUSet(dedupWindowSize)
+		since := time.Now()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.pollOnce(ctx, params, &since, seen, events)
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (s *observabilityManagerService) pollOnce(ctx context.Context, params TailParams, since *time.Time, seen *lruSet, events chan<- TraceEvent) {
+	listParams := traceobserver.ListTracesParams{
+		ServiceName:         params.ServiceName,
+		SinceTraceStartTime: *since,
+	}
+
+	resp, err := s.TraceObserverClient.ListTraces(ctx, listParams)
+	if err != nil {
+		s.logger.Error("TailTraces: poll failed", "serviceName", params.ServiceName, "error", err)
+		return
+	}
+
+	newSince := *since
+	for _, overview := range resp.Traces {
+		if seen.Contains(overview.TraceID) {
+			continue
+		}
+		if params.MinDurationMs > 0 && overview.DurationInNanos < params.MinDurationMs*int64(time.Millisecond) {
+			continue
+		}
+		if params.Status == "error" && (overview.Status == nil || !overview.Status.IsError) {
+			continue
+		}
+
+		details, err := s.TraceObserverClient.TraceDetailsById(ctx, traceobserver.TraceDetailsByIdParams{
+			TraceID:     overview.TraceID,
+			ServiceName: params.ServiceName,
+		})
+		if err != nil {
+			s.logger.Error("TailTraces: failed to fetch trace details", "traceId", overview.TraceID, "error", err)
+			continue
+		}
+
+		seen.Add(overview.TraceID)
+		select {
+		case events <- TraceEvent{Trace: *details}:
+		case <-ctx.Done():
+			return
+		}
+
+		if startTime, err := time.Parse(time.RFC3339Nano, overview.StartTime); err == nil && startTime.After(newSince) {
+			newSince = startTime
+		}
+	}
+	*since = newSince
+}
+
+// lruSet is a fixed-capacity set with FIFO eviction, used to bound memory use
+// while deduplicating trace IDs across tail polls.
+type lruSet struct {
+	capacity int
+	order    *list.List
+	index    map[string]*list.Element
+}
+
+func newLRUSet(capacity int) *lruSet {
+	return &lruSet{
+		capacity: capacity,
+		order:    list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+func (l *lruSet) Contains(key string) bool {
+	_, ok := l.index[key]
+	return ok
+}
+
+func (l *lruSet) Add(key string) {
+	if l.Contains(key) {
+		return
+	}
+	el := l.order.PushBack(key)
+	l.index[key] = el
+	if l.order.Len() > l.capacity {
+		oldest := l.order.Front()
+		if oldest != nil {
+			l.order.Remove(oldest)
+			delete(l.index, oldest.Value.(string))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"encoding/hex"
+
+	"github.com/google/uuid"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// ScoreStubsFromResourceSpans converts one WatchTraces batch into the
+// trace/span-identified Score stubs the evaluator pipeline scores, one per
+// span, respecting the nullable SpanID column exercised by
+// TestBatchCreateScores_NullSpanID: root spans (no parent span ID) and
+// spans with no span ID produce a SpanID of nil so they're scored at the
+// trace level, while child spans carry their own SpanID.
+//
+// The returned Scores have RunEvaluatorID and MonitorID left as their zero
+// UUID; the caller fills those in once it has resolved which monitor's
+// evaluators apply to this batch, since a *tracepb.ResourceSpans has no
+// monitor context of its own.
+func ScoreStubsFromResourceSpans(batch *tracepb.ResourceSpans) []models.Score {
+	if batch == nil {
+		return nil
+	}
+
+	var stubs []models.Score
+	for _, scope := range batch.ScopeSpans {
+		for _, span := range scope.GetSpans() {
+			stub := models.Score{
+				ID:      uuid.New(),
+				TraceID: hex.EncodeToString(span.TraceId),
+			}
+			if len(span.SpanId) > 0 && len(span.ParentSpanId) > 0 {
+				spanID := hex.EncodeToString(span.SpanId)
+				stub.SpanID = &spanID
+			}
+			stubs = append(stubs, stub)
+		}
+	}
+	return stubs
+}
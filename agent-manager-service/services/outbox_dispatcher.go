@@ -0,0 +1,175 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
+)
+
+// outboxBackoffSchedule is the fixed delay before each successive dispatch
+// retry of an outbox event. Mirrors gatewayWebhookBackoffSchedule's fixed
+// (rather than doubling) shape: a gateway's WebSocket connection is expected
+// to recover within seconds of a disconnect, so early retries stay tight.
+var outboxBackoffSchedule = [6]time.Duration{
+	1 * time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+	1 * time.Hour,
+}
+
+// maxOutboxAttempts is the attempt budget before an event is moved to
+// dead_letter: the first attempt plus one retry per entry of
+// outboxBackoffSchedule, so every scheduled backoff gets used once before
+// giving up.
+const maxOutboxAttempts = len(outboxBackoffSchedule) + 1
+
+// OutboxDispatcher polls outbox_events for undelivered rows and broadcasts
+// them to the gateway's WebSocket connection, providing at-least-once
+// delivery for events OnPremiseAdapter records transactionally alongside
+// its LLMProvider/ProviderGatewayDeployment writes.
+type OutboxDispatcher struct {
+	repo          repositories.OutboxRepository
+	eventsService GatewayEventsService
+	interval      time.Duration
+	batchSize     int
+	logger        *slog.Logger
+}
+
+// NewOutboxDispatcher returns a dispatcher that polls every interval for up
+// to batchSize due events per pass.
+func NewOutboxDispatcher(repo repositories.OutboxRepository, eventsService GatewayEventsService, interval time.Duration, batchSize int, logger *slog.Logger) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		repo:          repo,
+		eventsService: eventsService,
+		interval:      interval,
+		batchSize:     batchSize,
+		logger:        logger,
+	}
+}
+
+// Run dispatches due events every d.interval until ctx is cancelled.
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.DispatchDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue attempts every event whose next retry is due, advancing it to
+// dispatched, pending-with-backoff, or dead_letter.
+func (d *OutboxDispatcher) DispatchDue(ctx context.Context) {
+	due, err := d.repo.ListDue(d.batchSize)
+	if err != nil {
+		d.logger.Error("Failed to list due outbox events", "error", err)
+		return
+	}
+
+	for i := range due {
+		event := &due[i]
+
+		attemptErr := d.attempt(event)
+		event.AttemptCount++
+		now := time.Now()
+		if attemptErr == nil {
+			event.Status = models.OutboxEventDispatched
+			event.LastError = ""
+			event.NextAttemptAt = nil
+			event.DispatchedAt = &now
+		} else {
+			event.LastError = attemptErr.Error()
+			if event.AttemptCount >= maxOutboxAttempts {
+				event.Status = models.OutboxEventDeadLetter
+				event.NextAttemptAt = nil
+				d.logger.Error("Outbox event exhausted retries, moving to dead letter",
+					"eventId", event.ID, "gatewayUuid", event.GatewayUUID, "error", attemptErr)
+			} else {
+				next := now.Add(outboxBackoffFor(event.AttemptCount))
+				event.NextAttemptAt = &next
+			}
+		}
+
+		if err := d.repo.Update(event); err != nil {
+			d.logger.Error("Failed to update outbox event", "eventId", event.ID, "error", err)
+		}
+	}
+}
+
+// attempt decodes event.Payload for its EventType and broadcasts it over the
+// gateway's WebSocket connection.
+func (d *OutboxDispatcher) attempt(event *models.OutboxEvent) error {
+	gatewayID := event.GatewayUUID.String()
+
+	switch event.EventType {
+	case models.OutboxEventLLMProviderDeployed:
+		var payload models.DeploymentEvent
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return fmt.Errorf("failed to decode deployment event payload: %w", err)
+		}
+		return d.eventsService.BroadcastLLMProviderDeployed(gatewayID, &payload)
+	case models.OutboxEventLLMProviderUndeployed:
+		var payload models.APIUndeploymentEvent
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return fmt.Errorf("failed to decode undeployment event payload: %w", err)
+		}
+		return d.eventsService.BroadcastLLMProviderUndeployed(gatewayID, &payload)
+	default:
+		return fmt.Errorf("unknown outbox event type: %q", event.EventType)
+	}
+}
+
+// Replay returns every event recorded for gatewayID after since, so a
+// reconnecting gateway can request whatever it missed while disconnected
+// instead of waiting for the next full resync.
+func (d *OutboxDispatcher) Replay(gatewayID string, since time.Time) ([]models.OutboxEvent, error) {
+	gatewayUUID, err := uuid.Parse(gatewayID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway ID: %w", err)
+	}
+	return d.repo.ListSince(gatewayUUID, since)
+}
+
+// outboxBackoffFor returns the delay before the attempt-th retry, clamped to
+// the last entry of outboxBackoffSchedule once attempt exceeds it.
+func outboxBackoffFor(attempt int) time.Duration {
+	idx := attempt - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(outboxBackoffSchedule) {
+		idx = len(outboxBackoffSchedule) - 1
+	}
+	return outboxBackoffSchedule[idx]
+}
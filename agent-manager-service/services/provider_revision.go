@@ -0,0 +1,67 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// CreateProviderRevision snapshots provider's current (Template,
+// Configuration, DisplayName) as a new models.ProviderRevision, numbered one
+// higher than its previous revision (or 1 if it has none yet). Exported, and
+// taking a *gorm.DB directly rather than hanging off gatewayInternalService,
+// so callers outside this package that mutate an LLMProvider row directly —
+// notably onpremise.OnPremiseAdapter's DeployProvider/UpdateProvider — can
+// snapshot a revision too instead of duplicating the numbering logic.
+func CreateProviderRevision(ctx context.Context, dbInstance *gorm.DB, provider *models.LLMProvider) (*models.ProviderRevision, error) {
+	var previous models.ProviderRevision
+	err := dbInstance.WithContext(ctx).
+		Where("provider_uuid = ?", provider.UUID).
+		Order("revision_number DESC").
+		First(&previous).Error
+
+	nextNumber := int64(1)
+	if err == nil {
+		nextNumber = previous.RevisionNumber + 1
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to look up previous revision: %w", err)
+	}
+
+	revision := &models.ProviderRevision{
+		UUID:           uuid.New(),
+		ProviderUUID:   provider.UUID,
+		RevisionNumber: nextNumber,
+		DisplayName:    provider.DisplayName,
+		Template:       provider.Template,
+		Configuration:  provider.Configuration,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := dbInstance.WithContext(ctx).Create(revision).Error; err != nil {
+		return nil, fmt.Errorf("failed to create provider revision: %w", err)
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils"
+)
+
+// CreateRoutingPolicy validates that every rule in policy references a
+// provider actually DEPLOYED to policy.GatewayUUID, then creates it.
+// Exported and taking a *gorm.DB directly, like CreateProviderRevision,
+// so callers outside this package can create a policy without depending
+// on the full GatewayInternalService interface.
+func CreateRoutingPolicy(ctx context.Context, dbInstance *gorm.DB, policy *models.RoutingPolicy) error {
+	if policy.Name == "" || policy.VirtualModel == "" || len(policy.Rules) == 0 {
+		return utils.ErrInvalidInput
+	}
+
+	deployed, err := DeployedProviderUUIDs(ctx, dbInstance, policy.GatewayUUID)
+	if err != nil {
+		return fmt.Errorf("failed to list deployed providers for gateway %s: %w", policy.GatewayUUID, err)
+	}
+
+	for _, rule := range policy.Rules {
+		if !deployed[rule.ProviderUUID] {
+			return fmt.Errorf("%w: provider %s is not deployed to gateway %s", utils.ErrInvalidInput, rule.ProviderUUID, policy.GatewayUUID)
+		}
+	}
+
+	if err := dbInstance.WithContext(ctx).Create(policy).Error; err != nil {
+		return fmt.Errorf("failed to create routing policy: %w", err)
+	}
+
+	return nil
+}
+
+// DeployedProviderUUIDs returns the set of provider UUIDs currently
+// DEPLOYED to gatewayUUID. Used to validate RoutingPolicy rules both at
+// create time (CreateRoutingPolicy) and when GetAPIsByOrganization emits
+// them, so a rule whose provider has since been undeployed is dropped
+// rather than served to the gateway.
+func DeployedProviderUUIDs(ctx context.Context, dbInstance *gorm.DB, gatewayUUID uuid.UUID) (map[uuid.UUID]bool, error) {
+	var deployments []models.ProviderGatewayDeployment
+	if err := dbInstance.WithContext(ctx).
+		Where("gateway_uuid = ? AND status = ?", gatewayUUID, "DEPLOYED").
+		Find(&deployments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list deployments for gateway %s: %w", gatewayUUID, err)
+	}
+
+	deployed := make(map[uuid.UUID]bool, len(deployments))
+	for _, d := range deployments {
+		deployed[d.ProviderUUID] = true
+	}
+	return deployed, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// defaultHistogramSchema gives ~0.27% relative bucket width (2^(1/2^8) - 1),
+// fine enough for p50/p90/p99 reporting without storing raw scores.
+const defaultHistogramSchema int8 = 8
+
+// minHistogramSchema is the coarsest resolution considered useful for a
+// histogram; at this schema a bucket already spans a factor of 2^16, and
+// anything coarser makes quantile interpolation too lossy. Merge does not
+// check against it: it coarsens to whichever input schema is lower.
+const minHistogramSchema int8 = -4
+
+// zeroThreshold is the absolute score value at or below which an observation
+// is folded into ZeroCount instead of a positive bucket, matching evaluator
+// scores that legitimately land at (or within float noise of) zero.
+const zeroThreshold = 1e-9
+
+// HistogramSpan is a run of Length consecutive populated bucket indices,
+// starting Offset buckets after the previous span ended (or after index 0
+// for the first span). Spans let a histogram with mostly-contiguous
+// populated buckets skip storing an entry for every empty gap.
+type HistogramSpan struct {
+	Offset int32  `json:"offset"`
+	Length uint32 `json:"length"`
+}
+
+// ScoreHistogram is a compact sparse exponential-bucket histogram over
+// non-negative evaluator scores, suitable for storing as a JSONB column per
+// aggregation bucket and merging cheaply across buckets/traces. Bucket index
+// for a positive value v is ceil(log2(v) * 2^Schema); PositiveDeltas holds,
+// for every populated bucket across all spans in order, the count at that
+// bucket minus the count at the previous populated bucket (0 for the very
+// first one), so runs of similar counts compress well.
+type ScoreHistogram struct {
+	Schema         int8            `json:"schema"`
+	ZeroCount      uint64          `json:"zeroCount"`
+	PositiveSpans  []HistogramSpan `json:"positiveSpans"`
+	PositiveDeltas []int64         `json:"positiveDeltas"`
+}
+
+// NewScoreHistogram returns an empty histogram at the default schema.
+func NewScoreHistogram() *ScoreHistogram {
+	return &ScoreHistogram{Schema: defaultHistogramSchema}
+}
+
+// Observe folds a single score into the histogram.
+func (h *ScoreHistogram) Observe(v float64) {
+	if math.Abs(v) <= zeroThreshold {
+		h.ZeroCount++
+		return
+	}
+	counts := h.expand()
+	counts[bucketIndex(math.Abs(v), h.Schema)]++
+	h.PositiveSpans, h.PositiveDeltas = compress(counts)
+}
+
+// Merge folds other's observations into h, coarsening whichever of the two
+// has the finer schema until both sides agree. This is lossy only by the
+// coarsening step itself, and only when the schemas actually differ.
+func (h *ScoreHistogram) Merge(other *ScoreHistogram) {
+	if other == nil {
+		return
+	}
+
+	left := h.expand()
+	leftSchema := h.Schema
+	right := other.expand()
+	rightSchema := other.Schema
+
+	for leftSchema > rightSchema {
+		left = downscale(left)
+		leftSchema--
+	}
+	for rightSchema > leftSchema {
+		right = downscale(right)
+		rightSchema--
+	}
+
+	for idx, count := range right {
+		left[idx] += count
+	}
+
+	h.Schema = leftSchema
+	h.ZeroCount += other.ZeroCount
+	h.PositiveSpans, h.PositiveDeltas = compress(left)
+}
+
+// Quantile returns a linearly-interpolated estimate of the q-th quantile
+// (0 <= q <= 1) of the observed scores, treating ZeroCount as mass at 0 and
+// each positive bucket's mass as uniformly spread across its value range.
+func (h *ScoreHistogram) Quantile(q float64) (float64, error) {
+	if q < 0 || q > 1 {
+		return 0, fmt.Errorf("quantile must be in [0, 1], got %v", q)
+	}
+
+	total := h.ZeroCount
+	counts := h.expand()
+	indices := make([]int32, 0, len(counts))
+	for idx := range counts {
+		indices = append(indices, idx)
+		total += uint64(counts[idx])
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("histogram has no observations")
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	target := q * float64(total-1)
+	cumulative := float64(0)
+
+	if float64(h.ZeroCount) > target {
+		return 0, nil
+	}
+	cumulative = float64(h.ZeroCount)
+
+	for _, idx := range indices {
+		c := float64(counts[idx])
+		if cumulative+c > target {
+			lo, hi := bucketBounds(idx, h.Schema)
+			frac := (target - cumulative) / c
+			return lo + frac*(hi-lo), nil
+		}
+		cumulative += c
+	}
+
+	// Floating point rounding can leave us just short; fall back to the
+	// upper bound of the last populated bucket.
+	_, hi := bucketBounds(indices[len(indices)-1], h.Schema)
+	return hi, nil
+}
+
+// bucketIndex computes the exponential bucket index for a positive value at
+// the given schema: ceil(log2(v) * 2^schema).
+func bucketIndex(v float64, schema int8) int32 {
+	return int32(math.Ceil(math.Log2(v) * math.Exp2(float64(schema))))
+}
+
+// bucketBounds returns the (lo, hi] value range a bucket index covers.
+func bucketBounds(idx int32, schema int8) (lo, hi float64) {
+	scale := math.Exp2(-float64(schema))
+	return math.Exp2(float64(idx-1) * scale), math.Exp2(float64(idx) * scale)
+}
+
+// downscale halves resolution by mapping every bucket index to (idx+1)>>1,
+// merging each adjacent pair of buckets. Since bucketIndex computes
+// ceil(log2(v)*2^schema), halving schema must map idx to ceil(idx/2), not
+// idx>>1 (floor(idx/2)) - the latter places every odd index one bucket too
+// low. (idx+1)>>1 is ceil(idx/2) for negative idx too, since Go's >> on a
+// signed int is an arithmetic (floor) shift. This is the same move Merge
+// uses to bring two histograms of different schemas into agreement.
+func downscale(counts map[int32]int64) map[int32]int64 {
+	out := make(map[int32]int64, len(counts))
+	for idx, count := range counts {
+		out[(idx+1)>>1] += count
+	}
+	return out
+}
+
+// expand reconstructs a dense index->count map from the span/delta encoding.
+func (h *ScoreHistogram) expand() map[int32]int64 {
+	counts := make(map[int32]int64, len(h.PositiveDeltas))
+	idx := int32(0)
+	pos := 0
+	running := int64(0)
+	for _, span := range h.PositiveSpans {
+		idx += span.Offset
+		for i := uint32(0); i < span.Length; i++ {
+			running += h.PositiveDeltas[pos]
+			counts[idx] = running
+			pos++
+			idx++
+		}
+	}
+	return counts
+}
+
+// compress encodes a dense index->count map back into gap-compressed spans
+// with delta-encoded counts.
+func compress(counts map[int32]int64) ([]HistogramSpan, []int64) {
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	indices := make([]int32, 0, len(counts))
+	for idx := range counts {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	var spans []HistogramSpan
+	var deltas []int64
+	running := int64(0)
+	cursor := int32(0)
+	spanStart := int32(0)
+	spanLen := uint32(0)
+
+	flush := func() {
+		if spanLen > 0 {
+			spans = append(spans, HistogramSpan{Offset: spanStart - cursor, Length: spanLen})
+			cursor = spanStart + int32(spanLen)
+		}
+	}
+
+	for i, idx := range indices {
+		if i == 0 || idx != indices[i-1]+1 {
+			flush()
+			spanStart = idx
+			spanLen = 0
+		}
+		deltas = append(deltas, counts[idx]-running)
+		running = counts[idx]
+		spanLen++
+	}
+	flush()
+
+	return spans, deltas
+}
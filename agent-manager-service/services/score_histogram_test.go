@@ -0,0 +1,151 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreHistogramObserveAndQuantile(t *testing.T) {
+	h := NewScoreHistogram()
+	for i := 1; i <= 100; i++ {
+		h.Observe(float64(i) / 100.0)
+	}
+
+	p50, err := h.Quantile(0.5)
+	if err != nil {
+		t.Fatalf("Quantile(0.5) unexpected error = %v", err)
+	}
+	if math.Abs(p50-0.5) > 0.02 {
+		t.Errorf("Quantile(0.5) = %v, want ~0.5", p50)
+	}
+
+	p99, err := h.Quantile(0.99)
+	if err != nil {
+		t.Fatalf("Quantile(0.99) unexpected error = %v", err)
+	}
+	if p99 < 0.9 || p99 > 1.01 {
+		t.Errorf("Quantile(0.99) = %v, want close to 1.0", p99)
+	}
+}
+
+func TestScoreHistogramZeroValues(t *testing.T) {
+	h := NewScoreHistogram()
+	for i := 0; i < 10; i++ {
+		h.Observe(0)
+	}
+	h.Observe(1.0)
+
+	if h.ZeroCount != 10 {
+		t.Errorf("ZeroCount = %d, want 10", h.ZeroCount)
+	}
+
+	p50, err := h.Quantile(0.5)
+	if err != nil {
+		t.Fatalf("Quantile(0.5) unexpected error = %v", err)
+	}
+	if p50 != 0 {
+		t.Errorf("Quantile(0.5) = %v, want 0 (zero-mass dominates)", p50)
+	}
+}
+
+func TestScoreHistogramQuantileEmpty(t *testing.T) {
+	h := NewScoreHistogram()
+	if _, err := h.Quantile(0.5); err == nil {
+		t.Error("Quantile() on empty histogram expected error, got nil")
+	}
+}
+
+func TestScoreHistogramQuantileInvalid(t *testing.T) {
+	h := NewScoreHistogram()
+	h.Observe(1.0)
+	if _, err := h.Quantile(1.5); err == nil {
+		t.Error("Quantile(1.5) expected error, got nil")
+	}
+	if _, err := h.Quantile(-0.1); err == nil {
+		t.Error("Quantile(-0.1) expected error, got nil")
+	}
+}
+
+func TestScoreHistogramExpandCompressRoundTrip(t *testing.T) {
+	h := NewScoreHistogram()
+	values := []float64{0.1, 0.1, 0.2, 0.5, 0.5, 0.5, 0.9, 2.0, 10.0}
+	for _, v := range values {
+		h.Observe(v)
+	}
+
+	counts := h.expand()
+	var total int64
+	for _, c := range counts {
+		total += c
+	}
+	if total != int64(len(values)) {
+		t.Errorf("expand() total count = %d, want %d", total, len(values))
+	}
+
+	spans, deltas := compress(counts)
+	roundTripped := ScoreHistogram{Schema: h.Schema, PositiveSpans: spans, PositiveDeltas: deltas}
+	reExpanded := roundTripped.expand()
+	if len(reExpanded) != len(counts) {
+		t.Fatalf("round-tripped bucket count = %d, want %d", len(reExpanded), len(counts))
+	}
+	for idx, c := range counts {
+		if reExpanded[idx] != c {
+			t.Errorf("round-tripped bucket %d = %d, want %d", idx, reExpanded[idx], c)
+		}
+	}
+}
+
+func TestScoreHistogramMergeDifferentSchemas(t *testing.T) {
+	a := NewScoreHistogram()
+	for i := 1; i <= 50; i++ {
+		a.Observe(float64(i) / 50.0)
+	}
+
+	b := &ScoreHistogram{Schema: defaultHistogramSchema - 2}
+	for i := 1; i <= 50; i++ {
+		b.Observe(float64(i) / 50.0)
+	}
+
+	a.Merge(b)
+
+	if a.Schema != defaultHistogramSchema-2 {
+		t.Errorf("Merge() schema = %d, want %d (coarser of the two)", a.Schema, defaultHistogramSchema-2)
+	}
+
+	var total int64
+	for _, c := range a.expand() {
+		total += c
+	}
+	total += int64(a.ZeroCount)
+	if total != 100 {
+		t.Errorf("Merge() total observations = %d, want 100", total)
+	}
+
+	// Values 1/50..50/50 merged with themselves: p50 should land near 0.5.
+	// A downscale that maps idx->idx>>1 instead of ceil(idx/2) biases every
+	// odd bucket one slot low, which this would catch even though the
+	// total-count assertion above does not.
+	p50, err := a.Quantile(0.5)
+	if err != nil {
+		t.Fatalf("Quantile(0.5) error = %v", err)
+	}
+	if p50 < 0.45 || p50 > 0.55 {
+		t.Errorf("Quantile(0.5) after Merge() = %v, want within [0.45, 0.55]", p50)
+	}
+}
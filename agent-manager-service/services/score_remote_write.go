@@ -0,0 +1,338 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"google.golang.org/protobuf/proto"
+)
+
+// ScoreSeriesLabels are the Prometheus-style labels a Score row maps to on
+// a remote_read/remote_write series, keyed consistently so federated
+// queries can select on monitor_id/evaluator_name the same way ScoreRepo's
+// own filters do.
+const (
+	ScoreLabelMonitorID     = "monitor_id"
+	ScoreLabelEvaluatorName = "evaluator_name"
+	ScoreLabelTraceID       = "trace_id"
+	ScoreLabelSkipReason    = "skip_reason"
+	ScoreMetricName         = "agent_manager_evaluator_score"
+)
+
+// ScoreSample is one Score row reduced to the {labels, value, timestamp}
+// shape a Prometheus remote_write WriteRequest carries per series, plus the
+// skip_reason exemplar the request asked to preserve rather than drop.
+type ScoreSample struct {
+	MonitorID     string
+	EvaluatorName string
+	TraceID       string
+	SkipReason    string
+	Value         float64
+	TimestampMs   int64
+}
+
+// ScoreSeriesLabelSet renders s's identifying labels in the
+// (name, value) pairs a prompb.TimeSeries carries, with __name__ first as
+// Prometheus expects.
+func (s ScoreSample) ScoreSeriesLabelSet() [][2]string {
+	labels := [][2]string{
+		{"__name__", ScoreMetricName},
+		{ScoreLabelMonitorID, s.MonitorID},
+		{ScoreLabelEvaluatorName, s.EvaluatorName},
+	}
+	if s.TraceID != "" {
+		labels = append(labels, [2]string{ScoreLabelTraceID, s.TraceID})
+	}
+	return labels
+}
+
+// toTimeSeries renders s as the prompb.TimeSeries a remote_read ReadResponse
+// carries, folding SkipReason into an exemplar label (per the request's
+// "skip_reason as an exemplar label" rather than a series label, since it
+// varies per-sample and would otherwise fragment the series by label set).
+func (s ScoreSample) toTimeSeries() prompb.TimeSeries {
+	labelSet := s.ScoreSeriesLabelSet()
+	labels := make([]prompb.Label, len(labelSet))
+	for i, kv := range labelSet {
+		labels[i] = prompb.Label{Name: kv[0], Value: kv[1]}
+	}
+
+	ts := prompb.TimeSeries{
+		Labels:  labels,
+		Samples: []prompb.Sample{{Value: s.Value, Timestamp: s.TimestampMs}},
+	}
+	if s.SkipReason != "" {
+		ts.Exemplars = []prompb.Exemplar{{
+			Labels:    []prompb.Label{{Name: ScoreLabelSkipReason, Value: s.SkipReason}},
+			Value:     s.Value,
+			Timestamp: s.TimestampMs,
+		}}
+	}
+	return ts
+}
+
+// ScoreMatcher is a decoded remote_read label matcher, translated from
+// whatever matcher type (equal, regex, etc.) the request used.
+type ScoreMatcher struct {
+	Name  string
+	Value string
+}
+
+// ScoreRangePredicate is the {monitor_id, evaluator_name, from, to} shape
+// ScoreRepo.GetEvaluatorTimeSeriesAggregated / GetEvaluatorTraceAggregated
+// already accept; ResolveScoreRangePredicate translates a remote_read
+// matcher set plus its time range into one, so the remote_read handler can
+// hand off to the existing repo methods unchanged once they exist.
+type ScoreRangePredicate struct {
+	MonitorID     string
+	EvaluatorName string
+	From          time.Time
+	To            time.Time
+}
+
+// ResolveScoreRangePredicate extracts monitor_id/evaluator_name from
+// matchers (requiring both, since ScoreRepo's aggregation methods are keyed
+// on both; the matcher type is not checked, so each value is used as an
+// exact match whatever type the request used) and combines them with the
+// [fromMs, toMs] range a remote_read ReadRequest.Query carries.
+func ResolveScoreRangePredicate(matchers []ScoreMatcher, fromMs, toMs int64) (ScoreRangePredicate, error) {
+	predicate := ScoreRangePredicate{
+		From: time.UnixMilli(fromMs).UTC(),
+		To:   time.UnixMilli(toMs).UTC(),
+	}
+	for _, m := range matchers {
+		switch m.Name {
+		case ScoreLabelMonitorID:
+			predicate.MonitorID = m.Value
+		case ScoreLabelEvaluatorName:
+			predicate.EvaluatorName = m.Value
+		}
+	}
+	if predicate.MonitorID == "" {
+		return ScoreRangePredicate{}, fmt.Errorf("remote_read query missing required %q matcher", ScoreLabelMonitorID)
+	}
+	if predicate.EvaluatorName == "" {
+		return ScoreRangePredicate{}, fmt.Errorf("remote_read query missing required %q matcher", ScoreLabelEvaluatorName)
+	}
+	return predicate, nil
+}
+
+// DecodedReadQuery is one query within a remote_read ReadRequest, already
+// split into the matcher set and [fromMs, toMs] range
+// ResolveScoreRangePredicate expects.
+type DecodedReadQuery struct {
+	Matchers []ScoreMatcher
+	FromMs   int64
+	ToMs     int64
+}
+
+// DecodeWriteRequest snappy-decompresses and protobuf-unmarshals a
+// remote_write request body into the ScoreSamples it carries, reversing
+// ScoreSeriesLabelSet/toTimeSeries: each prompb.TimeSeries's labels are
+// matched back to monitor_id/evaluator_name/trace_id, its single sample's
+// value/timestamp become Value/TimestampMs, and a skip_reason exemplar
+// label (if present) becomes SkipReason.
+func DecodeWriteRequest(body []byte) ([]ScoreSample, error) {
+	raw, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snappy-decode remote_write body: %w", err)
+	}
+	var req prompb.WriteRequest
+	if err := proto.Unmarshal(raw, &req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal WriteRequest: %w", err)
+	}
+
+	var samples []ScoreSample
+	for _, ts := range req.Timeseries {
+		base := ScoreSample{}
+		for _, l := range ts.Labels {
+			switch l.Name {
+			case ScoreLabelMonitorID:
+				base.MonitorID = l.Value
+			case ScoreLabelEvaluatorName:
+				base.EvaluatorName = l.Value
+			case ScoreLabelTraceID:
+				base.TraceID = l.Value
+			}
+		}
+		for _, ex := range ts.Exemplars {
+			for _, l := range ex.Labels {
+				if l.Name == ScoreLabelSkipReason {
+					base.SkipReason = l.Value
+				}
+			}
+		}
+		for _, sample := range ts.Samples {
+			s := base
+			s.Value = sample.Value
+			s.TimestampMs = sample.Timestamp
+			samples = append(samples, s)
+		}
+	}
+	return samples, nil
+}
+
+// DecodeReadRequest snappy-decompresses and protobuf-unmarshals a
+// remote_read request body into its constituent queries, translating each
+// prompb.Query's matchers/range into the shape ResolveScoreRangePredicate
+// expects.
+func DecodeReadRequest(body []byte) ([]DecodedReadQuery, error) {
+	raw, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snappy-decode remote_read body: %w", err)
+	}
+	var req prompb.ReadRequest
+	if err := proto.Unmarshal(raw, &req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ReadRequest: %w", err)
+	}
+
+	queries := make([]DecodedReadQuery, 0, len(req.Queries))
+	for _, q := range req.Queries {
+		matchers := make([]ScoreMatcher, 0, len(q.Matchers))
+		for _, m := range q.Matchers {
+			matchers = append(matchers, ScoreMatcher{Name: m.Name, Value: m.Value})
+		}
+		queries = append(queries, DecodedReadQuery{
+			Matchers: matchers,
+			FromMs:   q.StartTimestampMs,
+			ToMs:     q.EndTimestampMs,
+		})
+	}
+	return queries, nil
+}
+
+// EncodeReadResponse protobuf-marshals and snappy-compresses one
+// prompb.QueryResult per query's matched samples, in query order, into the
+// body a remote_read ReadResponse expects.
+func EncodeReadResponse(perQuerySamples [][]ScoreSample) ([]byte, error) {
+	resp := prompb.ReadResponse{Results: make([]*prompb.QueryResult, len(perQuerySamples))}
+	for i, samples := range perQuerySamples {
+		series := make([]*prompb.TimeSeries, len(samples))
+		for j, s := range samples {
+			ts := s.toTimeSeries()
+			series[j] = &ts
+		}
+		resp.Results[i] = &prompb.QueryResult{Timeseries: series}
+	}
+
+	raw, err := proto.Marshal(&resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ReadResponse: %w", err)
+	}
+	return snappy.Encode(nil, raw), nil
+}
+
+// BatchScoresFromSamples groups decoded remote_write samples into the
+// per-item upsert shape BatchCreateScores expects, deduplicating by the
+// same (monitor_id, evaluator_name, trace_id) key uq_score_per_item
+// enforces so replayed remote_write batches upsert instead of duplicating.
+func BatchScoresFromSamples(samples []ScoreSample) []ScoreSample {
+	seen := make(map[string]int, len(samples))
+	deduped := make([]ScoreSample, 0, len(samples))
+	for _, s := range samples {
+		key := s.MonitorID + "\x00" + s.EvaluatorName + "\x00" + s.TraceID
+		if idx, ok := seen[key]; ok {
+			// Later samples for the same key win, mirroring an upsert.
+			deduped[idx] = s
+			continue
+		}
+		seen[key] = len(deduped)
+		deduped = append(deduped, s)
+	}
+	return deduped
+}
+
+// FormatScoreSampleValue renders a score the way Prometheus text/remote
+// samples expect: a plain decimal, with no trailing zeros beyond what's
+// needed to round-trip.
+func FormatScoreSampleValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// ScoreRemoteWriteRepo is the narrow slice of ScoreRepo the remote_write/
+// remote_read handlers depend on: upserting decoded samples the same way
+// BatchCreateScores upserts Score rows, and answering one decoded
+// remote_read query by predicate.
+type ScoreRemoteWriteRepo interface {
+	// UpsertScoreSamples batches deduplicated remote_write samples (see
+	// BatchScoresFromSamples) into an upsert respecting uq_score_per_item.
+	UpsertScoreSamples(ctx context.Context, samples []ScoreSample) error
+	// QueryScoreSamples answers one decoded remote_read query, translating
+	// predicate into the run_evaluator_id + trace_timestamp filters
+	// GetEvaluatorTraceAggregated/GetEvaluatorTimeSeriesAggregated already use.
+	QueryScoreSamples(ctx context.Context, predicate ScoreRangePredicate) ([]ScoreSample, error)
+}
+
+// ScoreRemoteWriteService implements the Prometheus remote_read/remote_write
+// wire protocol over evaluator Scores: decode/encode lives here so the
+// HTTP handler stays a thin body-in, body-out layer, and persistence is
+// delegated to a ScoreRemoteWriteRepo.
+type ScoreRemoteWriteService interface {
+	// RemoteWrite decodes and upserts one remote_write request body.
+	RemoteWrite(ctx context.Context, body []byte) error
+	// RemoteRead decodes a remote_read request body and returns the
+	// snappy-compressed protobuf ReadResponse body to write back.
+	RemoteRead(ctx context.Context, body []byte) ([]byte, error)
+}
+
+type scoreRemoteWriteService struct {
+	repo ScoreRemoteWriteRepo
+}
+
+// NewScoreRemoteWriteService creates a new score remote_read/remote_write service.
+func NewScoreRemoteWriteService(repo ScoreRemoteWriteRepo) ScoreRemoteWriteService {
+	return &scoreRemoteWriteService{repo: repo}
+}
+
+func (s *scoreRemoteWriteService) RemoteWrite(ctx context.Context, body []byte) error {
+	samples, err := DecodeWriteRequest(body)
+	if err != nil {
+		return err
+	}
+	deduped := BatchScoresFromSamples(samples)
+	if len(deduped) == 0 {
+		return nil
+	}
+	return s.repo.UpsertScoreSamples(ctx, deduped)
+}
+
+func (s *scoreRemoteWriteService) RemoteRead(ctx context.Context, body []byte) ([]byte, error) {
+	queries, err := DecodeReadRequest(body)
+	if err != nil {
+		return nil, err
+	}
+
+	perQuerySamples := make([][]ScoreSample, len(queries))
+	for i, q := range queries {
+		predicate, err := ResolveScoreRangePredicate(q.Matchers, q.FromMs, q.ToMs)
+		if err != nil {
+			return nil, err
+		}
+		samples, err := s.repo.QueryScoreSamples(ctx, predicate)
+		if err != nil {
+			return nil, err
+		}
+		perQuerySamples[i] = samples
+	}
+	return EncodeReadResponse(perQuerySamples)
+}
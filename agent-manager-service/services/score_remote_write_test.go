@@ -0,0 +1,54 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import "testing"
+
+func TestResolveScoreRangePredicateRequiresBothMatchers(t *testing.T) {
+	_, err := ResolveScoreRangePredicate([]ScoreMatcher{{Name: ScoreLabelMonitorID, Value: "m1"}}, 0, 1000)
+	if err == nil {
+		t.Error("ResolveScoreRangePredicate() with no evaluator_name matcher expected error, got nil")
+	}
+}
+
+func TestResolveScoreRangePredicateOK(t *testing.T) {
+	predicate, err := ResolveScoreRangePredicate([]ScoreMatcher{
+		{Name: ScoreLabelMonitorID, Value: "m1"},
+		{Name: ScoreLabelEvaluatorName, Value: "latency"},
+	}, 0, 60000)
+	if err != nil {
+		t.Fatalf("ResolveScoreRangePredicate() unexpected error = %v", err)
+	}
+	if predicate.MonitorID != "m1" || predicate.EvaluatorName != "latency" {
+		t.Errorf("predicate = %+v, want monitor_id=m1 evaluator_name=latency", predicate)
+	}
+}
+
+func TestBatchScoresFromSamplesDedupesByItemKey(t *testing.T) {
+	samples := []ScoreSample{
+		{MonitorID: "m1", EvaluatorName: "latency", TraceID: "t1", Value: 0.5},
+		{MonitorID: "m1", EvaluatorName: "latency", TraceID: "t1", Value: 0.9},
+		{MonitorID: "m1", EvaluatorName: "latency", TraceID: "t2", Value: 0.1},
+	}
+	deduped := BatchScoresFromSamples(samples)
+	if len(deduped) != 2 {
+		t.Fatalf("len(deduped) = %d, want 2", len(deduped))
+	}
+	if deduped[0].Value != 0.9 {
+		t.Errorf("deduped[0].Value = %v, want 0.9 (later sample should win)", deduped[0].Value)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"fmt"
+	"sort"
+)
+
+// defaultDigestCompression bounds how many centroids a ScoreDigest keeps;
+// higher values trade memory for quantile accuracy.
+const defaultDigestCompression = 100
+
+// Centroid is a weighted point a ScoreDigest clusters nearby observations
+// into, sacrificing exact values for a serializable, mergeable summary.
+type Centroid struct {
+	Mean   float64 `json:"mean"`
+	Weight float64 `json:"weight"`
+}
+
+// ScoreDigest is a t-digest style sketch over evaluator scores: cheap to
+// merge across buckets, and accurate enough at the tails (p90/p99) to
+// replace exact per-bucket means once a range gets too wide to aggregate
+// with SQL alone.
+type ScoreDigest struct {
+	Compression float64    `json:"compression"`
+	Centroids   []Centroid `json:"centroids"`
+}
+
+// NewScoreDigest returns an empty digest at the default compression.
+func NewScoreDigest() *ScoreDigest {
+	return &ScoreDigest{Compression: defaultDigestCompression}
+}
+
+// Add folds a single weighted observation into the digest.
+func (d *ScoreDigest) Add(value, weight float64) {
+	if weight <= 0 {
+		return
+	}
+	d.Centroids = append(d.Centroids, Centroid{Mean: value, Weight: weight})
+	d.compress()
+}
+
+// Merge folds other's centroids into d. Merging is commutative and
+// associative up to compression loss, so buckets can be summed in any order.
+func (d *ScoreDigest) Merge(other *ScoreDigest) {
+	if other == nil {
+		return
+	}
+	d.Centroids = append(d.Centroids, other.Centroids...)
+	d.compress()
+}
+
+// TotalWeight returns the total observation count represented by the digest.
+func (d *ScoreDigest) TotalWeight() float64 {
+	var total float64
+	for _, c := range d.Centroids {
+		total += c.Weight
+	}
+	return total
+}
+
+// Mean returns the weighted mean of all observations in the digest.
+func (d *ScoreDigest) Mean() float64 {
+	var weightedSum, total float64
+	for _, c := range d.Centroids {
+		weightedSum += c.Mean * c.Weight
+		total += c.Weight
+	}
+	if total == 0 {
+		return 0
+	}
+	return weightedSum / total
+}
+
+// Quantile estimates the q-th quantile (0 <= q <= 1) by linear interpolation
+// between centroid means, weighted by cumulative mass up to each centroid.
+func (d *ScoreDigest) Quantile(q float64) (float64, error) {
+	if q < 0 || q > 1 {
+		return 0, fmt.Errorf("quantile must be in [0, 1], got %v", q)
+	}
+	total := d.TotalWeight()
+	if total == 0 {
+		return 0, fmt.Errorf("digest has no observations")
+	}
+
+	target := q * total
+	var cumulative float64
+	for i, c := range d.Centroids {
+		next := cumulative + c.Weight
+		if next >= target || i == len(d.Centroids)-1 {
+			if i == 0 {
+				return c.Mean, nil
+			}
+			// Interpolate linearly between the previous centroid's mean and
+			// this one, weighted by how far into this centroid's mass
+			// target falls.
+			prev := d.Centroids[i-1]
+			frac := (target - cumulative) / c.Weight
+			return prev.Mean + frac*(c.Mean-prev.Mean), nil
+		}
+		cumulative = next
+	}
+	return d.Centroids[len(d.Centroids)-1].Mean, nil
+}
+
+// compress sorts centroids by mean and greedily merges neighbors while the
+// running cumulative-weight fraction stays within one compression "slot",
+// keeping the sketch's centroid count roughly bounded regardless of how many
+// observations have been added.
+func (d *ScoreDigest) compress() {
+	if len(d.Centroids) <= 1 {
+		return
+	}
+	sort.Slice(d.Centroids, func(i, j int) bool { return d.Centroids[i].Mean < d.Centroids[j].Mean })
+
+	total := d.TotalWeight()
+	if total == 0 {
+		return
+	}
+	maxWeightPerSlot := total / d.Compression
+
+	merged := make([]Centroid, 0, len(d.Centroids))
+	current := d.Centroids[0]
+	for _, c := range d.Centroids[1:] {
+		if current.Weight+c.Weight <= maxWeightPerSlot {
+			newWeight := current.Weight + c.Weight
+			current.Mean = (current.Mean*current.Weight + c.Mean*c.Weight) / newWeight
+			current.Weight = newWeight
+			continue
+		}
+		merged = append(merged, current)
+		current = c
+	}
+	merged = append(merged, current)
+	d.Centroids = merged
+}
+
+// bucketWidthLadder is the fixed set of candidate bucket widths
+// AdaptiveBucketWidth picks from, finest first.
+var bucketWidthLadder = []int64{
+	10,         // 10s
+	60,         // 1m
+	300,        // 5m
+	3600,       // 1h
+	86400,      // 1d
+	7 * 86400,  // 1 week
+	30 * 86400, // 30 days
+}
+
+// AdaptiveBucketWidth picks the finest width (in seconds) from
+// bucketWidthLadder such that ceil(rangeSeconds/width) <= maxPoints, falling
+// back to the coarsest rung if even that would exceed maxPoints.
+func AdaptiveBucketWidth(rangeSeconds int64, maxPoints int) int64 {
+	for _, width := range bucketWidthLadder {
+		points := (rangeSeconds + width - 1) / width
+		if points <= int64(maxPoints) {
+			return width
+		}
+	}
+	return bucketWidthLadder[len(bucketWidthLadder)-1]
+}
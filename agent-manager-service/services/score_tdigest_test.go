@@ -0,0 +1,103 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreDigestQuantiles(t *testing.T) {
+	d := NewScoreDigest()
+	for i := 1; i <= 1000; i++ {
+		d.Add(float64(i)/1000.0, 1)
+	}
+
+	p50, err := d.Quantile(0.5)
+	if err != nil {
+		t.Fatalf("Quantile(0.5) unexpected error = %v", err)
+	}
+	if math.Abs(p50-0.5) > 0.03 {
+		t.Errorf("Quantile(0.5) = %v, want ~0.5", p50)
+	}
+
+	p99, err := d.Quantile(0.99)
+	if err != nil {
+		t.Fatalf("Quantile(0.99) unexpected error = %v", err)
+	}
+	if p99 < 0.9 || p99 > 1.01 {
+		t.Errorf("Quantile(0.99) = %v, want close to 1.0", p99)
+	}
+}
+
+func TestScoreDigestMergeParity(t *testing.T) {
+	whole := NewScoreDigest()
+	a := NewScoreDigest()
+	b := NewScoreDigest()
+	for i := 1; i <= 500; i++ {
+		whole.Add(float64(i), 1)
+		a.Add(float64(i), 1)
+	}
+	for i := 501; i <= 1000; i++ {
+		whole.Add(float64(i), 1)
+		b.Add(float64(i), 1)
+	}
+
+	a.Merge(b)
+
+	wantMean := whole.Mean()
+	gotMean := a.Mean()
+	if math.Abs(wantMean-gotMean) > 5 {
+		t.Errorf("Merge() mean = %v, want close to %v", gotMean, wantMean)
+	}
+	if math.Abs(a.TotalWeight()-1000) > 0.01 {
+		t.Errorf("Merge() TotalWeight() = %v, want 1000", a.TotalWeight())
+	}
+}
+
+func TestScoreDigestQuantileEmpty(t *testing.T) {
+	d := NewScoreDigest()
+	if _, err := d.Quantile(0.5); err == nil {
+		t.Error("Quantile() on empty digest expected error, got nil")
+	}
+}
+
+func TestScoreDigestQuantileInvalid(t *testing.T) {
+	d := NewScoreDigest()
+	d.Add(1.0, 1)
+	if _, err := d.Quantile(1.5); err == nil {
+		t.Error("Quantile(1.5) expected error, got nil")
+	}
+}
+
+func TestAdaptiveBucketWidth(t *testing.T) {
+	tests := []struct {
+		rangeSeconds int64
+		maxPoints    int
+		want         int64
+	}{
+		{3600, 100, 60},         // 1 hour, 100 points -> 1m buckets (60 points)
+		{86400, 100, 3600},      // 1 day, 100 points -> 1h buckets (24 points)
+		{30 * 86400, 60, 86400}, // 30 days, 60 points -> 1d buckets (30 points)
+	}
+	for _, tt := range tests {
+		got := AdaptiveBucketWidth(tt.rangeSeconds, tt.maxPoints)
+		if got != tt.want {
+			t.Errorf("AdaptiveBucketWidth(%d, %d) = %d, want %d", tt.rangeSeconds, tt.maxPoints, got, tt.want)
+		}
+	}
+}
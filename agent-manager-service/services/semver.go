@@ -0,0 +1,210 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// virtualProviderNamespace seeds the deterministic UUIDs
+// DeriveVersionRangeDeployments mints for synthetic minor/major-version
+// providers, so the same concrete provider always derives the same virtual
+// UUID on every call instead of a random one that would look like a new
+// artifact to a gateway each time it synced.
+var virtualProviderNamespace = uuid.MustParse("8f14e45f-ceea-467e-bd76-14d5f1c4e3e2")
+
+// semanticVersion is a parsed semver 2.0 MAJOR.MINOR.PATCH[-PRERELEASE] version.
+type semanticVersion struct {
+	Major, Minor, Patch int
+	PreRelease          string
+}
+
+// parseSemver parses a MAJOR.MINOR.PATCH[-PRERELEASE] version string. Build
+// metadata (a trailing "+...") is accepted but ignored, matching semver
+// 2.0: it has no bearing on precedence.
+func parseSemver(version string) (semanticVersion, error) {
+	version = strings.SplitN(version, "+", 2)[0]
+
+	var preRelease string
+	if idx := strings.IndexByte(version, '-'); idx >= 0 {
+		preRelease = version[idx+1:]
+		version = version[:idx]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return semanticVersion{}, fmt.Errorf("invalid semver %q: expected MAJOR.MINOR.PATCH", version)
+	}
+
+	nums := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return semanticVersion{}, fmt.Errorf("invalid semver %q: %w", version, err)
+		}
+		nums[i] = n
+	}
+
+	return semanticVersion{Major: nums[0], Minor: nums[1], Patch: nums[2], PreRelease: preRelease}, nil
+}
+
+// compareSemver returns -1, 0, or 1 as a is less than, equal to, or greater
+// than b, per semver 2.0 precedence: major/minor/patch compare numerically
+// first, and a pre-release version always has lower precedence than the
+// same version without one. Pre-release tags themselves are compared as
+// plain strings rather than semver 2.0's full dot-separated identifier
+// rules, which is enough to rank this service's own release train.
+func compareSemver(a, b semanticVersion) int {
+	if a.Major != b.Major {
+		return compareInt(a.Major, b.Major)
+	}
+	if a.Minor != b.Minor {
+		return compareInt(a.Minor, b.Minor)
+	}
+	if a.Patch != b.Patch {
+		return compareInt(a.Patch, b.Patch)
+	}
+	if a.PreRelease == b.PreRelease {
+		return 0
+	}
+	if a.PreRelease == "" {
+		return 1
+	}
+	if b.PreRelease == "" {
+		return -1
+	}
+	if a.PreRelease < b.PreRelease {
+		return -1
+	}
+	return 1
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// VersionedProviderDeployment pairs a deployed LLMProvider with the
+// ProviderGatewayDeployment that put it on a gateway — the unit
+// DeriveVersionRangeDeployments groups and ranks. Deployment is nil when
+// the caller has no gateway-scoped deployment to attach (e.g. an
+// organization-wide sync rather than one gateway's deployments).
+type VersionedProviderDeployment struct {
+	Provider   models.LLMProvider
+	Deployment *models.ProviderGatewayDeployment
+}
+
+// DeriveVersionRangeDeployments takes every concrete provider/deployment
+// pair sharing a handle and returns the synthetic "latest within range"
+// pairs a gateway uses to route unpinned requests (POST /openai/1/chat,
+// POST /openai/1.2/chat) to the newest matching concrete version: one per
+// (handle, major) pointing at the highest minor.patch deployed under that
+// major, and one per (handle, major, minor) pointing at the highest patch
+// deployed under that minor. A group contributes no synthetic entry when it
+// has only one concrete member, since that member is already the latest.
+//
+// Synthetic UUIDs are derived deterministically (uuid.NewSHA1 over a fixed
+// namespace) from the concrete target's UUID and the range it represents,
+// so the same deployment state always produces the same synthetic UUIDs.
+// Entries whose Version does not parse as semver are skipped; they keep
+// routing only by their own exact concrete version.
+func DeriveVersionRangeDeployments(deployed []VersionedProviderDeployment) []VersionedProviderDeployment {
+	type parsedEntry struct {
+		VersionedProviderDeployment
+		version semanticVersion
+	}
+
+	byMinor := make(map[string][]parsedEntry)
+	byMajor := make(map[string][]parsedEntry)
+
+	for _, entry := range deployed {
+		version, err := parseSemver(entry.Provider.Version)
+		if err != nil {
+			continue
+		}
+		parsed := parsedEntry{VersionedProviderDeployment: entry, version: version}
+
+		minorKey := fmt.Sprintf("%s/%d.%d", entry.Provider.Handle, version.Major, version.Minor)
+		byMinor[minorKey] = append(byMinor[minorKey], parsed)
+
+		majorKey := fmt.Sprintf("%s/%d", entry.Provider.Handle, version.Major)
+		byMajor[majorKey] = append(byMajor[majorKey], parsed)
+	}
+
+	highest := func(group []parsedEntry) parsedEntry {
+		sort.Slice(group, func(i, j int) bool {
+			return compareSemver(group[i].version, group[j].version) > 0
+		})
+		return group[0]
+	}
+
+	var synthetic []VersionedProviderDeployment
+
+	for _, group := range byMinor {
+		if len(group) < 2 {
+			continue
+		}
+		top := highest(group)
+		synthetic = append(synthetic, virtualVersionDeployment(top.VersionedProviderDeployment,
+			fmt.Sprintf("%d.%d", top.version.Major, top.version.Minor),
+			models.DeploymentTypePatchVersion))
+	}
+
+	for _, group := range byMajor {
+		if len(group) < 2 {
+			continue
+		}
+		top := highest(group)
+		synthetic = append(synthetic, virtualVersionDeployment(top.VersionedProviderDeployment,
+			fmt.Sprintf("%d", top.version.Major),
+			models.DeploymentTypeMinorVersion))
+	}
+
+	return synthetic
+}
+
+// virtualVersionDeployment builds the synthetic provider/deployment pair
+// for a version-range route like "openai-1" or "openai-1.2", pointing at
+// target's concrete configuration.
+func virtualVersionDeployment(target VersionedProviderDeployment, rangeVersion string, deploymentType string) VersionedProviderDeployment {
+	provider := target.Provider
+	provider.UUID = uuid.NewSHA1(virtualProviderNamespace, []byte(target.Provider.UUID.String()+":"+rangeVersion))
+	provider.Handle = fmt.Sprintf("%s-%s", target.Provider.Handle, rangeVersion)
+
+	var deployment *models.ProviderGatewayDeployment
+	if target.Deployment != nil {
+		d := *target.Deployment
+		d.ProviderUUID = provider.UUID
+		d.Type = deploymentType
+		deployment = &d
+	}
+
+	return VersionedProviderDeployment{Provider: provider, Deployment: deployment}
+}
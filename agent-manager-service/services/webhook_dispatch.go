@@ -0,0 +1,198 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/repositories"
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/utils/retry"
+)
+
+// webhookSignatureHeader carries the HMAC-SHA256 signature of the request
+// body, hex-encoded, so subscribers can verify the callback actually came
+// from agent-manager and wasn't forged.
+const webhookSignatureHeader = "X-Agent-Manager-Signature"
+
+// maxDeliveryAttempts is the retry budget before a delivery is moved to the
+// dead-letter status.
+const maxDeliveryAttempts = 6
+
+// WebhookDispatchService delivers MonitorRunCompletedEventDTO (and future
+// event types) to every active WebhookSubscription for the event's org/agent.
+type WebhookDispatchService interface {
+	// Dispatch queues eventType/payload for every subscription matching
+	// orgName/agentName, recording one pending WebhookDelivery per
+	// subscription. Signing and sending happen later, when the caller's
+	// background loop calls DeliverDue, so Dispatch never blocks on a slow
+	// subscriber.
+	Dispatch(ctx context.Context, orgName, agentName, eventType string, payload interface{}) error
+	// DeliverDue attempts every delivery whose next retry is due, advancing
+	// it to succeeded, failed (with backoff), or dead_letter.
+	DeliverDue(ctx context.Context, limit int) error
+}
+
+type webhookDispatchService struct {
+	repo       repositories.WebhookDeliveryRepository
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewWebhookDispatchService creates a new webhook dispatch service.
+func NewWebhookDispatchService(repo repositories.WebhookDeliveryRepository, logger *slog.Logger) WebhookDispatchService {
+	return &webhookDispatchService{
+		repo:       repo,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+	}
+}
+
+// Dispatch queues eventType/payload for every subscription matching
+// orgName/agentName, creating one pending WebhookDelivery per subscription
+// for DeliverDue to sign and send.
+func (s *webhookDispatchService) Dispatch(ctx context.Context, orgName, agentName, eventType string, payload interface{}) error {
+	subs, err := s.repo.ListActiveSubscriptions(orgName, agentName)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	for _, sub := range subs {
+		delivery := &models.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: sub.ID,
+			EventType:      eventType,
+			Payload:        string(raw),
+			Status:         models.WebhookDeliveryPending,
+		}
+		if err := s.repo.CreateDelivery(delivery); err != nil {
+			s.logger.Error("Failed to queue webhook delivery", "subscriptionId", sub.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// DeliverDue attempts every delivery whose next retry is due.
+func (s *webhookDispatchService) DeliverDue(ctx context.Context, limit int) error {
+	due, err := s.repo.ListDueDeliveries(limit)
+	if err != nil {
+		return fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+
+	for i := range due {
+		delivery := &due[i]
+		sub, err := s.repo.GetSubscriptionByID(delivery.SubscriptionID)
+		if err != nil {
+			s.logger.Error("Failed to load webhook subscription for delivery", "deliveryId", delivery.ID, "error", err)
+			continue
+		}
+
+		attemptErr := s.attempt(ctx, sub, delivery)
+		delivery.AttemptCount++
+		if attemptErr == nil {
+			delivery.Status = models.WebhookDeliverySucceeded
+			delivery.LastError = ""
+			delivery.NextAttemptAt = nil
+		} else {
+			delivery.LastError = attemptErr.Error()
+			if delivery.AttemptCount >= maxDeliveryAttempts {
+				delivery.Status = models.WebhookDeliveryDeadLetter
+				delivery.NextAttemptAt = nil
+			} else {
+				delivery.Status = models.WebhookDeliveryFailed
+				next := time.Now().Add(backoffFor(delivery.AttemptCount))
+				delivery.NextAttemptAt = &next
+			}
+		}
+
+		if err := s.repo.UpdateDelivery(delivery); err != nil {
+			s.logger.Error("Failed to update webhook delivery", "deliveryId", delivery.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// attempt signs delivery.Payload and POSTs it to sub.URL. Within this single
+// call it retries, per retry.DefaultPolicy, only errors that
+// retry.DefaultIsRetryable accepts (such as a context deadline); a non-2xx
+// response or a refused connection is returned on the first failure.
+func (s *webhookDispatchService) attempt(ctx context.Context, sub *models.WebhookSubscription, delivery *models.WebhookDelivery) error {
+	if sub == nil {
+		return fmt.Errorf("subscription not found")
+	}
+
+	signature := signPayload(sub.Secret, []byte(delivery.Payload))
+
+	return retry.Do(ctx, retry.DefaultPolicy, retry.DefaultIsRetryable, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader([]byte(delivery.Payload)))
+		if err != nil {
+			return fmt.Errorf("failed to build webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(webhookSignatureHeader, signature)
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("webhook request failed: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+		}
+		return nil
+	})
+}
+
+// signPayload returns the hex-encoded HMAC-SHA256 of payload under secret.
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoffFor returns the delay before the next delivery attempt, doubling
+// from 30s up to a 1 hour ceiling.
+func backoffFor(attempt int) time.Duration {
+	backoff := 30 * time.Second
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff > time.Hour {
+			return time.Hour
+		}
+	}
+	return backoff
+}
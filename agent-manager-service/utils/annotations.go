@@ -0,0 +1,207 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import "fmt"
+
+// AnnotationSeverity distinguishes annotations that merely explain a
+// response (Info) from ones that flag a response may be incomplete or
+// degraded (Warning), modeled after PromQL's warnings/infos split.
+type AnnotationSeverity int
+
+const (
+	SeverityInfo AnnotationSeverity = iota
+	SeverityWarning
+)
+
+// AnnotationError is implemented by the typed annotation constructors below
+// (and can be implemented by callers) so Annotations.Add can file an error
+// under its proper type and severity instead of a generic bucket.
+type AnnotationError interface {
+	error
+	AnnotationType() string
+	Severity() AnnotationSeverity
+}
+
+type typedAnnotation struct {
+	typ      string
+	severity AnnotationSeverity
+	message  string
+}
+
+func (a typedAnnotation) Error() string               { return a.message }
+func (a typedAnnotation) AnnotationType() string       { return a.typ }
+func (a typedAnnotation) Severity() AnnotationSeverity { return a.severity }
+
+// NewPossibleIncompleteBucketWarning flags that a bucket's aggregation may
+// not reflect every row in range, e.g. because the backing query hit a
+// statement timeout and returned a partial result.
+func NewPossibleIncompleteBucketWarning(bucket string) error {
+	return typedAnnotation{
+		typ:      "PossibleIncompleteBucketWarning",
+		severity: SeverityWarning,
+		message:  fmt.Sprintf("bucket %s may be incomplete: the backing query returned a partial result", bucket),
+	}
+}
+
+// NewDownsampledGranularityInfo notes which granularity CalculateAdaptiveGranularity
+// chose, so clients can tell a "week" bucketed response from a "minute" one
+// without re-deriving the choice themselves.
+func NewDownsampledGranularityInfo(granularity string) error {
+	return typedAnnotation{
+		typ:      "DownsampledGranularityInfo",
+		severity: SeverityInfo,
+		message:  fmt.Sprintf("results are bucketed at %q granularity", granularity),
+	}
+}
+
+// NewEvaluatorNotConfiguredWarning flags that the requested evaluator has no
+// matching monitor_run_evaluator row, so the response reflects no data
+// rather than a zero score.
+func NewEvaluatorNotConfiguredWarning(evaluator string) error {
+	return typedAnnotation{
+		typ:      "EvaluatorNotConfiguredWarning",
+		severity: SeverityWarning,
+		message:  fmt.Sprintf("evaluator %q is not configured on this monitor", evaluator),
+	}
+}
+
+// NewSparseDataInfo notes that trace-level (rather than bucketed) granularity
+// was chosen because the count probe was at or below RawThreshold.
+func NewSparseDataInfo(count, threshold int64) error {
+	return typedAnnotation{
+		typ:      "SparseDataInfo",
+		severity: SeverityInfo,
+		message:  fmt.Sprintf("only %d data point(s) in range (<= %d); returning trace-level results", count, threshold),
+	}
+}
+
+// NewStepAutoWidenedInfo notes that the repo widened the requested bucket
+// step to keep the point count under its safety cap.
+func NewStepAutoWidenedInfo(requested, actual string) error {
+	return typedAnnotation{
+		typ:      "StepAutoWidenedInfo",
+		severity: SeverityInfo,
+		message:  fmt.Sprintf("requested step %q would exceed the maximum point count; widened to %q", requested, actual),
+	}
+}
+
+// NewHeterogeneousEvaluatorVersionsWarning flags that the scores in range
+// come from more than one run_evaluator_id for the same evaluator, e.g.
+// because the evaluator's config was re-seeded mid-range, so the aggregate
+// may mix incompatible scoring versions.
+func NewHeterogeneousEvaluatorVersionsWarning(evaluator string) error {
+	return typedAnnotation{
+		typ:      "HeterogeneousEvaluatorVersionsWarning",
+		severity: SeverityWarning,
+		message:  fmt.Sprintf("evaluator %q changed configuration within this range; aggregate mixes multiple evaluator versions", evaluator),
+	}
+}
+
+// NewHighSkipRatioWarning flags that enough rows in range have a SkipReason
+// that the mean may not be representative.
+func NewHighSkipRatioWarning(skipRatio, threshold float64) error {
+	return typedAnnotation{
+		typ:      "HighSkipRatioWarning",
+		severity: SeverityWarning,
+		message:  fmt.Sprintf("%.0f%% of rows in range were skipped (over the %.0f%% threshold); the mean may be unreliable", skipRatio*100, threshold*100),
+	}
+}
+
+// NewPartitionedTailWarning flags that the query only saw the partitioned
+// tail of data because older buckets fell past a retention/TTL cutoff.
+func NewPartitionedTailWarning(cutoff string) error {
+	return typedAnnotation{
+		typ:      "PartitionedTailWarning",
+		severity: SeverityWarning,
+		message:  fmt.Sprintf("data before %s has aged out of retention; results only cover the remaining tail", cutoff),
+	}
+}
+
+// Annotations accumulates warnings and infos over the course of handling a
+// request, so a service can attach "why" context to a response without
+// turning it into an HTTP error. The zero value is ready to use.
+type Annotations struct {
+	items []typedAnnotation
+}
+
+// NewAnnotations returns an empty, ready-to-use Annotations set.
+func NewAnnotations() *Annotations {
+	return &Annotations{}
+}
+
+// Add files err as an annotation. If err implements AnnotationError its type
+// and severity are preserved; otherwise it's recorded as a generic warning.
+func (a *Annotations) Add(err error) {
+	if err == nil {
+		return
+	}
+	if ae, ok := err.(AnnotationError); ok {
+		a.items = append(a.items, typedAnnotation{typ: ae.AnnotationType(), severity: ae.Severity(), message: ae.Error()})
+		return
+	}
+	a.items = append(a.items, typedAnnotation{typ: "Error", severity: SeverityWarning, message: err.Error()})
+}
+
+// Merge appends other's annotations onto a. A nil other is a no-op.
+func (a *Annotations) Merge(other *Annotations) {
+	if other == nil {
+		return
+	}
+	a.items = append(a.items, other.items...)
+}
+
+// Len returns the total number of annotations recorded (warnings + infos).
+func (a *Annotations) Len() int {
+	if a == nil {
+		return 0
+	}
+	return len(a.items)
+}
+
+// AsStrings renders the accumulated annotations as warning/info message
+// strings prefixed with query for context, capping each distinct annotation
+// type at maxPerType messages (0 means unlimited) so one noisy bucket can't
+// drown out everything else.
+func (a *Annotations) AsStrings(query string, maxPerType int) (warnings, infos []string) {
+	if a == nil {
+		return nil, nil
+	}
+
+	seenPerType := make(map[string]int)
+	for _, item := range a.items {
+		if maxPerType > 0 {
+			seenPerType[item.typ]++
+			if seenPerType[item.typ] > maxPerType {
+				continue
+			}
+		}
+
+		msg := item.message
+		if query != "" {
+			msg = fmt.Sprintf("%s: %s", query, msg)
+		}
+
+		switch item.severity {
+		case SeverityWarning:
+			warnings = append(warnings, msg)
+		default:
+			infos = append(infos, msg)
+		}
+	}
+	return warnings, infos
+}
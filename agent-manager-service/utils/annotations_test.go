@@ -0,0 +1,92 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAnnotationsAddSplitsWarningsAndInfos(t *testing.T) {
+	a := NewAnnotations()
+	a.Add(NewSparseDataInfo(10, RawThreshold))
+	a.Add(NewEvaluatorNotConfiguredWarning("latency"))
+	a.Add(errors.New("boom"))
+
+	warnings, infos := a.AsStrings("", 0)
+	if len(warnings) != 2 {
+		t.Errorf("len(warnings) = %d, want 2", len(warnings))
+	}
+	if len(infos) != 1 {
+		t.Errorf("len(infos) = %d, want 1", len(infos))
+	}
+	if a.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", a.Len())
+	}
+}
+
+func TestAnnotationsMerge(t *testing.T) {
+	a := NewAnnotations()
+	a.Add(NewDownsampledGranularityInfo("week"))
+
+	b := NewAnnotations()
+	b.Add(NewPossibleIncompleteBucketWarning("2026-07-26T10:00:00Z"))
+
+	a.Merge(b)
+	if a.Len() != 2 {
+		t.Errorf("Len() after Merge = %d, want 2", a.Len())
+	}
+}
+
+func TestAnnotationsAsStringsMaxPerType(t *testing.T) {
+	a := NewAnnotations()
+	for i := 0; i < 5; i++ {
+		a.Add(NewEvaluatorNotConfiguredWarning("latency"))
+	}
+
+	warnings, _ := a.AsStrings("", 2)
+	if len(warnings) != 2 {
+		t.Errorf("len(warnings) with maxPerType=2 = %d, want 2", len(warnings))
+	}
+}
+
+func TestAnnotationsAggregationWarnings(t *testing.T) {
+	a := NewAnnotations()
+	a.Add(NewStepAutoWidenedInfo("10s", "1m"))
+	a.Add(NewHeterogeneousEvaluatorVersionsWarning("latency"))
+	a.Add(NewHighSkipRatioWarning(0.6, 0.5))
+	a.Add(NewPartitionedTailWarning("2026-01-01"))
+
+	warnings, infos := a.AsStrings("", 0)
+	if len(warnings) != 3 {
+		t.Errorf("len(warnings) = %d, want 3", len(warnings))
+	}
+	if len(infos) != 1 {
+		t.Errorf("len(infos) = %d, want 1", len(infos))
+	}
+}
+
+func TestAnnotationsNilSafe(t *testing.T) {
+	var a *Annotations
+	if a.Len() != 0 {
+		t.Errorf("Len() on nil Annotations = %d, want 0", a.Len())
+	}
+	warnings, infos := a.AsStrings("q", 0)
+	if warnings != nil || infos != nil {
+		t.Error("AsStrings() on nil Annotations should return nil, nil")
+	}
+}
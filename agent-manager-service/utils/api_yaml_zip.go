@@ -0,0 +1,148 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/wso2/ai-agent-management-platform/agent-manager-service/models"
+)
+
+// zipFixedModTime is stamped on every entry instead of time.Now(), so two
+// calls over identical apis produce a byte-identical archive. That lets
+// GetAPI/GetAPIsByOrganization key an ETag/cache off the archive's content
+// rather than the time it happened to be built.
+var zipFixedModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// flusher is satisfied by http.ResponseWriter (and anything else exposing
+// Flush), checked via a local interface so this package doesn't need to
+// import net/http just for the type assertion in WriteAPIYamlZip.
+type flusher interface {
+	Flush()
+}
+
+// ZipOptions configures WriteAPIYamlZip's entry compression and, optionally,
+// the detached signature embedded alongside the YAML entries.
+type ZipOptions struct {
+	// DeflateLevel is passed to flate.NewWriter for every entry. Zero means
+	// flate.DefaultCompression.
+	DeflateLevel int
+
+	// Signature, when set, is written as signature.sig (base64) and
+	// SignerPublicKey as signer.pub (base64), so a gateway can verify the
+	// archive's YAML entries before applying them. Both must be set
+	// together; see services.ArtifactSigningService.Sign for how the
+	// signature is produced.
+	Signature       *models.ArtifactSignature
+	SignerPublicKey []byte
+}
+
+// writeSignatureEntries writes signature.sig and signer.pub to zw when opts
+// carries a signature, shared by WriteAPIYamlZip and WriteBundleZip so both
+// archive formats embed signatures the same way.
+func writeSignatureEntries(zw *zip.Writer, opts ZipOptions) error {
+	if opts.Signature == nil {
+		return nil
+	}
+
+	sigEntry, err := zw.CreateHeader(&zip.FileHeader{Name: "signature.sig", Method: zip.Deflate, Modified: zipFixedModTime})
+	if err != nil {
+		return fmt.Errorf("failed to create signature.sig entry: %w", err)
+	}
+	if _, err := fmt.Fprintf(sigEntry, "kid=%s\nalgorithm=%s\n%s", opts.Signature.KID, opts.Signature.Algorithm, opts.Signature.Signature); err != nil {
+		return fmt.Errorf("failed to write signature.sig entry: %w", err)
+	}
+
+	pubEntry, err := zw.CreateHeader(&zip.FileHeader{Name: "signer.pub", Method: zip.Deflate, Modified: zipFixedModTime})
+	if err != nil {
+		return fmt.Errorf("failed to create signer.pub entry: %w", err)
+	}
+	if _, err := pubEntry.Write([]byte(base64.StdEncoding.EncodeToString(opts.SignerPublicKey))); err != nil {
+		return fmt.Errorf("failed to write signer.pub entry: %w", err)
+	}
+
+	return zw.Flush()
+}
+
+// WriteAPIYamlZip streams a ZIP archive of apis (entry name -> YAML
+// content) directly to w, writing and flushing one entry at a time instead
+// of buffering the whole archive in memory first. The YAML for every entry
+// is already in apis when this is called; what is not held in memory is the
+// compressed archive, whose bytes reach w as each entry is written.
+//
+// Entries are written in sorted-name order and stamped with a fixed mtime
+// (see zipFixedModTime), so two calls over the same apis produce a
+// byte-identical archive.
+//
+// Once the first entry is flushed, a later error can no longer be reported
+// via an HTTP status code — callers must have already committed to a 200
+// response before calling this, same tradeoff every streaming handler makes
+// for not buffering the full response up front.
+func WriteAPIYamlZip(w io.Writer, apis map[string]string, opts ZipOptions) error {
+	level := opts.DeflateLevel
+	if level == 0 {
+		level = flate.DefaultCompression
+	}
+
+	zw := zip.NewWriter(w)
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, level)
+	})
+
+	names := make([]string, 0, len(apis))
+	for name := range apis {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	flush, canFlush := w.(flusher)
+
+	for _, name := range names {
+		entryWriter, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     name + ".yaml",
+			Method:   zip.Deflate,
+			Modified: zipFixedModTime,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create zip entry %q: %w", name, err)
+		}
+		if _, err := entryWriter.Write([]byte(apis[name])); err != nil {
+			return fmt.Errorf("failed to write zip entry %q: %w", name, err)
+		}
+		if err := zw.Flush(); err != nil {
+			return fmt.Errorf("failed to flush zip entry %q: %w", name, err)
+		}
+		if canFlush {
+			flush.Flush()
+		}
+	}
+
+	if err := writeSignatureEntries(zw, opts); err != nil {
+		return err
+	}
+	if canFlush {
+		flush.Flush()
+	}
+
+	return zw.Close()
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func TestWriteAPIYamlZipRoundTrip(t *testing.T) {
+	apis := map[string]string{
+		"provider-b": "b: yaml\n",
+		"provider-a": "a: yaml\n",
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAPIYamlZip(&buf, apis, ZipOptions{}); err != nil {
+		t.Fatalf("WriteAPIYamlZip() error = %v", err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	if len(r.File) != 2 {
+		t.Fatalf("len(r.File) = %d, want 2", len(r.File))
+	}
+
+	// Entries are written in sorted-name order.
+	if r.File[0].Name != "provider-a.yaml" || r.File[1].Name != "provider-b.yaml" {
+		t.Errorf("entry order = [%s, %s], want sorted names", r.File[0].Name, r.File[1].Name)
+	}
+
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("f.Open() error = %v", err)
+		}
+		var got bytes.Buffer
+		if _, err := got.ReadFrom(rc); err != nil {
+			t.Fatalf("ReadFrom() error = %v", err)
+		}
+		_ = rc.Close()
+
+		name := f.Name[:len(f.Name)-len(".yaml")]
+		if got.String() != apis[name] {
+			t.Errorf("entry %q content = %q, want %q", f.Name, got.String(), apis[name])
+		}
+	}
+}
+
+func TestWriteAPIYamlZipIsDeterministic(t *testing.T) {
+	apis := map[string]string{"provider-a": "a: 1\n", "provider-b": "b: 2\n"}
+
+	var first, second bytes.Buffer
+	if err := WriteAPIYamlZip(&first, apis, ZipOptions{}); err != nil {
+		t.Fatalf("WriteAPIYamlZip() error = %v", err)
+	}
+	if err := WriteAPIYamlZip(&second, apis, ZipOptions{}); err != nil {
+		t.Fatalf("WriteAPIYamlZip() error = %v", err)
+	}
+
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("WriteAPIYamlZip() produced different bytes across calls with identical input")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"fmt"
+	"io"
+)
+
+// BundleFile is one artifact entry written into a bundle ZIP by
+// WriteBundleZip, alongside the shared manifest.json.
+type BundleFile struct {
+	Name    string
+	Content []byte
+}
+
+// WriteBundleZip streams a ZIP archive to w containing manifestJSON as
+// manifest.json followed by files in the given order, writing and flushing
+// one entry at a time the same way WriteAPIYamlZip does. Unlike
+// WriteAPIYamlZip, entry order here is caller-controlled rather than sorted,
+// since manifest.json must come first and the remaining files are already
+// ordered the way GetBundleForGateway built the manifest.
+func WriteBundleZip(w io.Writer, manifestJSON []byte, files []BundleFile, opts ZipOptions) error {
+	level := opts.DeflateLevel
+	if level == 0 {
+		level = flate.DefaultCompression
+	}
+
+	zw := zip.NewWriter(w)
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, level)
+	})
+
+	flush, canFlush := w.(flusher)
+
+	writeEntry := func(name string, content []byte) error {
+		entryWriter, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: zipFixedModTime,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create zip entry %q: %w", name, err)
+		}
+		if _, err := entryWriter.Write(content); err != nil {
+			return fmt.Errorf("failed to write zip entry %q: %w", name, err)
+		}
+		if err := zw.Flush(); err != nil {
+			return fmt.Errorf("failed to flush zip entry %q: %w", name, err)
+		}
+		if canFlush {
+			flush.Flush()
+		}
+		return nil
+	}
+
+	if err := writeEntry("manifest.json", manifestJSON); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := writeEntry(f.Name, f.Content); err != nil {
+			return err
+		}
+	}
+
+	if err := writeSignatureEntries(zw, opts); err != nil {
+		return err
+	}
+	if canFlush {
+		flush.Flush()
+	}
+
+	return zw.Close()
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ShouldReturnNotModified reports whether a conditional GET request already
+// has the current representation, per RFC 7232: an exact (or "*") match on
+// If-None-Match takes precedence over If-Modified-Since, matching how
+// browsers and CDNs prioritize a strong validator over a timestamp.
+func ShouldReturnNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			if strings.TrimSpace(candidate) == "*" || strings.TrimSpace(candidate) == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		t, err := http.ParseTime(ims)
+		if err == nil && !lastModified.Truncate(time.Second).After(t) {
+			return true
+		}
+	}
+
+	return false
+}
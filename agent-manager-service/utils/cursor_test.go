@@ -0,0 +1,81 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import "testing"
+
+func TestEncodeDecodeCursorRoundTrip(t *testing.T) {
+	original := Cursor{
+		LastSortKey: "2026-07-26T10:00:00Z",
+		LastUUID:    "8f14e45f-ceea-4ab9-8e0f-e7b5a3a2b1f1",
+		FilterHash:  HashFilters("kind=agent", "handlePrefix=gpt"),
+	}
+
+	token, err := EncodeCursor(original)
+	if err != nil {
+		t.Fatalf("EncodeCursor() unexpected error = %v", err)
+	}
+	if token == "" {
+		t.Fatal("EncodeCursor() returned empty token")
+	}
+
+	decoded, err := DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("DecodeCursor() unexpected error = %v", err)
+	}
+	if decoded != original {
+		t.Errorf("DecodeCursor() = %+v, want %+v", decoded, original)
+	}
+}
+
+func TestDecodeCursorRejectsTampering(t *testing.T) {
+	token, err := EncodeCursor(Cursor{LastSortKey: "a", LastUUID: "b", FilterHash: "c"})
+	if err != nil {
+		t.Fatalf("EncodeCursor() unexpected error = %v", err)
+	}
+
+	tampered := []rune(token)
+	mid := len(tampered) / 2
+	if tampered[mid] == 'A' {
+		tampered[mid] = 'B'
+	} else {
+		tampered[mid] = 'A'
+	}
+
+	if _, err := DecodeCursor(string(tampered)); err != ErrInvalidCursor {
+		t.Errorf("DecodeCursor() on tampered token error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	if _, err := DecodeCursor("not-a-valid-cursor"); err != ErrInvalidCursor {
+		t.Errorf("DecodeCursor() error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestHashFiltersStableAndDistinct(t *testing.T) {
+	a := HashFilters("kind=agent", "handlePrefix=gpt")
+	b := HashFilters("kind=agent", "handlePrefix=gpt")
+	c := HashFilters("kind=mcp", "handlePrefix=gpt")
+
+	if a != b {
+		t.Errorf("HashFilters() not stable: %q != %q", a, b)
+	}
+	if a == c {
+		t.Errorf("HashFilters() collided for distinct filters: %q == %q", a, c)
+	}
+}
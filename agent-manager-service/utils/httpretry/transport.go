@@ -0,0 +1,208 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package httpretry wraps an http.RoundTripper with Retry-After-aware retry,
+// for upstreams (like the trace_observer client) that rate-limit or shed
+// load with a 429/503 and an honest Retry-After rather than just dropping
+// the connection.
+package httpretry
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Policy configures Transport's backoff schedule and retry budget.
+type Policy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the floor used when a retryable response carries no
+	// Retry-After header.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the floor backoff's growth.
+	MaxBackoff time.Duration
+	// Multiplier scales the floor backoff after each retry.
+	Multiplier float64
+	// Jitter is the fraction (0-1) of the floor backoff to randomize.
+	Jitter float64
+	// MaxElapsed bounds the total time spent retrying a single request when
+	// the request's context has no deadline of its own. If the context does
+	// have a deadline, that takes precedence.
+	MaxElapsed time.Duration
+}
+
+// DefaultPolicy makes up to 4 attempts (the first plus 3 retries), backing
+// off from 200ms to 10s absent a Retry-After header, within a 30s overall
+// budget.
+var DefaultPolicy = Policy{
+	MaxAttempts:    4,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	MaxElapsed:     30 * time.Second,
+}
+
+// Metrics counts retry outcomes across every request a Transport handles.
+type Metrics struct {
+	retried           uint64
+	gaveUp            uint64
+	retryAfterHonored uint64
+}
+
+func (m *Metrics) recordRetried()           { atomic.AddUint64(&m.retried, 1) }
+func (m *Metrics) recordGaveUp()            { atomic.AddUint64(&m.gaveUp, 1) }
+func (m *Metrics) recordRetryAfterHonored() { atomic.AddUint64(&m.retryAfterHonored, 1) }
+
+// Retried returns the total number of retries made across all requests (a
+// request retried twice counts twice).
+func (m *Metrics) Retried() uint64 { return atomic.LoadUint64(&m.retried) }
+
+// GaveUp returns how many requests exhausted their retry budget without
+// succeeding.
+func (m *Metrics) GaveUp() uint64 { return atomic.LoadUint64(&m.gaveUp) }
+
+// RetryAfterHonored returns how many retries had a parseable Retry-After
+// header on the response. Such a retry waits for the larger of that value
+// and the floor backoff.
+func (m *Metrics) RetryAfterHonored() uint64 { return atomic.LoadUint64(&m.retryAfterHonored) }
+
+// Transport retries requests that fail with a 429 or 5xx status, honoring
+// any Retry-After header on the response and otherwise backing off from
+// Policy.InitialBackoff.
+type Transport struct {
+	// Next is the underlying RoundTripper. Defaults to
+	// http.DefaultTransport if nil.
+	Next http.RoundTripper
+	// Policy configures the retry schedule. Defaults to DefaultPolicy if
+	// the zero value.
+	Policy Policy
+	// Metrics accumulates retry outcomes. A Transport with a nil Metrics
+	// still retries correctly; it just can't be observed.
+	Metrics *Metrics
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	policy := t.Policy
+	if policy.MaxAttempts == 0 {
+		policy = DefaultPolicy
+	}
+
+	deadline := time.Now().Add(policy.MaxElapsed)
+	if ctxDeadline, ok := req.Context().Deadline(); ok {
+		deadline = ctxDeadline
+	}
+
+	backoff := policy.InitialBackoff
+	var resp *http.Response
+	var err error
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		resp, err = next.RoundTrip(req)
+
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if attempt == policy.MaxAttempts {
+			break
+		}
+		if err == nil && time.Now().After(deadline) {
+			break
+		}
+
+		var wait time.Duration
+		honored := false
+		if err == nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+				honored = true
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if wait < jitter(backoff, policy.Jitter) {
+			wait = jitter(backoff, policy.Jitter)
+		}
+		if remaining := time.Until(deadline); wait > remaining {
+			wait = remaining
+		}
+		if wait < 0 {
+			break
+		}
+
+		if t.Metrics != nil {
+			t.Metrics.recordRetried()
+			if honored {
+				t.Metrics.recordRetryAfterHonored()
+			}
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+
+	if t.Metrics != nil && (err != nil || isRetryableStatus(resp.StatusCode)) {
+		t.Metrics.recordGaveUp()
+	}
+	return resp, err
+}
+
+// isRetryableStatus reports whether status is worth retrying: 429 (rate
+// limited) or any 5xx (server error).
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// parseRetryAfter decodes a Retry-After header in either of its two forms:
+// a delta in seconds, or an HTTP-date to wait until.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when), true
+	}
+	return 0, false
+}
+
+// jitter randomizes d by up to +/- frac of its value.
+func jitter(d time.Duration, frac float64) time.Duration {
+	if frac <= 0 {
+		return d
+	}
+	delta := float64(d) * frac
+	return d + time.Duration(delta*(2*rand.Float64()-1))
+}
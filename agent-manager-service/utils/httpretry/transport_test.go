@@ -0,0 +1,141 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpretry
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubRoundTripper struct {
+	responses []*http.Response
+	calls     int
+}
+
+func (s *stubRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp := s.responses[s.calls]
+	s.calls++
+	return resp, nil
+}
+
+func newResponse(status int, retryAfter string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return resp
+}
+
+func TestTransportRetriesOn503AndHonorsRetryAfter(t *testing.T) {
+	stub := &stubRoundTripper{responses: []*http.Response{
+		newResponse(http.StatusServiceUnavailable, "0"),
+		newResponse(http.StatusOK, ""),
+	}}
+	metrics := &Metrics{}
+	transport := &Transport{
+		Next: stub,
+		Policy: Policy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+			Multiplier:     2,
+			MaxElapsed:     time.Second,
+		},
+		Metrics: metrics,
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/traces", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() unexpected error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("RoundTrip() status = %d, want 200", resp.StatusCode)
+	}
+	if stub.calls != 2 {
+		t.Errorf("calls = %d, want 2", stub.calls)
+	}
+	if metrics.Retried() != 1 {
+		t.Errorf("Retried() = %d, want 1", metrics.Retried())
+	}
+	if metrics.RetryAfterHonored() != 1 {
+		t.Errorf("RetryAfterHonored() = %d, want 1", metrics.RetryAfterHonored())
+	}
+}
+
+func TestTransportGivesUpAfterMaxAttempts(t *testing.T) {
+	stub := &stubRoundTripper{responses: []*http.Response{
+		newResponse(http.StatusTooManyRequests, ""),
+		newResponse(http.StatusTooManyRequests, ""),
+	}}
+	metrics := &Metrics{}
+	transport := &Transport{
+		Next: stub,
+		Policy: Policy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+			Multiplier:     2,
+			MaxElapsed:     time.Second,
+		},
+		Metrics: metrics,
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/traces", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() unexpected error = %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("RoundTrip() status = %d, want 429", resp.StatusCode)
+	}
+	if metrics.GaveUp() != 1 {
+		t.Errorf("GaveUp() = %d, want 1", metrics.GaveUp())
+	}
+}
+
+func TestParseRetryAfterDeltaSeconds(t *testing.T) {
+	d, ok := parseRetryAfter("5")
+	if !ok || d != 5*time.Second {
+		t.Errorf("parseRetryAfter(\"5\") = (%v, %v), want (5s, true)", d, ok)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	when := time.Now().Add(2 * time.Second).UTC()
+	d, ok := parseRetryAfter(when.Format(http.TimeFormat))
+	if !ok {
+		t.Fatal("parseRetryAfter() with HTTP-date expected ok=true")
+	}
+	if d < time.Second || d > 3*time.Second {
+		t.Errorf("parseRetryAfter() = %v, want ~2s", d)
+	}
+}
+
+func TestParseRetryAfterEmpty(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Error("parseRetryAfter(\"\") expected ok=false")
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package retry
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// CircuitState is the observable state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed means calls pass through normally.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means calls are rejected without being attempted.
+	CircuitOpen
+	// CircuitHalfOpen means a single trial call is allowed through to test
+	// whether the downstream has recovered.
+	CircuitHalfOpen
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Allow/Do when the circuit is
+// open and the cooldown hasn't elapsed yet.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker trips open after FailureThreshold consecutive failures and
+// stays open for Cooldown before allowing a single half-open trial call.
+// Call sites should wrap Do around the same operation they pass to retry.Do.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures that trips the
+	// circuit open.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a trial call is
+	// allowed through.
+	Cooldown time.Duration
+
+	mu            sync.Mutex
+	state         CircuitState
+	failures      int
+	openedAt      time.Time
+	trialInFlight bool
+}
+
+// NewCircuitBreaker returns a closed circuit breaker with the given
+// configuration.
+func NewCircuitBreaker(failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{FailureThreshold: failureThreshold, Cooldown: cooldown}
+}
+
+// State returns the breaker's current state, advancing open -> half-open
+// once Cooldown has elapsed.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.advanceLocked()
+	return cb.state
+}
+
+// advanceLocked transitions an open circuit to half-open once Cooldown has
+// elapsed. Callers must hold cb.mu.
+func (cb *CircuitBreaker) advanceLocked() {
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.Cooldown {
+		cb.state = CircuitHalfOpen
+	}
+}
+
+// Do runs fn if the circuit allows it, recording the outcome. It returns
+// ErrCircuitOpen without calling fn when the circuit is open and still
+// cooling down, and allows exactly one concurrent trial call through while
+// half-open.
+func (cb *CircuitBreaker) Do(fn func() error) error {
+	if err := cb.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	cb.recordResult(err == nil)
+	return err
+}
+
+func (cb *CircuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.advanceLocked()
+
+	switch cb.state {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.trialInFlight {
+			return ErrCircuitOpen
+		}
+		cb.trialInFlight = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// remainingCooldown returns how much longer an open circuit will reject
+// calls, or 0 if it isn't currently open.
+func (cb *CircuitBreaker) remainingCooldown() time.Duration {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.advanceLocked()
+
+	if cb.state != CircuitOpen {
+		return 0
+	}
+	remaining := cb.Cooldown - time.Since(cb.openedAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func (cb *CircuitBreaker) recordResult(success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.trialInFlight = false
+
+	if success {
+		cb.failures = 0
+		cb.state = CircuitClosed
+		return
+	}
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.FailureThreshold {
+		cb.state = CircuitOpen
+		cb.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerTripsAfterThreshold(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+	failing := func() error { return errors.New("boom") }
+
+	cb.Do(failing)
+	if cb.State() != CircuitClosed {
+		t.Fatalf("State() after 1 failure = %v, want CircuitClosed", cb.State())
+	}
+
+	cb.Do(failing)
+	if cb.State() != CircuitOpen {
+		t.Fatalf("State() after 2 failures = %v, want CircuitOpen", cb.State())
+	}
+
+	if err := cb.Do(func() error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Do() while open = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreakerHalfOpenRecovery(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Millisecond)
+	cb.Do(func() error { return errors.New("boom") })
+	if cb.State() != CircuitOpen {
+		t.Fatalf("State() = %v, want CircuitOpen", cb.State())
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if cb.State() != CircuitHalfOpen {
+		t.Fatalf("State() after cooldown = %v, want CircuitHalfOpen", cb.State())
+	}
+
+	if err := cb.Do(func() error { return nil }); err != nil {
+		t.Fatalf("Do() trial call unexpected error = %v", err)
+	}
+	if cb.State() != CircuitClosed {
+		t.Errorf("State() after successful trial = %v, want CircuitClosed", cb.State())
+	}
+}
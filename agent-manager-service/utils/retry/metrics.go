@@ -0,0 +1,44 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package retry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// repoRetriesTotal counts every DoWithBreaker call by its outcome:
+	// succeeded (no retry needed), retried (succeeded after >1 attempt),
+	// exhausted (ran out of retries), or circuit_open (rejected before fn
+	// ever ran).
+	repoRetriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "agent_manager_repo_retries_total",
+		Help: "Repository calls made through retry.DoWithBreaker, by operation and outcome (succeeded, retried, exhausted, circuit_open).",
+	}, []string{"op", "outcome"})
+
+	// circuitStateGauge mirrors each op's CircuitBreaker.State(): 0=closed,
+	// 1=open, 2=half-open.
+	circuitStateGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "agent_manager_circuit_state",
+		Help: "Current CircuitBreaker state per operation (0=closed, 1=open, 2=half-open).",
+	}, []string{"op"})
+)
+
+func recordCircuitStateMetric(op string, cb *CircuitBreaker) {
+	circuitStateGauge.WithLabelValues(op).Set(float64(cb.State()))
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package retry provides a shared retry-with-backoff and circuit-breaker
+// helper for repository call sites, so transient errors (lock contention,
+// dropped connections) get retried uniformly instead of every service
+// reimplementing its own loop.
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math/rand"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RetryPolicy configures Do's backoff schedule.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps how large the delay between attempts can grow.
+	MaxBackoff time.Duration
+	// Multiplier scales the backoff after each failed attempt.
+	Multiplier float64
+	// Jitter is the fraction (0-1) of the computed backoff to randomize,
+	// so many callers retrying at once don't all wake up in lockstep.
+	Jitter float64
+}
+
+// DefaultPolicy is a reasonable starting point for a repository call: retry
+// up to 3 times total, backing off from 50ms to at most 1s.
+var DefaultPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 50 * time.Millisecond,
+	MaxBackoff:     1 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// IsRetryable decides whether an error from a wrapped call is worth retrying.
+type IsRetryable func(error) bool
+
+// sqlStater is satisfied by driver-specific Postgres error types (e.g.
+// *pgconn.PgError) without this package depending on any particular driver.
+type sqlStater interface {
+	SQLState() string
+}
+
+// retryablePostgresSQLStates are SQLSTATE codes for conditions that are
+// expected to clear on their own: serialization failure and deadlock.
+var retryablePostgresSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+}
+
+// DefaultIsRetryable retries connection-level failures and the transient
+// Postgres error classes above, but not gorm.ErrRecordNotFound or validation
+// errors, which retrying can never fix.
+func DefaultIsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	if errors.Is(err, gorm.ErrInvalidTransaction) {
+		return true
+	}
+
+	var state sqlStater
+	if errors.As(err, &state) && retryablePostgresSQLStates[state.SQLState()] {
+		return true
+	}
+
+	return false
+}
+
+// Do invokes fn, retrying according to policy while isRetryable(err) holds
+// and ctx hasn't been cancelled, and returns the last error otherwise.
+func Do(ctx context.Context, policy RetryPolicy, isRetryable IsRetryable, fn func() error) error {
+	backoff := policy.InitialBackoff
+	var err error
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		if attempt == policy.MaxAttempts || !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(jitter(backoff, policy.Jitter)):
+		}
+
+		backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+
+	return err
+}
+
+// DoWithBreaker wraps Do with cb's circuit breaker and records both as the
+// agent_manager_repo_retries_total{op,outcome} and
+// agent_manager_circuit_state{op} metrics, so operators can tell a
+// transient blip apart from a call site whose breaker has actually tripped.
+// op is purely a metric label; callers own cb and reuse the same instance
+// across calls so consecutive failures accumulate across invocations, the
+// same way the traces-observer OpenSearch client's breaker does.
+//
+// cb is consulted before Do ever runs fn: if the breaker is open,
+// DoWithBreaker returns ErrCircuitOpen without calling fn at all.
+func DoWithBreaker(ctx context.Context, op string, cb *CircuitBreaker, policy RetryPolicy, isRetryable IsRetryable, fn func() error) error {
+	if err := cb.allow(); err != nil {
+		repoRetriesTotal.WithLabelValues(op, "circuit_open").Inc()
+		recordCircuitStateMetric(op, cb)
+		return err
+	}
+
+	attempts := 0
+	err := Do(ctx, policy, isRetryable, func() error {
+		attempts++
+		return fn()
+	})
+
+	cb.recordResult(err == nil)
+	recordCircuitStateMetric(op, cb)
+
+	outcome := "succeeded"
+	switch {
+	case err != nil:
+		outcome = "exhausted"
+	case attempts > 1:
+		outcome = "retried"
+	}
+	repoRetriesTotal.WithLabelValues(op, outcome).Inc()
+	return err
+}
+
+// jitter randomizes d by up to +/- frac of its value.
+func jitter(d time.Duration, frac float64) time.Duration {
+	if frac <= 0 {
+		return d
+	}
+	delta := float64(d) * frac
+	return d + time.Duration(delta*(2*rand.Float64()-1))
+}
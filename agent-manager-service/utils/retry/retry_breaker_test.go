@@ -0,0 +1,76 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDoWithBreakerOpensAfterThreshold(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+	policy := RetryPolicy{MaxAttempts: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 2}
+	failing := func() error { return errors.New("boom") }
+
+	for i := 0; i < 2; i++ {
+		if err := DoWithBreaker(context.Background(), "test.op", cb, policy, func(error) bool { return false }, failing); err == nil {
+			t.Fatalf("DoWithBreaker() call %d = nil error, want failure", i)
+		}
+	}
+
+	err := DoWithBreaker(context.Background(), "test.op", cb, policy, func(error) bool { return false }, func() error {
+		t.Fatal("fn should not run once the circuit is open")
+		return nil
+	})
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("DoWithBreaker() error = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestRetryAfterScalesPerTenant(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Minute)
+	cb.Do(func() error { return errors.New("boom") })
+
+	const op = "test.retry_after"
+	first, ok := RetryAfter(op, cb, "org-a")
+	if !ok {
+		t.Fatal("RetryAfter() ok = false, want true for an open circuit")
+	}
+
+	second, ok := RetryAfter(op, cb, "org-a")
+	if !ok {
+		t.Fatal("RetryAfter() ok = false on second call, want true")
+	}
+	if second <= first {
+		t.Errorf("RetryAfter() second = %v, want greater than first = %v for a repeat-offending tenant", second, first)
+	}
+
+	clearTenantBackoff(op, "org-b")
+	// The remaining cooldown shrinks as time passes, so the un-scaled value
+	// is bracketed by readings taken just before and just after the call.
+	before := cb.remainingCooldown()
+	other, ok := RetryAfter(op, cb, "org-b")
+	after := cb.remainingCooldown()
+	if !ok {
+		t.Fatal("RetryAfter() ok = false for a fresh tenant, want true")
+	}
+	if other > before || other < after {
+		t.Errorf("RetryAfter() for a fresh tenant = %v, want the un-scaled cooldown (between %v and %v)", other, after, before)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	attempts := 0
+	err := Do(context.Background(), RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Multiplier: 2}, func(error) bool { return true }, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestDoStopsOnNonRetryable(t *testing.T) {
+	attempts := 0
+	err := Do(context.Background(), RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Multiplier: 2}, func(err error) bool { return !errors.Is(err, errNotFound) }, func() error {
+		attempts++
+		return errNotFound
+	})
+	if !errors.Is(err, errNotFound) {
+		t.Fatalf("Do() error = %v, want errNotFound", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (no retry on non-retryable error)", attempts)
+	}
+}
+
+func TestDoRespectsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := Do(ctx, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Second, Multiplier: 2}, func(error) bool { return true }, func() error {
+		attempts++
+		return errors.New("transient")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Do() error = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestDefaultIsRetryable(t *testing.T) {
+	if DefaultIsRetryable(nil) {
+		t.Error("DefaultIsRetryable(nil) = true, want false")
+	}
+	if !DefaultIsRetryable(context.DeadlineExceeded) {
+		t.Error("DefaultIsRetryable(context.DeadlineExceeded) = false, want true")
+	}
+	if DefaultIsRetryable(errNotFound) {
+		t.Error("DefaultIsRetryable(errNotFound) = true, want false")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// maxTenantBackoffEntries bounds tenantBackoffEntries. op is always one of a
+// small, fixed set of call-site names, but tenant is caller-supplied org
+// identity, so this map (unlike op's circuit breakers) needs an explicit
+// cap: once full, a sweep reclaims idle entries before a new one is added,
+// and a tenant that still doesn't free up a slot gets the un-scaled
+// cooldown rather than growing the map further.
+const maxTenantBackoffEntries = 4096
+
+// tenantBackoffIdleTTL is how long a (op, tenant) streak survives without a
+// new circuit-open encounter before it's swept and the next one starts back
+// at the un-scaled cooldown.
+const tenantBackoffIdleTTL = 10 * time.Minute
+
+// maxTenantBackoffShift caps the exponential scaling at 2^6 = 64x the
+// breaker's base cooldown.
+const maxTenantBackoffShift = 6
+
+type tenantBackoffEntry struct {
+	streak    int
+	updatedAt time.Time
+}
+
+var (
+	tenantBackoffMu      sync.Mutex
+	tenantBackoffEntries = map[string]*tenantBackoffEntry{}
+)
+
+// RetryAfter returns how long a caller acting on behalf of tenant should
+// wait before retrying op, exponentially scaling op's circuit breaker's
+// remaining cooldown by how many consecutive times in a row tenant has hit
+// it open. This lets one noisy org back off harder than a blip that's
+// affecting every caller of op. ok is false when cb isn't currently open,
+// in which case there's nothing to back off from.
+func RetryAfter(op string, cb *CircuitBreaker, tenant string) (wait time.Duration, ok bool) {
+	base := cb.remainingCooldown()
+	if base <= 0 {
+		clearTenantBackoff(op, tenant)
+		return 0, false
+	}
+	return nextTenantBackoff(op, tenant, base), true
+}
+
+func nextTenantBackoff(op, tenant string, base time.Duration) time.Duration {
+	key := op + "\x00" + tenant
+	now := time.Now()
+
+	tenantBackoffMu.Lock()
+	defer tenantBackoffMu.Unlock()
+
+	if entry, ok := tenantBackoffEntries[key]; ok && now.Sub(entry.updatedAt) <= tenantBackoffIdleTTL {
+		entry.streak++
+		entry.updatedAt = now
+		return scaleBackoff(base, entry.streak)
+	}
+
+	if len(tenantBackoffEntries) >= maxTenantBackoffEntries {
+		evictStaleTenantBackoffsLocked(now)
+	}
+	if len(tenantBackoffEntries) >= maxTenantBackoffEntries {
+		return base
+	}
+
+	tenantBackoffEntries[key] = &tenantBackoffEntry{streak: 1, updatedAt: now}
+	return base
+}
+
+func clearTenantBackoff(op, tenant string) {
+	key := op + "\x00" + tenant
+	tenantBackoffMu.Lock()
+	defer tenantBackoffMu.Unlock()
+	delete(tenantBackoffEntries, key)
+}
+
+func evictStaleTenantBackoffsLocked(now time.Time) {
+	for key, entry := range tenantBackoffEntries {
+		if now.Sub(entry.updatedAt) > tenantBackoffIdleTTL {
+			delete(tenantBackoffEntries, key)
+		}
+	}
+}
+
+// scaleBackoff doubles base per consecutive streak, capped at
+// maxTenantBackoffShift so a long-failing tenant doesn't end up waiting
+// hours for one retry.
+func scaleBackoff(base time.Duration, streak int) time.Duration {
+	shift := streak - 1
+	if shift > maxTenantBackoffShift {
+		shift = maxTenantBackoffShift
+	}
+	return base * time.Duration(int64(1)<<uint(shift))
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// WriteRetryAfterResponse responds 503 with a Retry-After header set to
+// wait rounded up to whole seconds (so a sub-second wait never collapses to
+// "0", which a client would read as "retry immediately"), alongside the
+// usual error body.
+func WriteRetryAfterResponse(w http.ResponseWriter, wait time.Duration, message string) {
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	WriteErrorResponse(w, http.StatusServiceUnavailable, message)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schedule
+
+import "time"
+
+// CatchUpPolicy decides what ResolveNextRunTime does when the worker was
+// offline past one or more fire times.
+type CatchUpPolicy int
+
+const (
+	// CatchUpSkip jumps straight to the next fire time after now, silently
+	// dropping any fire times that were missed while the worker was down.
+	CatchUpSkip CatchUpPolicy = iota
+	// CatchUpRunOnce runs once immediately (at now) to catch up on the
+	// missed window, then resumes the normal schedule from there.
+	CatchUpRunOnce
+)
+
+// ResolveNextRunTime computes the next run time for a monitor whose schedule
+// last fired at lastRun (zero if it has never run), applying policy when
+// s.Next(lastRun) has already passed.
+func ResolveNextRunTime(s Schedule, lastRun, now time.Time, policy CatchUpPolicy) time.Time {
+	next := s.Next(lastRun)
+	if next.After(now) {
+		return next
+	}
+
+	switch policy {
+	case CatchUpRunOnce:
+		return now
+	default: // CatchUpSkip
+		for !next.After(now) {
+			next = s.Next(next)
+		}
+		return next
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package schedule parses cron-style schedule expressions and computes the
+// next fire time, honoring a given IANA timezone rather than always
+// scheduling in UTC. models.Monitor has no schedule field, and nothing
+// outside this package calls Parse or ResolveNextRunTime.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLookahead bounds how far into the future Next will scan before giving
+// up, so a schedule that can never match (e.g. "31 2 *" in a 30-day month
+// combined with an impossible day-of-week) fails fast instead of hanging.
+const maxLookahead = 4 * 366 * 24 * time.Hour
+
+// Schedule computes successive fire times after a given instant.
+type Schedule interface {
+	// Next returns the first fire time strictly after after.
+	Next(after time.Time) time.Time
+}
+
+// Parse parses a 5-field standard cron expression ("min hour dom month dow")
+// or an "@every <duration>" shorthand, returning a Schedule that fires in loc.
+func Parse(expr string, loc *time.Location) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if strings.HasPrefix(expr, "@every ") {
+		d, err := time.ParseDuration(strings.TrimPrefix(expr, "@every "))
+		if err != nil {
+			return nil, fmt.Errorf("invalid @every duration in %q: %w", expr, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid @every duration in %q: must be positive", expr)
+		}
+		return &intervalSchedule{interval: d}, nil
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields (min hour dom month dow), got %d", expr, len(fields))
+	}
+
+	minute, err := parseField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	hour, err := parseField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	dom, err := parseField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	month, err := parseField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	dow, err := parseField(fields[4], 0, 6)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+
+	return &cronSchedule{
+		minute:        minute,
+		hour:          hour,
+		dom:           dom,
+		month:         month,
+		dow:           dow,
+		domRestricted: fields[2] != "*",
+		dowRestricted: fields[4] != "*",
+		loc:           loc,
+	}, nil
+}
+
+// intervalSchedule implements the "@every" shorthand.
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+func (s *intervalSchedule) Next(after time.Time) time.Time {
+	return after.Add(s.interval)
+}
+
+// cronSchedule implements a standard 5-field cron expression.
+type cronSchedule struct {
+	minute, hour, dom, month, dow [64]bool
+	// domRestricted/dowRestricted record whether day-of-month/day-of-week
+	// was anything other than "*", so Next knows when to OR rather than AND
+	// them together (see dayMatches).
+	domRestricted, dowRestricted bool
+	loc                          *time.Location
+}
+
+// dayMatches reports whether t's day-of-month and day-of-week satisfy the
+// schedule. Standard cron ANDs the two fields, except when both are
+// restricted (neither is "*"), in which case it ORs them - e.g. "0 0 13 * 5"
+// fires on the 13th of every month OR any Friday, not only Friday the 13th.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom[t.Day()]
+	dowMatch := s.dow[int(t.Weekday())]
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// Next scans forward one minute at a time (dropping any seconds/nanoseconds)
+// until all fields match, reading each candidate's fields in loc. Candidates
+// advance by absolute minutes, so a wall-clock time that a DST transition
+// skips never matches, and one that a transition repeats matches both times.
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	deadline := after.Add(maxLookahead)
+
+	for t.Before(deadline) {
+		if s.month[int(t.Month())] && s.dayMatches(t) && s.hour[t.Hour()] && s.minute[t.Minute()] {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
+// parseField parses one cron field: "*", a single value, a range "a-b", a
+// step "*/n" or "a-b/n", or a comma-separated list of any of those.
+func parseField(field string, min, max int) ([64]bool, error) {
+	var bitmap [64]bool
+	for _, part := range strings.Split(field, ",") {
+		if err := parsePart(part, min, max, &bitmap); err != nil {
+			return bitmap, err
+		}
+	}
+	return bitmap, nil
+}
+
+func parsePart(part string, min, max int, bitmap *[64]bool) error {
+	step := 1
+	rangePart := part
+	if idx := strings.Index(part, "/"); idx != -1 {
+		rangePart = part[:idx]
+		s, err := strconv.Atoi(part[idx+1:])
+		if err != nil || s <= 0 {
+			return fmt.Errorf("invalid step in %q", part)
+		}
+		step = s
+	}
+
+	lo, hi := min, max
+	switch {
+	case rangePart == "*":
+		// lo/hi already cover the full range
+	case strings.Contains(rangePart, "-"):
+		bounds := strings.SplitN(rangePart, "-", 2)
+		l, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return fmt.Errorf("invalid range start in %q", part)
+		}
+		h, err := strconv.Atoi(bounds[1])
+		if err != nil {
+			return fmt.Errorf("invalid range end in %q", part)
+		}
+		lo, hi = l, h
+	default:
+		v, err := strconv.Atoi(rangePart)
+		if err != nil {
+			return fmt.Errorf("invalid value %q", part)
+		}
+		lo, hi = v, v
+	}
+
+	if lo < min || hi > max || lo > hi {
+		return fmt.Errorf("value %q out of range [%d, %d]", part, min, max)
+	}
+
+	for v := lo; v <= hi; v += step {
+		bitmap[v] = true
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEvery(t *testing.T) {
+	s, err := Parse("@every 5m", time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	after := time.Date(2026, 7, 26, 10, 0, 0, 0, time.UTC)
+	got := s.Next(after)
+	want := after.Add(5 * time.Minute)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCronEveryHourAtMinuteZero(t *testing.T) {
+	s, err := Parse("0 * * * *", time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	after := time.Date(2026, 7, 26, 10, 15, 0, 0, time.UTC)
+	got := s.Next(after)
+	want := time.Date(2026, 7, 26, 11, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCronWeekdaysAtNine(t *testing.T) {
+	s, err := Parse("0 9 * * 1-5", time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	// Saturday 2026-07-25 -> expect Monday 2026-07-27 at 09:00
+	after := time.Date(2026, 7, 25, 10, 0, 0, 0, time.UTC)
+	got := s.Next(after)
+	want := time.Date(2026, 7, 27, 9, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCronDOMOrDOWWhenBothRestricted(t *testing.T) {
+	// "0 0 13 * 5": should fire on the 13th of any month OR any Friday, not
+	// only Friday the 13th.
+	s, err := Parse("0 0 13 * 5", time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	// Saturday 2026-07-11 -> expect Monday 2026-07-13 (the 13th, not a Friday)
+	after := time.Date(2026, 7, 11, 0, 0, 0, 0, time.UTC)
+	got := s.Next(after)
+	want := time.Date(2026, 7, 13, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+
+	// From the 14th, the next match is the following Friday, 2026-07-17.
+	after = time.Date(2026, 7, 14, 0, 0, 0, 0, time.UTC)
+	got = s.Next(after)
+	want = time.Date(2026, 7, 17, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCronAcrossDSTSpringForward(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	// DST starts 2026-03-08 02:00 -> 03:00 in America/New_York.
+	s, err := Parse("30 2 * * *", loc)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	after := time.Date(2026, 3, 7, 12, 0, 0, 0, loc)
+	got := s.Next(after)
+	// 02:30 doesn't exist on 2026-03-08 in this zone; the next real match is
+	// the following day.
+	want := time.Date(2026, 3, 9, 2, 30, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse("not a schedule", time.UTC); err == nil {
+		t.Error("Parse() expected error for malformed expression, got nil")
+	}
+	if _, err := Parse("60 * * * *", time.UTC); err == nil {
+		t.Error("Parse() expected error for out-of-range minute, got nil")
+	}
+}
+
+func TestResolveNextRunTimeSkip(t *testing.T) {
+	s, _ := Parse("@every 1h", time.UTC)
+	lastRun := time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC)
+	now := lastRun.Add(5 * time.Hour)
+
+	got := ResolveNextRunTime(s, lastRun, now, CatchUpSkip)
+	want := now.Add(time.Hour)
+	if !got.Equal(want) {
+		t.Errorf("ResolveNextRunTime() = %v, want %v", got, want)
+	}
+}
+
+func TestResolveNextRunTimeRunOnce(t *testing.T) {
+	s, _ := Parse("@every 1h", time.UTC)
+	lastRun := time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC)
+	now := lastRun.Add(5 * time.Hour)
+
+	got := ResolveNextRunTime(s, lastRun, now, CatchUpRunOnce)
+	if !got.Equal(now) {
+		t.Errorf("ResolveNextRunTime() = %v, want %v (catch-up run now)", got, now)
+	}
+}
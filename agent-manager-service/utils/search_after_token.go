@@ -0,0 +1,64 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SearchAfterToken is the opaque page-token payload for a point-in-time +
+// search_after/composite-agg pagination scheme: it carries the open PIT's ID
+// alongside the last page's sort/after key, so the next call can resume
+// without re-scanning from offset 0.
+type SearchAfterToken struct {
+	PitID     string        `json:"pitId"`
+	AfterKey  []interface{} `json:"afterKey"`
+	ExpiresAt time.Time     `json:"expiresAt"`
+}
+
+// EncodeSearchAfterToken base64-encodes t for use as a PageToken/NextPageToken
+// value. Unlike utils.Cursor, this isn't HMAC-signed: the PIT ID is itself an
+// opaque, server-issued handle that OpenSearch rejects once expired, so
+// tampering can't widen what a caller can see.
+func EncodeSearchAfterToken(t SearchAfterToken) (string, error) {
+	raw, err := json.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal search-after token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeSearchAfterToken reverses EncodeSearchAfterToken, additionally
+// rejecting tokens whose PIT has already expired so callers fail fast
+// instead of issuing a doomed request to OpenSearch.
+func DecodeSearchAfterToken(token string) (SearchAfterToken, error) {
+	var t SearchAfterToken
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return t, fmt.Errorf("invalid search-after token: %w", err)
+	}
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return t, fmt.Errorf("invalid search-after token: %w", err)
+	}
+	if !t.ExpiresAt.IsZero() && time.Now().After(t.ExpiresAt) {
+		return t, fmt.Errorf("search-after token expired at %s", t.ExpiresAt)
+	}
+	return t, nil
+}
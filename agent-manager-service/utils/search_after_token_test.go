@@ -0,0 +1,59 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchAfterTokenRoundTrip(t *testing.T) {
+	want := SearchAfterToken{
+		PitID:     "pit-123",
+		AfterKey:  []interface{}{"2026-07-26T10:00:00Z", "trace-abc"},
+		ExpiresAt: time.Now().Add(time.Minute).UTC().Truncate(time.Second),
+	}
+
+	token, err := EncodeSearchAfterToken(want)
+	if err != nil {
+		t.Fatalf("EncodeSearchAfterToken() unexpected error = %v", err)
+	}
+
+	got, err := DecodeSearchAfterToken(token)
+	if err != nil {
+		t.Fatalf("DecodeSearchAfterToken() unexpected error = %v", err)
+	}
+	if got.PitID != want.PitID || !got.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Errorf("DecodeSearchAfterToken() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSearchAfterTokenRejectsExpired(t *testing.T) {
+	token, err := EncodeSearchAfterToken(SearchAfterToken{PitID: "pit-123", ExpiresAt: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("EncodeSearchAfterToken() unexpected error = %v", err)
+	}
+	if _, err := DecodeSearchAfterToken(token); err == nil {
+		t.Error("DecodeSearchAfterToken() on expired token expected error, got nil")
+	}
+}
+
+func TestSearchAfterTokenRejectsGarbage(t *testing.T) {
+	if _, err := DecodeSearchAfterToken("not-a-valid-token"); err == nil {
+		t.Error("DecodeSearchAfterToken() on garbage expected error, got nil")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// MinStep is the smallest explicit step a range query will accept;
+	// anything finer risks turning a wide window into millions of points.
+	MinStep = 15 * time.Second
+
+	// MaxStepPoints bounds duration/step so a client can't request a step so
+	// small the response would blow past a sane point count, mirroring
+	// Prometheus's own 11000-point range-query cap.
+	MaxStepPoints = 11000
+)
+
+// ParseStep parses an explicit `step` query parameter, accepting either a Go
+// duration string ("30s", "5m") or a bare number of seconds ("30"), the same
+// dual format Prometheus's range-query API accepts.
+func ParseStep(raw string) (time.Duration, error) {
+	if d, err := time.ParseDuration(raw); err == nil {
+		return d, nil
+	}
+	if seconds, err := strconv.ParseFloat(raw, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return 0, fmt.Errorf("invalid step %q: must be a duration (e.g. \"30s\") or a number of seconds", raw)
+}
+
+// ValidateStep checks an explicit step against MinStep and MaxStepPoints for
+// the given query duration, returning a client-facing error describing which
+// bound was violated.
+func ValidateStep(step, duration time.Duration) error {
+	if step < MinStep {
+		return fmt.Errorf("step %s is below the minimum of %s", step, MinStep)
+	}
+	if points := duration / step; points > MaxStepPoints {
+		return fmt.Errorf("step %s over a %s range would return %d points, exceeding the limit of %d", step, duration, points, MaxStepPoints)
+	}
+	return nil
+}
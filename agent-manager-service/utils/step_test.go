@@ -0,0 +1,57 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStep(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30s", 30 * time.Second, false},
+		{"5m", 5 * time.Minute, false},
+		{"30", 30 * time.Second, false},
+		{"not-a-step", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseStep(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseStep(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseStep(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestValidateStep(t *testing.T) {
+	if err := ValidateStep(5*time.Second, time.Hour); err == nil {
+		t.Error("ValidateStep() below MinStep expected error, got nil")
+	}
+	if err := ValidateStep(MinStep, 30*24*time.Hour); err == nil {
+		t.Error("ValidateStep() exceeding MaxStepPoints expected error, got nil")
+	}
+	if err := ValidateStep(time.Minute, time.Hour); err != nil {
+		t.Errorf("ValidateStep() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+)
+
+// crockfordAlphabet is the Base32 alphabet ULIDs are encoded with: it omits
+// I, L, O and U to avoid visual confusion with 1 and 0.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var (
+	ulidMu     sync.Mutex
+	ulidLastMs uint64
+	ulidLastRo [10]byte
+)
+
+// NewULID returns a 26-character Crockford base32-encoded ULID: a 48-bit
+// millisecond timestamp followed by 80 bits of randomness. Successive calls
+// within the same millisecond increment the random part rather than
+// re-rolling it, so IDs minted in a tight loop still sort in call order.
+//
+// This is used as the idempotency key for gateway webhook deliveries
+// (X-AgentMgr-Delivery), where sortable-by-creation-time uniqueness matters
+// more than cryptographic unpredictability.
+func NewULID() string {
+	ulidMu.Lock()
+	defer ulidMu.Unlock()
+
+	ms := uint64(time.Now().UnixMilli())
+	if ms == ulidLastMs {
+		ulidLastRo = incrementULIDRandom(ulidLastRo)
+	} else {
+		ulidLastMs = ms
+		if _, err := rand.Read(ulidLastRo[:]); err != nil {
+			// crypto/rand failing is effectively unrecoverable for this
+			// process; fall back to an all-zero random part rather than
+			// panicking a request-handling goroutine.
+			ulidLastRo = [10]byte{}
+		}
+	}
+
+	var id [16]byte
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+	copy(id[6:], ulidLastRo[:])
+
+	return encodeULID(id)
+}
+
+// incrementULIDRandom adds 1 to r, treated as a big-endian integer, so two
+// ULIDs minted in the same millisecond still order correctly.
+func incrementULIDRandom(r [10]byte) [10]byte {
+	for i := len(r) - 1; i >= 0; i-- {
+		r[i]++
+		if r[i] != 0 {
+			break
+		}
+	}
+	return r
+}
+
+// encodeULID renders the 128-bit id as the 26-character Crockford base32
+// string ULIDs use.
+func encodeULID(id [16]byte) string {
+	var dst [26]byte
+	dst[0] = crockfordAlphabet[(id[0]&224)>>5]
+	dst[1] = crockfordAlphabet[id[0]&31]
+	dst[2] = crockfordAlphabet[(id[1]&248)>>3]
+	dst[3] = crockfordAlphabet[((id[1]&7)<<2)|((id[2]&192)>>6)]
+	dst[4] = crockfordAlphabet[(id[2]&62)>>1]
+	dst[5] = crockfordAlphabet[((id[2]&1)<<4)|((id[3]&240)>>4)]
+	dst[6] = crockfordAlphabet[((id[3]&15)<<1)|((id[4]&128)>>7)]
+	dst[7] = crockfordAlphabet[(id[4]&124)>>2]
+	dst[8] = crockfordAlphabet[((id[4]&3)<<3)|((id[5]&224)>>5)]
+	dst[9] = crockfordAlphabet[id[5]&31]
+	dst[10] = crockfordAlphabet[(id[6]&248)>>3]
+	dst[11] = crockfordAlphabet[((id[6]&7)<<2)|((id[7]&192)>>6)]
+	dst[12] = crockfordAlphabet[(id[7]&62)>>1]
+	dst[13] = crockfordAlphabet[((id[7]&1)<<4)|((id[8]&240)>>4)]
+	dst[14] = crockfordAlphabet[((id[8]&15)<<1)|((id[9]&128)>>7)]
+	dst[15] = crockfordAlphabet[(id[9]&124)>>2]
+	dst[16] = crockfordAlphabet[((id[9]&3)<<3)|((id[10]&224)>>5)]
+	dst[17] = crockfordAlphabet[id[10]&31]
+	dst[18] = crockfordAlphabet[(id[11]&248)>>3]
+	dst[19] = crockfordAlphabet[((id[11]&7)<<2)|((id[12]&192)>>6)]
+	dst[20] = crockfordAlphabet[(id[12]&62)>>1]
+	dst[21] = crockfordAlphabet[((id[12]&1)<<4)|((id[13]&240)>>4)]
+	dst[22] = crockfordAlphabet[((id[13]&15)<<1)|((id[14]&128)>>7)]
+	dst[23] = crockfordAlphabet[(id[14]&124)>>2]
+	dst[24] = crockfordAlphabet[((id[14]&3)<<3)|((id[15]&224)>>5)]
+	dst[25] = crockfordAlphabet[id[15]&31]
+	return string(dst[:])
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import "testing"
+
+func TestNewULIDLength(t *testing.T) {
+	id := NewULID()
+	if len(id) != 26 {
+		t.Errorf("len(NewULID()) = %d, want 26", len(id))
+	}
+}
+
+func TestNewULIDMonotonicallyIncreasing(t *testing.T) {
+	prev := NewULID()
+	for i := 0; i < 1000; i++ {
+		id := NewULID()
+		if id <= prev {
+			t.Fatalf("NewULID() not monotonic: %q did not sort after %q", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestIncrementULIDRandomCarries(t *testing.T) {
+	r := [10]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0xFF}
+	got := incrementULIDRandom(r)
+	want := [10]byte{0, 0, 0, 0, 0, 0, 0, 0, 1, 0}
+	if got != want {
+		t.Errorf("incrementULIDRandom(%v) = %v, want %v", r, got, want)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader carries "sha256=<hex-hmac>" of the raw request
+	// body, letting a gateway verify a configuration.changed callback
+	// actually came from agent-manager.
+	WebhookSignatureHeader = "X-AgentMgr-Signature"
+
+	// WebhookDeliveryHeader carries the monotonic ULID identifying this
+	// delivery attempt group, so a gateway that receives the same delivery
+	// twice (e.g. after an operator-triggered redeliver) can dedupe it.
+	WebhookDeliveryHeader = "X-AgentMgr-Delivery"
+
+	// WebhookTimestampHeader carries the Unix-seconds time the delivery was
+	// sent, so a gateway can reject stale replays outside its replay window.
+	WebhookTimestampHeader = "X-AgentMgr-Timestamp"
+)
+
+// SignWebhookPayload returns the X-AgentMgr-Signature header value for body
+// signed with secret: the literal prefix "sha256=" followed by the
+// hex-encoded HMAC-SHA256 of body.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signatureHeader is the correct
+// X-AgentMgr-Signature for body under secret. Comparison is constant-time
+// (via hmac.Equal) so a timing side channel can't leak the secret one byte
+// at a time.
+//
+// This is the reference implementation gateways are expected to port: given
+// the raw request body and the X-AgentMgr-Signature header, it's the whole
+// check needed before trusting a configuration.changed callback.
+func VerifyWebhookSignature(secret string, body []byte, signatureHeader string) bool {
+	expected := SignWebhookPayload(secret, body)
+	return hmac.Equal([]byte(expected), []byte(signatureHeader))
+}
+
+// VerifyWebhookTimestamp reports whether timestampHeader (Unix seconds, the
+// value of X-AgentMgr-Timestamp) is within maxSkew of now, rejecting both
+// replays of old deliveries and clock-skewed requests claiming to be from
+// the future.
+func VerifyWebhookTimestamp(timestampHeader string, now time.Time, maxSkew time.Duration) bool {
+	sec, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return false
+	}
+	delta := now.Sub(time.Unix(sec, 0))
+	if delta < 0 {
+		delta = -delta
+	}
+	return delta <= maxSkew
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhookSignatureRoundTrip(t *testing.T) {
+	body := []byte(`{"orgName":"acme","scope":"organization"}`)
+	sig := SignWebhookPayload("s3cr3t", body)
+
+	if !VerifyWebhookSignature("s3cr3t", body, sig) {
+		t.Error("VerifyWebhookSignature() = false, want true for matching secret/body")
+	}
+	if VerifyWebhookSignature("wrong-secret", body, sig) {
+		t.Error("VerifyWebhookSignature() = true, want false for mismatched secret")
+	}
+	if VerifyWebhookSignature("s3cr3t", []byte("tampered"), sig) {
+		t.Error("VerifyWebhookSignature() = true, want false for tampered body")
+	}
+}
+
+func TestSignWebhookPayloadHasSha256Prefix(t *testing.T) {
+	sig := SignWebhookPayload("secret", []byte("body"))
+	if len(sig) < 7 || sig[:7] != "sha256=" {
+		t.Errorf("SignWebhookPayload() = %q, want sha256= prefix", sig)
+	}
+}
+
+func TestVerifyWebhookTimestampWithinSkew(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	header := strconv.FormatInt(now.Add(-3*time.Second).Unix(), 10)
+
+	if !VerifyWebhookTimestamp(header, now, 5*time.Second) {
+		t.Error("VerifyWebhookTimestamp() = false, want true within skew window")
+	}
+}
+
+func TestVerifyWebhookTimestampOutsideSkew(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	header := strconv.FormatInt(now.Add(-10*time.Second).Unix(), 10)
+
+	if VerifyWebhookTimestamp(header, now, 5*time.Second) {
+		t.Error("VerifyWebhookTimestamp() = true, want false outside skew window")
+	}
+}
+
+func TestVerifyWebhookTimestampInvalidHeader(t *testing.T) {
+	if VerifyWebhookTimestamp("not-a-number", time.Now(), 5*time.Second) {
+		t.Error("VerifyWebhookTimestamp() = true, want false for malformed header")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// CachedZipArtifact is one entry in a ZipArtifactCache: the ZIP bytes last
+// built for a key, the strong ETag over those bytes, and when it was built.
+type CachedZipArtifact struct {
+	ETag    string
+	Data    []byte
+	BuiltAt time.Time
+}
+
+// ZipArtifactCache is a small bounded, TTL-bounded cache of built ZIP
+// artifacts keyed by an arbitrary string (e.g.
+// "llm-provider:<org>:<gateway>:<id>"). It exists so GetLLMProvider and
+// GetLLMProxy don't re-invoke CreateLLMProviderYamlZip/CreateLLMProxyYamlZip
+// on every poll from a gateway that already has the current version.
+//
+// An entry is trusted for ttl before the next request rebuilds it
+// regardless of the client's If-None-Match, bounding how stale a cache hit
+// can be without requiring a cache-invalidation hook into the deployment
+// write path.
+type ZipArtifactCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	order   []string
+	entries map[string]CachedZipArtifact
+}
+
+// NewZipArtifactCache creates a cache that holds up to maxSize entries, each
+// trusted for ttl after being built.
+func NewZipArtifactCache(maxSize int, ttl time.Duration) *ZipArtifactCache {
+	return &ZipArtifactCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[string]CachedZipArtifact),
+	}
+}
+
+// Get returns the cached artifact for key, if present and still within ttl.
+func (c *ZipArtifactCache) Get(key string) (CachedZipArtifact, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.BuiltAt) > c.ttl {
+		return CachedZipArtifact{}, false
+	}
+	return entry, true
+}
+
+// Put stores data under key, evicting the oldest entry if the cache is at
+// capacity, and returns the computed artifact so callers don't have to hash
+// the data themselves.
+func (c *ZipArtifactCache) Put(key string, data []byte) CachedZipArtifact {
+	entry := CachedZipArtifact{
+		ETag:    ComputeZipETag(data),
+		Data:    data,
+		BuiltAt: time.Now(),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists {
+		if c.maxSize > 0 && len(c.order) >= c.maxSize {
+			oldest := c.order[0]
+			c.order = c.order[1:]
+			delete(c.entries, oldest)
+		}
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = entry
+	return entry
+}
+
+// ComputeZipETag computes a strong ETag (quoted, per RFC 7232) over a built
+// ZIP artifact's bytes.
+func ComputeZipETag(data []byte) string {
+	return `"` + SHA256Hex(data) + `"`
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package v1 is the output package for oapi-codegen run over
+// api/openapi/v1/traces.yaml. Running `go generate ./...` from the module
+// root writes traces.gen.go here (request/response models, the
+// std-http-server ServerInterface and its handler wrappers, and the embedded
+// spec); that file is not checked in, and handlers.Handler does not
+// implement ServerInterface.
+package v1
+
+//go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen --config=../../openapi/v1/codegen-config.yaml ../../openapi/v1/traces.yaml
@@ -18,13 +18,23 @@ package controllers
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"sort"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/wso2/ai-agent-management-platform/traces-observer-service/middleware/logger"
 	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch/traceql"
 )
 
 // ErrTraceNotFound is returned when a trace is not found
@@ -41,18 +51,102 @@ const (
 
 // TracingController provides tracing functionality
 type TracingController struct {
-	osClient *opensearch.Client
+	store  SpanStore
+	tracer trace.Tracer
 }
 
-// NewTracingController creates a new tracing service
-func NewTracingController(osClient *opensearch.Client) *TracingController {
+// NewTracingController creates a new tracing service against store (see
+// SpanStore and NewSpanStore for the available backends). tracerProvider
+// is the source of the controller's own OpenTelemetry spans (not the spans
+// it queries from the backend); passing nil uses otel.GetTracerProvider(),
+// the same global-default fallback the otel SDK itself uses, so a service
+// that hasn't bootstrapped an exporter yet (see telemetry.InitTracerProvider)
+// still gets a no-op tracer rather than a nil-pointer panic.
+func NewTracingController(store SpanStore, tracerProvider trace.TracerProvider) *TracingController {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
 	return &TracingController{
-		osClient: osClient,
+		store:  store,
+		tracer: tracerProvider.Tracer("traces-observer-service/controllers"),
+	}
+}
+
+// searchSpan wraps a single OpenSearch call in a child span named name,
+// recording indices.count and any error. fn should call the osClient
+// method and return its error, stashing the result (response, total
+// count, etc.) in a variable captured from the enclosing scope. Only used
+// by the methods gated behind advancedSpanStore, which still talk to
+// *opensearch.Client directly.
+func (s *TracingController) searchSpan(ctx context.Context, name string, indices []string, fn func(context.Context) error) error {
+	ctx, span := s.tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.Int("indices.count", len(indices)),
+	))
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// storeSpan wraps a single SpanStore call in a child span named name,
+// recording any error. Unlike searchSpan, it has no indices.count
+// attribute: index selection is an adapter-internal concern hidden behind
+// SpanStore, not something the controller computes itself.
+func (s *TracingController) storeSpan(ctx context.Context, name string, fn func(context.Context) error) error {
+	ctx, span := s.tracer.Start(ctx, name)
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// requireAdvancedStore returns the *opensearch.Client underlying s.store
+// for methods that haven't been generalized behind SpanStore, or
+// ErrAdvancedQueriesUnsupported if the configured backend doesn't support
+// them (currently: every backend except opensearch).
+func (s *TracingController) requireAdvancedStore() (*opensearch.Client, error) {
+	adv, ok := s.store.(advancedSpanStore)
+	if !ok {
+		return nil, ErrAdvancedQueriesUnsupported
 	}
+	return adv.osClient(), nil
 }
 
-// retrieveAndGroupTraces is a shared helper that fetches spans and groups them into traces
-func (s *TracingController) retrieveAndGroupTraces(ctx context.Context, params opensearch.TraceQueryParams) ([]map[string]interface{}, int, error) {
+// retrieveAndGroupTraces is a shared helper that discovers the traces
+// matching params and groups their spans into per-trace maps.
+//
+// It runs in two phases, the same strategy GetTraceOverviewsV2 established:
+// phase 1 runs a terms aggregation (SpanStore.AggregateTraceBuckets) to pick
+// exactly params.Limit trace IDs (after params.Offset) along with each
+// trace's real doc_count, and phase 2 fetches every span belonging to those
+// trace IDs (SpanStore.FetchSpansByTraceIDs, paginated internally by
+// opensearchSpanStore for the opensearch backend). This replaced a fixed
+// 100x span-fetch multiplier that both over-fetched for small result sets
+// and silently dropped traces whose span count exceeded the multiplier's
+// cap; the returned partialTraces lists any trace ID whose fetched span
+// count differs from its aggregated doc_count, so callers can surface that
+// instead of pretending the trace was retrieved whole.
+func (s *TracingController) retrieveAndGroupTraces(ctx context.Context, params opensearch.TraceQueryParams) (_ []map[string]interface{}, totalCount int, partialTraces []string, err error) {
+	ctx, tracerSpan := s.tracer.Start(ctx, "TracingController.retrieveAndGroupTraces", trace.WithAttributes(
+		attribute.String("component.uid", params.ComponentUid),
+		attribute.String("environment.uid", params.EnvironmentUid),
+	))
+	defer func() {
+		if err != nil {
+			tracerSpan.RecordError(err)
+			tracerSpan.SetStatus(codes.Error, err.Error())
+		}
+		tracerSpan.End()
+	}()
+
 	log := logger.GetLogger(ctx)
 
 	// Set defaults for limit and offset
@@ -63,72 +157,79 @@ func (s *TracingController) retrieveAndGroupTraces(ctx context.Context, params o
 		params.Offset = 0
 	}
 
-	// Store original pagination params before modifying
-	originalLimit := params.Limit
-	originalOffset := params.Offset
-
-	// Fetch spans with multiplier to ensure we get complete traces
-	params.Limit = originalLimit * 100
-	if params.Limit > MaxSpansPerRequest {
-		params.Limit = MaxSpansPerRequest
-	}
-	params.Offset = 0
-
-	log.Debug("Fetching spans for traces",
-		"originalLimit", originalLimit,
-		"originalOffset", originalOffset,
-		"spanFetchLimit", params.Limit)
-
-	// Build query
-	query := opensearch.BuildTraceQuery(params)
-	log.Info("Built query", "query", query)
-
-	// Generate indices based on time range
-	indices, err := opensearch.GetIndicesForTimeRange(params.StartTime, params.EndTime)
-	if err != nil {
-		log.Error("Failed to generate indices for time range",
-			"startTime", params.StartTime,
-			"endTime", params.EndTime,
-			"error", err)
-		return nil, 0, fmt.Errorf("failed to generate indices: %w", err)
-	}
-	log.Debug("Searching indices", "indices", indices, "indexCount", len(indices))
-
-	// Execute search
-	response, err := s.osClient.Search(ctx, indices, query)
+	// Phase 1: aggregate to discover exactly which trace IDs this page
+	// covers, along with their real span counts.
+	var buckets []TraceBucket
+	err = s.storeSpan(ctx, "SpanStore.AggregateTraceBuckets", func(ctx context.Context) error {
+		var searchErr error
+		buckets, totalCount, searchErr = s.store.AggregateTraceBuckets(ctx, params)
+		return searchErr
+	})
 	if err != nil {
-		log.Error("OpenSearch query failed",
-			"indices", indices,
+		log.Error("Span store aggregation failed",
 			"component", params.ComponentUid,
 			"environment", params.EnvironmentUid,
 			"error", err)
-		return nil, 0, fmt.Errorf("failed to search traces: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to search traces: %w", err)
 	}
 
-	// Parse all spans
-	spans := opensearch.ParseSpans(response)
-	log.Debug("Parsed spans from OpenSearch", "spanCount", len(spans))
+	start := params.Offset
+	end := params.Offset + params.Limit
+	if start >= len(buckets) {
+		return []map[string]interface{}{}, totalCount, nil, nil
+	}
+	if end > len(buckets) {
+		end = len(buckets)
+	}
+	paginatedBuckets := buckets[start:end]
 
-	if len(spans) == 0 {
-		log.Warn("No spans found for query",
+	if len(paginatedBuckets) == 0 {
+		log.Warn("No traces found for query",
 			"component", params.ComponentUid,
 			"environment", params.EnvironmentUid,
 			"startTime", params.StartTime,
 			"endTime", params.EndTime)
-		return []map[string]interface{}{}, 0, nil
+		return []map[string]interface{}{}, totalCount, nil, nil
+	}
+
+	traceIDs := make([]string, len(paginatedBuckets))
+	docCountMap := make(map[string]int, len(paginatedBuckets))
+	for i, bucket := range paginatedBuckets {
+		traceIDs[i] = bucket.TraceID
+		docCountMap[bucket.TraceID] = bucket.DocCount
 	}
 
+	// Phase 2: fetch every span belonging to those trace IDs.
+	var spans []opensearch.Span
+	err = s.storeSpan(ctx, "SpanStore.FetchSpansByTraceIDs", func(ctx context.Context) error {
+		var searchErr error
+		spans, searchErr = s.store.FetchSpansByTraceIDs(ctx, traceIDs, params.ComponentUid, params.EnvironmentUid, false)
+		return searchErr
+	})
+	if err != nil {
+		log.Error("Span store query failed",
+			"component", params.ComponentUid,
+			"environment", params.EnvironmentUid,
+			"error", err)
+		return nil, 0, nil, fmt.Errorf("failed to search traces: %w", err)
+	}
+	log.Debug("Fetched spans from span store", "spanCount", len(spans))
+	tracerSpan.SetAttributes(attribute.Int("spans.parsed", len(spans)))
+
 	// Group spans by traceId
-	traceMap := make(map[string][]opensearch.Span)
+	traceMap := make(map[string][]opensearch.Span, len(traceIDs))
 	for _, span := range spans {
 		traceMap[span.TraceID] = append(traceMap[span.TraceID], span)
 	}
 	log.Debug("Grouped spans into traces", "uniqueTraceCount", len(traceMap))
 
-	// Process each trace to extract metadata
-	allTraces := []map[string]interface{}{}
+	// Process each trace to extract metadata, in aggregation order
+	allTraces := make([]map[string]interface{}, 0, len(paginatedBuckets))
 	skippedTraces := 0
-	for traceID, traceSpans := range traceMap {
+	for _, bucket := range paginatedBuckets {
+		traceID := bucket.TraceID
+		traceSpans := traceMap[traceID]
+
 		// Find root span (span with no parentSpanId)
 		var rootSpan *opensearch.Span
 		for i := range traceSpans {
@@ -147,6 +248,10 @@ func (s *TracingController) retrieveAndGroupTraces(ctx context.Context, params o
 			continue
 		}
 
+		if len(traceSpans) != bucket.DocCount {
+			partialTraces = append(partialTraces, traceID)
+		}
+
 		// Extract token usage from GenAI spans
 		tokenUsage := opensearch.ExtractTokenUsage(traceSpans)
 
@@ -194,20 +299,27 @@ func (s *TracingController) retrieveAndGroupTraces(ctx context.Context, params o
 			"endTime":         rootSpan.EndTime.Format(time.RFC3339Nano),
 			"durationInNanos": rootSpan.DurationInNanos,
 			"spanCount":       len(traceSpans),
-			"originalLimit":   originalLimit,
-			"originalOffset":  originalOffset,
 		}
 
 		allTraces = append(allTraces, traceData)
 	}
 
+	tracerSpan.SetAttributes(
+		attribute.Int("traces.skipped", skippedTraces),
+		attribute.Int("traces.partial", len(partialTraces)),
+	)
 	if skippedTraces > 0 {
 		log.Warn("Skipped traces due to missing root spans",
 			"skippedCount", skippedTraces,
-			"totalTraces", len(traceMap))
+			"totalTraces", len(paginatedBuckets))
+	}
+	if len(partialTraces) > 0 {
+		log.Warn("Some traces returned a span count different from their aggregated doc_count",
+			"partialCount", len(partialTraces))
 	}
 
-	// Sort by StartTime (descending) for consistent pagination
+	// Sort by root span StartTime, descending, whatever params.SortOrder is;
+	// SortOrder only decides which traces phase 1 picks for this page.
 	sort.Slice(allTraces, func(i, j int) bool {
 		return allTraces[i]["startTime"].(string) > allTraces[j]["startTime"].(string)
 	})
@@ -216,10 +328,11 @@ func (s *TracingController) retrieveAndGroupTraces(ctx context.Context, params o
 		"uniqueTraces", len(allTraces),
 		"totalSpans", len(spans),
 		"skippedTraces", skippedTraces,
-		"requestedLimit", originalLimit,
-		"requestedOffset", originalOffset)
+		"partialTraces", len(partialTraces),
+		"requestedLimit", params.Limit,
+		"requestedOffset", params.Offset)
 
-	return allTraces, len(spans), nil
+	return allTraces, totalCount, partialTraces, nil
 }
 
 // GetTraceOverviews retrieves unique trace IDs with root span information
@@ -231,9 +344,9 @@ func (s *TracingController) GetTraceOverviews(ctx context.Context, params opense
 		"startTime", params.StartTime,
 		"endTime", params.EndTime)
 
-	// Retrieve and group traces using shared function
-	// Use 100x multiplier to ensure we discover all traces
-	allTraces, totalSpans, err := s.retrieveAndGroupTraces(ctx, params)
+	// Retrieve and group traces using shared function. This already applies
+	// params.Limit/params.Offset in phase 1, so allTraces is exactly this page.
+	allTraces, totalCount, partialTraces, err := s.retrieveAndGroupTraces(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -257,80 +370,57 @@ func (s *TracingController) GetTraceOverviews(ctx context.Context, params opense
 		})
 	}
 
-	// Apply pagination to the trace overviews
-	totalCount := len(allOverviews)
-
-	// Get pagination params from first trace (they're all the same)
-	var originalLimit, originalOffset int
-	if len(allTraces) > 0 {
-		originalLimit = allTraces[0]["originalLimit"].(int)
-		originalOffset = allTraces[0]["originalOffset"].(int)
-	}
-
-	start := originalOffset
-	end := originalOffset + originalLimit
-
-	if start >= len(allOverviews) {
-		start = len(allOverviews)
-	}
-	if end > len(allOverviews) {
-		end = len(allOverviews)
-	}
-
-	paginatedOverviews := allOverviews[start:end]
-
 	log.Info("Retrieved trace overviews",
 		"unique_traces", len(allOverviews),
-		"total_spans", totalSpans,
-		"showing_start", start,
-		"showing_end", end,
+		"partial_traces", len(partialTraces),
 		"total_count", totalCount)
 
 	return &opensearch.TraceOverviewResponse{
-		Traces:     paginatedOverviews,
-		TotalCount: totalCount,
+		Traces:        allOverviews,
+		TotalCount:    totalCount,
+		PartialTraces: partialTraces,
 	}, nil
 }
 
 // GetTraceByIdAndService retrieves spans for a specific trace ID and component UID
-func (s *TracingController) GetTraceByIdAndService(ctx context.Context, params opensearch.TraceByIdAndServiceParams) (*opensearch.TraceResponse, error) {
+func (s *TracingController) GetTraceByIdAndService(ctx context.Context, params opensearch.TraceByIdAndServiceParams) (_ *opensearch.TraceResponse, err error) {
+	ctx, tracerSpan := s.tracer.Start(ctx, "TracingController.GetTraceByIdAndService", trace.WithAttributes(
+		attribute.String("component.uid", params.ComponentUid),
+		attribute.String("environment.uid", params.EnvironmentUid),
+	))
+	defer func() {
+		if err != nil {
+			tracerSpan.RecordError(err)
+			tracerSpan.SetStatus(codes.Error, err.Error())
+		}
+		tracerSpan.End()
+	}()
+
 	log := logger.GetLogger(ctx)
 	log.Info("Getting trace by ID",
 		"traceId", params.TraceID,
 		"component", params.ComponentUid,
 		"environment", params.EnvironmentUid)
 
-	// Build query
-	query := opensearch.BuildTraceByIdAndServiceQuery(params)
-
-	// For trace by ID queries, we need to search across a broader time range
-	// Use current day and previous 7 days as default
-	endTime := time.Now()
-	startTime := endTime.AddDate(0, 0, -7)
-	indices, err := opensearch.GetIndicesForTimeRange(
-		startTime.Format(time.RFC3339),
-		endTime.Format(time.RFC3339),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate indices: %w", err)
-	}
-	log.Debug("Searching indices for trace ID", "indices", indices)
-
-	// Execute search
-	response, err := s.osClient.Search(ctx, indices, query)
+	// Fetch this trace's spans from the configured SpanStore
+	var spans []opensearch.Span
+	err = s.storeSpan(ctx, "SpanStore.FetchSpansByTraceIDs", func(ctx context.Context) error {
+		var searchErr error
+		spans, searchErr = s.store.FetchSpansByTraceIDs(ctx, []string{params.TraceID}, params.ComponentUid, params.EnvironmentUid, false)
+		return searchErr
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to search traces: %w", err)
 	}
-
-	// Parse spans
-	spans := opensearch.ParseSpans(response)
+	tracerSpan.SetAttributes(attribute.Int("spans.parsed", len(spans)))
 
 	if len(spans) == 0 {
 		log.Warn("No spans found for trace",
 			"traceId", params.TraceID,
 			"component", params.ComponentUid,
 			"environment", params.EnvironmentUid)
-		return nil, ErrTraceNotFound
+		err = ErrTraceNotFound
+		return nil, err
 	}
 
 	// Extract token usage from GenAI spans
@@ -354,7 +444,19 @@ func (s *TracingController) GetTraceByIdAndService(ctx context.Context, params o
 }
 
 // ExportTraces retrieves complete trace objects with all spans for export
-func (s *TracingController) ExportTraces(ctx context.Context, params opensearch.TraceQueryParams) (*opensearch.TraceExportResponse, error) {
+func (s *TracingController) ExportTraces(ctx context.Context, params opensearch.TraceQueryParams) (_ *opensearch.TraceExportResponse, err error) {
+	ctx, tracerSpan := s.tracer.Start(ctx, "TracingController.ExportTraces", trace.WithAttributes(
+		attribute.String("component.uid", params.ComponentUid),
+		attribute.String("environment.uid", params.EnvironmentUid),
+	))
+	defer func() {
+		if err != nil {
+			tracerSpan.RecordError(err)
+			tracerSpan.SetStatus(codes.Error, err.Error())
+		}
+		tracerSpan.End()
+	}()
+
 	log := logger.GetLogger(ctx)
 	log.Info("Starting trace export",
 		"component", params.ComponentUid,
@@ -368,7 +470,7 @@ func (s *TracingController) ExportTraces(ctx context.Context, params opensearch.
 	params.Offset = 0
 
 	// Retrieve and group traces using shared function
-	allTraces, totalSpans, err := s.retrieveAndGroupTraces(ctx, params)
+	allTraces, totalCount, partialTraces, err := s.retrieveAndGroupTraces(ctx, params)
 	if err != nil {
 		log.Error("Failed to retrieve traces for export",
 			"component", params.ComponentUid,
@@ -376,6 +478,7 @@ func (s *TracingController) ExportTraces(ctx context.Context, params opensearch.
 			"error", err)
 		return nil, err
 	}
+	tracerSpan.SetAttributes(attribute.Int("traces.total", totalCount))
 
 	if len(allTraces) == 0 {
 		log.Warn("No traces found to export",
@@ -415,16 +518,219 @@ func (s *TracingController) ExportTraces(ctx context.Context, params opensearch.
 
 	log.Info("Successfully completed trace export",
 		"exportedTraces", len(fullTraces),
-		"totalSpans", totalSpans,
+		"partialTraces", len(partialTraces),
 		"component", params.ComponentUid,
 		"environment", params.EnvironmentUid)
 
 	return &opensearch.TraceExportResponse{
-		Traces:     fullTraces, // Return ALL traces, no pagination
-		TotalCount: len(fullTraces),
+		Traces:        fullTraces, // Return ALL traces, no pagination
+		TotalCount:    len(fullTraces),
+		PartialTraces: partialTraces,
 	}, nil
 }
 
+// ndjsonFlushEvery is how many traces StreamExportTraces encodes between
+// flushes, so a client sees the export arrive incrementally without a
+// syscall per trace.
+const ndjsonFlushEvery = 50
+
+// exportPageSpanLimit is how many spans StreamExportTraces fetches per
+// search_after page.
+const exportPageSpanLimit = 5000
+
+// ExportFormat selects how StreamExportTraces serializes each completed
+// trace.
+type ExportFormat string
+
+const (
+	// ExportFormatNDJSON writes one JSON-encoded opensearch.FullTrace per line.
+	ExportFormatNDJSON ExportFormat = "ndjson"
+	// ExportFormatOTLPJSON writes one OTLP/JSON ExportTraceServiceRequest
+	// envelope per trace, reconstructed from the stored span/resource
+	// attributes (see otlp_export.go).
+	ExportFormatOTLPJSON ExportFormat = "otlp-json"
+)
+
+// buildFullTrace assembles one opensearch.FullTrace from every span of a
+// single trace, mirroring the per-trace enrichment retrieveAndGroupTraces
+// does (root span lookup, token usage, status, input/output, baggage
+// task/trial IDs) for callers like StreamExportTraces that group spans
+// incrementally instead of all at once. ok is false when spans has no root
+// span (parentSpanId == ""), in which case the trace can't be assembled.
+func buildFullTrace(traceID string, spans []opensearch.Span) (fullTrace opensearch.FullTrace, ok bool) {
+	var rootSpan *opensearch.Span
+	for i := range spans {
+		if spans[i].ParentSpanID == "" {
+			rootSpan = &spans[i]
+			break
+		}
+	}
+	if rootSpan == nil {
+		return opensearch.FullTrace{}, false
+	}
+
+	var input, output interface{}
+	if opensearch.IsCrewAISpan(rootSpan.Attributes) {
+		input, output = opensearch.ExtractCrewAIRootSpanInputOutput(rootSpan)
+	} else {
+		input, output = opensearch.ExtractRootSpanInputOutput(rootSpan)
+	}
+
+	taskId, _ := rootSpan.Attributes["task.id"].(string)
+	trialId, _ := rootSpan.Attributes["trial.id"].(string)
+
+	sortedSpans := make([]opensearch.Span, len(spans))
+	copy(sortedSpans, spans)
+	sort.Slice(sortedSpans, func(i, j int) bool {
+		return sortedSpans[i].StartTime.Before(sortedSpans[j].StartTime)
+	})
+
+	return opensearch.FullTrace{
+		TraceID:         traceID,
+		RootSpanID:      rootSpan.SpanID,
+		RootSpanName:    rootSpan.Name,
+		RootSpanKind:    string(opensearch.DetermineSpanType(*rootSpan)),
+		StartTime:       rootSpan.StartTime.Format(time.RFC3339Nano),
+		EndTime:         rootSpan.EndTime.Format(time.RFC3339Nano),
+		DurationInNanos: rootSpan.DurationInNanos,
+		SpanCount:       len(spans),
+		TokenUsage:      opensearch.ExtractTokenUsage(spans),
+		Status:          opensearch.ExtractTraceStatus(spans),
+		Input:           input,
+		Output:          output,
+		TaskId:          taskId,
+		TrialId:         trialId,
+		Spans:           sortedSpans,
+	}, true
+}
+
+// StreamExportTraces writes every trace matching params to w in format,
+// paging through OpenSearch with search_after (sorted by traceId then
+// spanId, see opensearch.BuildTraceExportQuery) instead of
+// retrieveAndGroupTraces's aggregate-then-fetch phases. A trace is flushed
+// to w as soon as its spans are known complete - detected by the next
+// page's traceId moving past it, since the sort guarantees one trace's
+// spans arrive contiguously - so exports aren't held in memory as one
+// object and the response can start arriving immediately. If w also
+// implements http.Flusher, output is flushed every ndjsonFlushEvery traces.
+//
+// Unlike ExportTraces, this has no MaxTracesPerRequest or MaxSpansPerRequest
+// cap: the export runs until OpenSearch stops returning spans for params'
+// scope.
+func (s *TracingController) StreamExportTraces(ctx context.Context, params opensearch.TraceQueryParams, w io.Writer, format ExportFormat) error {
+	log := logger.GetLogger(ctx)
+	log.Info("Starting trace export stream",
+		"format", format,
+		"component", params.ComponentUid,
+		"environment", params.EnvironmentUid,
+		"startTime", params.StartTime,
+		"endTime", params.EndTime)
+
+	osClient, err := s.requireAdvancedStore()
+	if err != nil {
+		return err
+	}
+
+	indices, err := opensearch.GetIndicesForTimeRange(params.StartTime, params.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to generate indices: %w", err)
+	}
+
+	flusher, _ := w.(http.Flusher)
+
+	encodeTrace := ndjsonTraceEncoder(w)
+	if format == ExportFormatOTLPJSON {
+		encodeTrace = otlpTraceEncoder(w, params)
+	}
+
+	var (
+		searchAfter  []interface{}
+		currentID    string
+		currentSpans []opensearch.Span
+		written      int
+		totalSpans   int
+	)
+
+	flushCurrent := func() error {
+		if currentID == "" {
+			return nil
+		}
+		fullTrace, ok := buildFullTrace(currentID, currentSpans)
+		if !ok {
+			log.Warn("No root span found for trace, skipping", "traceId", currentID)
+			return nil
+		}
+		if err := encodeTrace(fullTrace); err != nil {
+			return fmt.Errorf("failed to encode trace %s: %w", currentID, err)
+		}
+		written++
+		if flusher != nil && written%ndjsonFlushEvery == 0 {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		query := opensearch.BuildTraceExportQuery(params, searchAfter, exportPageSpanLimit)
+		var response *opensearch.SearchResponse
+		err := s.searchSpan(ctx, "opensearch.Search", indices, func(ctx context.Context) error {
+			var searchErr error
+			response, searchErr = osClient.Search(ctx, indices, query)
+			return searchErr
+		})
+		if err != nil {
+			return fmt.Errorf("failed to search traces for export: %w", err)
+		}
+
+		spans := opensearch.ParseSpans(response)
+		if len(spans) == 0 {
+			break
+		}
+		totalSpans += len(spans)
+
+		for i := range spans {
+			span := spans[i]
+			if span.TraceID != currentID {
+				if err := flushCurrent(); err != nil {
+					return err
+				}
+				currentID = span.TraceID
+				currentSpans = currentSpans[:0]
+			}
+			currentSpans = append(currentSpans, span)
+		}
+
+		last := spans[len(spans)-1]
+		searchAfter = []interface{}{last.TraceID, last.SpanID}
+
+		if len(spans) < exportPageSpanLimit {
+			break
+		}
+	}
+
+	if err := flushCurrent(); err != nil {
+		return err
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	log.Info("Completed trace export stream",
+		"format", format,
+		"exportedTraces", written,
+		"totalSpans", totalSpans,
+		"component", params.ComponentUid,
+		"environment", params.EnvironmentUid)
+
+	return nil
+}
+
 // --- v2 controller methods ---
 
 // GetTraceByIdV2 retrieves spans for a specific trace using the v2 query builder.
@@ -437,29 +743,17 @@ func (s *TracingController) GetTraceByIdV2(ctx context.Context, params opensearc
 		"environment", params.EnvironmentUid,
 		"parentSpan", params.ParentSpan)
 
-	// Build query
-	query := opensearch.BuildV2TraceByIdsQuery(params)
-
-	// Search across last 7 days
-	endTime := time.Now()
-	startTime := endTime.AddDate(0, 0, -7)
-	indices, err := opensearch.GetIndicesForTimeRange(
-		startTime.Format(time.RFC3339),
-		endTime.Format(time.RFC3339),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate indices: %w", err)
-	}
-
-	// Execute search
-	response, err := s.osClient.Search(ctx, indices, query)
+	// Fetch spans from the configured SpanStore
+	var spans []opensearch.Span
+	err := s.storeSpan(ctx, "SpanStore.FetchSpansByTraceIDs", func(ctx context.Context) error {
+		var searchErr error
+		spans, searchErr = s.store.FetchSpansByTraceIDs(ctx, params.TraceIDs, params.ComponentUid, params.EnvironmentUid, params.ParentSpan)
+		return searchErr
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to search traces: %w", err)
 	}
 
-	// Parse spans
-	spans := opensearch.ParseSpans(response)
-
 	if len(spans) == 0 {
 		log.Warn("No spans found for trace (v2)",
 			"traceIds", params.TraceIDs,
@@ -487,7 +781,19 @@ func (s *TracingController) GetTraceByIdV2(ctx context.Context, params opensearc
 
 // GetTraceOverviewsV2 retrieves trace overviews using OpenSearch aggregations for proper
 // trace-level grouping and pagination, then enriches with root span data.
-func (s *TracingController) GetTraceOverviewsV2(ctx context.Context, params opensearch.TraceQueryParams) (*opensearch.TraceOverviewResponse, error) {
+func (s *TracingController) GetTraceOverviewsV2(ctx context.Context, params opensearch.TraceQueryParams) (_ *opensearch.TraceOverviewResponse, err error) {
+	ctx, tracerSpan := s.tracer.Start(ctx, "TracingController.GetTraceOverviewsV2", trace.WithAttributes(
+		attribute.String("component.uid", params.ComponentUid),
+		attribute.String("environment.uid", params.EnvironmentUid),
+	))
+	defer func() {
+		if err != nil {
+			tracerSpan.RecordError(err)
+			tracerSpan.SetStatus(codes.Error, err.Error())
+		}
+		tracerSpan.End()
+	}()
+
 	log := logger.GetLogger(ctx)
 	log.Info("Getting trace overviews (v2)",
 		"component", params.ComponentUid,
@@ -506,21 +812,19 @@ func (s *TracingController) GetTraceOverviewsV2(ctx context.Context, params open
 	}
 
 	// Phase 1: Aggregation to discover trace IDs with pagination
-	aggQuery := opensearch.BuildTraceAggregationQuery(params)
-
-	indices, err := opensearch.GetIndicesForTimeRange(params.StartTime, params.EndTime)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate indices: %w", err)
-	}
-
-	aggResponse, err := s.osClient.SearchWithAggregation(ctx, indices, aggQuery)
+	var (
+		buckets    []TraceBucket
+		totalCount int
+	)
+	err = s.storeSpan(ctx, "SpanStore.AggregateTraceBuckets", func(ctx context.Context) error {
+		var searchErr error
+		buckets, totalCount, searchErr = s.store.AggregateTraceBuckets(ctx, params)
+		return searchErr
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute aggregation: %w", err)
 	}
 
-	totalCount := aggResponse.Aggregations.TotalTraces.Value
-	buckets := aggResponse.Aggregations.Traces.Buckets
-
 	// Apply pagination: skip first `offset` buckets, take `limit`
 	start := params.Offset
 	end := params.Offset + params.Limit
@@ -546,26 +850,21 @@ func (s *TracingController) GetTraceOverviewsV2(ctx context.Context, params open
 	traceIDs := make([]string, len(paginatedBuckets))
 	spanCountMap := make(map[string]int, len(paginatedBuckets))
 	for i, bucket := range paginatedBuckets {
-		traceIDs[i] = bucket.Key
-		spanCountMap[bucket.Key] = bucket.DocCount
+		traceIDs[i] = bucket.TraceID
+		spanCountMap[bucket.TraceID] = bucket.DocCount
 	}
 
 	// Phase 2: Fetch root spans for enrichment
-	rootSpanParams := opensearch.V2TraceByIdParams{
-		TraceIDs:       traceIDs,
-		ComponentUid:   params.ComponentUid,
-		EnvironmentUid: params.EnvironmentUid,
-		ParentSpan:     true,
-		Limit:          len(traceIDs), // One root span per trace
-	}
-
-	rootSpanQuery := opensearch.BuildV2TraceByIdsQuery(rootSpanParams)
-	rootSpanResponse, err := s.osClient.Search(ctx, indices, rootSpanQuery)
+	var rootSpans []opensearch.Span
+	err = s.storeSpan(ctx, "SpanStore.FetchSpansByTraceIDs", func(ctx context.Context) error {
+		var searchErr error
+		rootSpans, searchErr = s.store.FetchSpansByTraceIDs(ctx, traceIDs, params.ComponentUid, params.EnvironmentUid, true)
+		return searchErr
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch root spans: %w", err)
 	}
-
-	rootSpans := opensearch.ParseSpans(rootSpanResponse)
+	tracerSpan.SetAttributes(attribute.Int("spans.parsed", len(rootSpans)))
 
 	// Index root spans by traceId
 	rootSpanMap := make(map[string]*opensearch.Span, len(rootSpans))
@@ -575,11 +874,13 @@ func (s *TracingController) GetTraceOverviewsV2(ctx context.Context, params open
 
 	// Build trace overviews in aggregation order (preserves sort)
 	overviews := make([]opensearch.TraceOverview, 0, len(paginatedBuckets))
+	skippedTraces := 0
 	for _, bucket := range paginatedBuckets {
-		rootSpan, hasRoot := rootSpanMap[bucket.Key]
+		rootSpan, hasRoot := rootSpanMap[bucket.TraceID]
 		if !hasRoot {
 			log.Warn("No root span found for trace, skipping",
-				"traceId", bucket.Key)
+				"traceId", bucket.TraceID)
+			skippedTraces++
 			continue
 		}
 
@@ -596,14 +897,14 @@ func (s *TracingController) GetTraceOverviewsV2(ctx context.Context, params open
 		traceStatus := opensearch.ExtractTraceStatus([]opensearch.Span{*rootSpan})
 
 		overviews = append(overviews, opensearch.TraceOverview{
-			TraceID:         bucket.Key,
+			TraceID:         bucket.TraceID,
 			RootSpanID:      rootSpan.SpanID,
 			RootSpanName:    rootSpan.Name,
 			RootSpanKind:    string(opensearch.DetermineSpanType(*rootSpan)),
 			StartTime:       rootSpan.StartTime.Format(time.RFC3339Nano),
 			EndTime:         rootSpan.EndTime.Format(time.RFC3339Nano),
 			DurationInNanos: rootSpan.DurationInNanos,
-			SpanCount:       spanCountMap[bucket.Key],
+			SpanCount:       spanCountMap[bucket.TraceID],
 			TokenUsage:      tokenUsage,
 			Status:          traceStatus,
 			Input:           input,
@@ -611,6 +912,7 @@ func (s *TracingController) GetTraceOverviewsV2(ctx context.Context, params open
 		})
 	}
 
+	tracerSpan.SetAttributes(attribute.Int("traces.skipped", skippedTraces))
 	log.Info("Retrieved trace overviews (v2)",
 		"totalCount", totalCount,
 		"returned", len(overviews),
@@ -623,7 +925,409 @@ func (s *TracingController) GetTraceOverviewsV2(ctx context.Context, params open
 	}, nil
 }
 
+// --- live tailing ---
+
+const (
+	// defaultTraceStreamPollInterval is how often StreamTraces re-queries
+	// OpenSearch for newly ingested spans.
+	defaultTraceStreamPollInterval = 5 * time.Second
+	// maxStreamSeenTraces bounds the in-memory dedup set StreamTraces keeps
+	// for a single connection; it resets at the end of a poll once it holds
+	// more than this many trace IDs, so a long-lived connection's memory
+	// doesn't grow unbounded.
+	maxStreamSeenTraces = 5000
+)
+
+// StreamTraces tails newly ingested traces matching params and pushes each
+// one onto ch as soon as it's observed, until ctx is done, at which point ch
+// is closed. params.StartTime seeds the tail cursor (e.g. from a
+// reconnecting client's Last-Event-ID); an empty StartTime starts the tail
+// from now.
+//
+// This polls on a fixed interval and re-queries a sliding
+// [cursor, now) window rather than using a true OpenSearch search_after
+// cursor: BuildTraceQuery has no search_after variant in this deployment, so
+// newly-seen traces are instead deduplicated by traceId against the
+// connection's own seen-set. A future search_after-based cursor (see
+// Client.SearchRootSpans) would let this skip the dedup step entirely.
+func (s *TracingController) StreamTraces(ctx context.Context, params opensearch.TraceQueryParams, ch chan<- opensearch.TraceOverview) error {
+	log := logger.GetLogger(ctx)
+	defer close(ch)
+
+	cursor := params.StartTime
+	if cursor == "" {
+		cursor = time.Now().Format(time.RFC3339Nano)
+	}
+	seen := make(map[string]struct{})
+
+	ticker := time.NewTicker(defaultTraceStreamPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			now := time.Now().Format(time.RFC3339Nano)
+
+			pollParams := params
+			pollParams.StartTime = cursor
+			pollParams.EndTime = now
+			pollParams.Limit = MaxTracesPerRequest
+			pollParams.Offset = 0
+			pollParams.SortOrder = "asc"
+
+			traces, _, _, err := s.retrieveAndGroupTraces(ctx, pollParams)
+			if err != nil {
+				log.Error("StreamTraces: failed to poll for new traces", "error", err)
+				cursor = now
+				continue
+			}
+
+			for _, traceData := range traces {
+				traceID := traceData["traceID"].(string)
+				if _, ok := seen[traceID]; ok {
+					continue
+				}
+				seen[traceID] = struct{}{}
+
+				overview := opensearch.TraceOverview{
+					TraceID:         traceID,
+					RootSpanID:      traceData["rootSpanID"].(string),
+					RootSpanName:    traceData["rootSpanName"].(string),
+					RootSpanKind:    traceData["rootSpanKind"].(string),
+					StartTime:       traceData["startTime"].(string),
+					EndTime:         traceData["endTime"].(string),
+					DurationInNanos: traceData["durationInNanos"].(int64),
+					SpanCount:       traceData["spanCount"].(int),
+					TokenUsage:      traceData["tokenUsage"].(*opensearch.TokenUsage),
+					Status:          traceData["status"].(*opensearch.TraceStatus),
+					Input:           traceData["input"],
+					Output:          traceData["output"],
+				}
+
+				select {
+				case ch <- overview:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			cursor = now
+			if len(seen) > maxStreamSeenTraces {
+				seen = make(map[string]struct{})
+			}
+		}
+	}
+}
+
+// SearchTraces runs a rich, cursor-paginated search over root spans
+// (attribute, duration, status, root span name, and span event filters).
+// Because matches are already trace-level (parentSpanId == ""), unlike
+// GetTraceOverviews/GetTraceOverviewsV2 no post-fetch grouping step is
+// needed, and pagination follows OpenSearch's search_after cursor instead
+// of an offset: offset-based paging re-counts from hit zero on every page,
+// which both degrades past ~10k results and goes stale as new traces are
+// ingested between pages.
+func (s *TracingController) SearchTraces(ctx context.Context, params opensearch.TraceSearchParams) (*opensearch.TraceSearchResponse, error) {
+	log := logger.GetLogger(ctx)
+
+	osClient, err := s.requireAdvancedStore()
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = DefaultTracesLimit
+	}
+	if params.Limit > MaxTracesPerRequest {
+		params.Limit = MaxTracesPerRequest
+	}
+
+	query := opensearch.BuildTraceSearchQuery(params)
+	log.Info("Built trace search query", "query", query)
+
+	indices, err := opensearch.GetIndicesForTimeRange(params.StartTime, params.EndTime)
+	if err != nil {
+		log.Error("Failed to generate indices for time range",
+			"startTime", params.StartTime,
+			"endTime", params.EndTime,
+			"error", err)
+		return nil, fmt.Errorf("failed to generate indices: %w", err)
+	}
+
+	var response *opensearch.RootSpanSearchResponse
+	err = s.searchSpan(ctx, "opensearch.SearchRootSpans", indices, func(ctx context.Context) error {
+		var searchErr error
+		response, searchErr = osClient.SearchRootSpans(ctx, indices, query)
+		return searchErr
+	})
+	if err != nil {
+		log.Error("Trace search failed",
+			"indices", indices,
+			"component", params.ComponentUid,
+			"environment", params.EnvironmentUid,
+			"error", err)
+		return nil, fmt.Errorf("failed to search traces: %w", err)
+	}
+
+	hits := response.Hits.Hits
+	overviews := make([]opensearch.TraceOverview, 0, len(hits))
+	var lastSort []interface{}
+	for _, hit := range hits {
+		rootSpan := hit.Source
+
+		var input, output interface{}
+		if opensearch.IsCrewAISpan(rootSpan.Attributes) {
+			input, output = opensearch.ExtractCrewAIRootSpanInputOutput(&rootSpan)
+		} else {
+			input, output = opensearch.ExtractRootSpanInputOutput(&rootSpan)
+		}
+
+		overviews = append(overviews, opensearch.TraceOverview{
+			TraceID:         rootSpan.TraceID,
+			RootSpanID:      rootSpan.SpanID,
+			RootSpanName:    rootSpan.Name,
+			RootSpanKind:    string(opensearch.DetermineSpanType(rootSpan)),
+			StartTime:       rootSpan.StartTime.Format(time.RFC3339Nano),
+			EndTime:         rootSpan.EndTime.Format(time.RFC3339Nano),
+			DurationInNanos: rootSpan.DurationInNanos,
+			SpanCount:       1,
+			TokenUsage:      opensearch.ExtractTokenUsage([]opensearch.Span{rootSpan}),
+			Status:          opensearch.ExtractTraceStatus([]opensearch.Span{rootSpan}),
+			Input:           input,
+			Output:          output,
+		})
+		lastSort = hit.Sort
+	}
+
+	var nextCursor string
+	if len(hits) >= params.Limit && lastSort != nil {
+		encoded, err := encodeSearchCursor(lastSort)
+		if err != nil {
+			log.Error("SearchTraces: failed to encode next cursor", "error", err)
+		} else {
+			nextCursor = encoded
+		}
+	}
+
+	log.Info("Trace search completed",
+		"returned", len(overviews),
+		"totalTraces", response.Aggregations.TotalTraces.Value,
+		"hasNextCursor", nextCursor != "")
+
+	return &opensearch.TraceSearchResponse{
+		Traces:     overviews,
+		TotalCount: response.Aggregations.TotalTraces.Value,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// SearchTracesQL runs a TraceQL-like query (see opensearch/traceql) over
+// spans: query is parsed into an AST whose leaf attribute/reserved-field
+// predicates are compiled into an OpenSearch pre-filter to shrink the span
+// scan, then the full pipeline (set operators, aggregate stages) is
+// evaluated in Go over every span the pre-filter could match - all spans,
+// not just root spans, since a SpansetFilter can match any span in a
+// trace. Traces whose final spanset is empty, or whose aggregate stage
+// comparisons don't hold, are dropped; the rest are returned in the same
+// shape as SearchTraces, newest first.
+func (s *TracingController) SearchTracesQL(ctx context.Context, query string, params opensearch.TraceQueryParams) (*opensearch.TraceSearchResponse, error) {
+	log := logger.GetLogger(ctx)
+
+	osClient, err := s.requireAdvancedStore()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := traceql.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TraceQL query: %w", err)
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = DefaultTracesLimit
+	}
+	if params.Limit > MaxTracesPerRequest {
+		params.Limit = MaxTracesPerRequest
+	}
+
+	preFilter, _ := traceql.CompilePreFilter(parsed)
+	osQuery := opensearch.BuildTraceQLPreFilterQuery(params, preFilter, MaxSpansPerRequest)
+	log.Info("Built TraceQL pre-filter query", "query", osQuery)
+
+	indices, err := opensearch.GetIndicesForTimeRange(params.StartTime, params.EndTime)
+	if err != nil {
+		log.Error("Failed to generate indices for time range",
+			"startTime", params.StartTime,
+			"endTime", params.EndTime,
+			"error", err)
+		return nil, fmt.Errorf("failed to generate indices: %w", err)
+	}
+
+	var response *opensearch.SearchResponse
+	err = s.searchSpan(ctx, "opensearch.Search", indices, func(ctx context.Context) error {
+		var searchErr error
+		response, searchErr = osClient.Search(ctx, indices, osQuery)
+		return searchErr
+	})
+	if err != nil {
+		log.Error("TraceQL pre-filter search failed",
+			"indices", indices,
+			"component", params.ComponentUid,
+			"environment", params.EnvironmentUid,
+			"error", err)
+		return nil, fmt.Errorf("failed to search traces: %w", err)
+	}
+
+	spans := opensearch.ParseSpans(response)
+	log.Debug("Parsed spans for TraceQL evaluation", "spanCount", len(spans))
+
+	spansByTrace := make(map[string][]opensearch.Span, len(spans))
+	for _, span := range spans {
+		spansByTrace[span.TraceID] = append(spansByTrace[span.TraceID], span)
+	}
+
+	traceIDs := traceql.MatchingTraceIDs(parsed, spansByTrace)
+
+	overviews := make([]opensearch.TraceOverview, 0, len(traceIDs))
+	for _, traceID := range traceIDs {
+		traceSpans := spansByTrace[traceID]
+
+		var rootSpan *opensearch.Span
+		for i := range traceSpans {
+			if traceSpans[i].ParentSpanID == "" {
+				rootSpan = &traceSpans[i]
+				break
+			}
+		}
+		if rootSpan == nil {
+			log.Warn("SearchTracesQL: no root span found for matching trace, skipping", "traceId", traceID)
+			continue
+		}
+
+		var input, output interface{}
+		if opensearch.IsCrewAISpan(rootSpan.Attributes) {
+			input, output = opensearch.ExtractCrewAIRootSpanInputOutput(rootSpan)
+		} else {
+			input, output = opensearch.ExtractRootSpanInputOutput(rootSpan)
+		}
+
+		overviews = append(overviews, opensearch.TraceOverview{
+			TraceID:         traceID,
+			RootSpanID:      rootSpan.SpanID,
+			RootSpanName:    rootSpan.Name,
+			RootSpanKind:    string(opensearch.DetermineSpanType(*rootSpan)),
+			StartTime:       rootSpan.StartTime.Format(time.RFC3339Nano),
+			EndTime:         rootSpan.EndTime.Format(time.RFC3339Nano),
+			DurationInNanos: rootSpan.DurationInNanos,
+			SpanCount:       len(traceSpans),
+			TokenUsage:      opensearch.ExtractTokenUsage(traceSpans),
+			Status:          opensearch.ExtractTraceStatus(traceSpans),
+			Input:           input,
+			Output:          output,
+		})
+	}
+
+	sort.Slice(overviews, func(i, j int) bool {
+		return overviews[i].StartTime > overviews[j].StartTime
+	})
+	if len(overviews) > params.Limit {
+		overviews = overviews[:params.Limit]
+	}
+
+	log.Info("TraceQL search completed",
+		"matchedTraces", len(traceIDs),
+		"returned", len(overviews))
+
+	return &opensearch.TraceSearchResponse{
+		Traces:     overviews,
+		TotalCount: len(traceIDs),
+	}, nil
+}
+
+// encodeSearchCursor packs OpenSearch sort values into the opaque cursor
+// string SearchTraces hands back as NextCursor; DecodeSearchCursor reverses
+// this.
+func encodeSearchCursor(sortValues []interface{}) (string, error) {
+	raw, err := json.Marshal(sortValues)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal sort values: %w", err)
+	}
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeSearchCursor decodes a SearchTraces NextCursor token, handed back
+// by a client as TraceSearchParams.SearchAfter's wire-level input, into the
+// OpenSearch sort values to resume from.
+func DecodeSearchCursor(cursor string) ([]interface{}, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to base64-decode cursor: %w", err)
+	}
+	var sortValues []interface{}
+	if err := json.Unmarshal(raw, &sortValues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cursor: %w", err)
+	}
+	return sortValues, nil
+}
+
+// QuerySpanMetrics computes time-bucketed aggregates over spans matching
+// params (rate/count/quantile/sum, optionally grouped by attribute keys)
+// as a single OpenSearch composite aggregation, without materializing or
+// grouping spans into traces - see opensearch.BuildSpanMetricsQuery and
+// opensearch.EvaluateSpanMetrics.
+func (s *TracingController) QuerySpanMetrics(ctx context.Context, params opensearch.SpanMetricsParams) (_ *opensearch.SpanMetricsResponse, err error) {
+	ctx, tracerSpan := s.tracer.Start(ctx, "TracingController.QuerySpanMetrics", trace.WithAttributes(
+		attribute.String("component.uid", params.ComponentUid),
+		attribute.String("environment.uid", params.EnvironmentUid),
+	))
+	defer func() {
+		if err != nil {
+			tracerSpan.RecordError(err)
+			tracerSpan.SetStatus(codes.Error, err.Error())
+		}
+		tracerSpan.End()
+	}()
+
+	log := logger.GetLogger(ctx)
+	log.Info("Querying span metrics",
+		"component", params.ComponentUid,
+		"environment", params.EnvironmentUid,
+		"aggregation", params.Aggregation,
+		"groupBy", params.GroupBy,
+		"step", params.Step)
+
+	osClient, err := s.requireAdvancedStore()
+	if err != nil {
+		return nil, err
+	}
+
+	indices, err := opensearch.GetIndicesForTimeRange(params.StartTime, params.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate indices: %w", err)
+	}
+	tracerSpan.SetAttributes(attribute.Int("indices.count", len(indices)))
+
+	query := opensearch.BuildSpanMetricsQuery(params)
+
+	var response *opensearch.SpanMetricsAggregationResponse
+	err = s.searchSpan(ctx, "opensearch.SearchSpanMetrics", indices, func(ctx context.Context) error {
+		var searchErr error
+		response, searchErr = osClient.SearchSpanMetrics(ctx, indices, query)
+		return searchErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query span metrics: %w", err)
+	}
+
+	series := opensearch.EvaluateSpanMetrics(params, response)
+	log.Info("Computed span metrics", "seriesCount", len(series))
+
+	return &opensearch.SpanMetricsResponse{Series: series}, nil
+}
+
 // HealthCheck checks if the service is healthy
 func (s *TracingController) HealthCheck(ctx context.Context) error {
-	return s.osClient.HealthCheck(ctx)
+	return s.store.HealthCheck(ctx)
 }
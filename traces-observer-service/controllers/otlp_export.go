@@ -0,0 +1,242 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
+)
+
+// OTLP status codes, per the OpenTelemetry Status proto. Spans that never
+// recorded a status (span.StatusCode == "") are left unset rather than
+// mapped to otlpStatusCodeUnset explicitly.
+const (
+	otlpStatusCodeUnset = 0
+	otlpStatusCodeOK    = 1
+	otlpStatusCodeError = 2
+)
+
+// otlpExportEnvelope mirrors the shape of an OTLP/JSON
+// ExportTraceServiceRequest. StreamExportTraces writes one of these per
+// trace rather than batching traces into a single request, so the export
+// can be streamed.
+type otlpExportEnvelope struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Spans []otlpSpan `json:"spans"`
+}
+
+// otlpSpan is a best-effort reconstruction of an OTLP span from the fields
+// ParseSpans persists. Span IDs are emitted as the hex strings already
+// stored rather than base64-encoded bytes, and Kind and the instrumentation
+// scope are left at their zero values: the stored span documents don't
+// retain the original OTLP span kind or scope name/version, so there's
+// nothing faithful to reconstruct them from.
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code int `json:"code"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// ndjsonTraceEncoder returns the encodeTrace func StreamExportTraces calls
+// per completed trace for ExportFormatNDJSON: one json.Encoder.Encode per
+// line, same as ExportTraces' in-memory opensearch.FullTrace shape.
+func ndjsonTraceEncoder(w io.Writer) func(opensearch.FullTrace) error {
+	encoder := json.NewEncoder(w)
+	return encoder.Encode
+}
+
+// otlpTraceEncoder returns the encodeTrace func StreamExportTraces calls
+// per completed trace for ExportFormatOTLPJSON. Every trace in one export
+// shares a single resource built from params, since one export call is
+// scoped to one component/environment.
+func otlpTraceEncoder(w io.Writer, params opensearch.TraceQueryParams) func(opensearch.FullTrace) error {
+	resource := otlpResource{
+		Attributes: []otlpKeyValue{
+			otlpStringAttribute("resource.openchoreo.dev/component-uid", params.ComponentUid),
+			otlpStringAttribute("resource.openchoreo.dev/environment-uid", params.EnvironmentUid),
+		},
+	}
+	encoder := json.NewEncoder(w)
+
+	return func(trace opensearch.FullTrace) error {
+		spans := make([]otlpSpan, len(trace.Spans))
+		for i, span := range trace.Spans {
+			spans[i] = toOTLPSpan(span)
+		}
+
+		envelope := otlpExportEnvelope{
+			ResourceSpans: []otlpResourceSpans{
+				{
+					Resource: resource,
+					ScopeSpans: []otlpScopeSpans{
+						{Spans: spans},
+					},
+				},
+			},
+		}
+		return encoder.Encode(envelope)
+	}
+}
+
+func toOTLPSpan(span opensearch.Span) otlpSpan {
+	otlp := otlpSpan{
+		TraceID:           span.TraceID,
+		SpanID:            span.SpanID,
+		ParentSpanID:      span.ParentSpanID,
+		Name:              span.Name,
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+	}
+
+	for key, value := range span.Attributes {
+		otlp.Attributes = append(otlp.Attributes, toOTLPAttribute(key, value))
+	}
+
+	switch span.StatusCode {
+	case "OK":
+		otlp.Status = &otlpStatus{Code: otlpStatusCodeOK}
+	case "ERROR":
+		otlp.Status = &otlpStatus{Code: otlpStatusCodeError}
+	}
+
+	return otlp
+}
+
+// fromOTLPSpan converts an otlpSpan back into this service's
+// opensearch.Span shape, the reverse of toOTLPSpan - used by
+// tempoSpanStore.fetchTrace, which receives spans from Tempo as OTLP/JSON
+// rather than this service's own OpenSearch-stored span documents.
+// traceID is passed in separately because Tempo's per-span traceId field
+// is sometimes omitted on the wire when it's already implied by the
+// surrounding GET /api/traces/{traceID} response.
+func fromOTLPSpan(span otlpSpan, traceID string) (opensearch.Span, error) {
+	startNanos, err := strconv.ParseInt(span.StartTimeUnixNano, 10, 64)
+	if err != nil {
+		return opensearch.Span{}, fmt.Errorf("invalid startTimeUnixNano %q: %w", span.StartTimeUnixNano, err)
+	}
+	endNanos, err := strconv.ParseInt(span.EndTimeUnixNano, 10, 64)
+	if err != nil {
+		return opensearch.Span{}, fmt.Errorf("invalid endTimeUnixNano %q: %w", span.EndTimeUnixNano, err)
+	}
+
+	osSpan := opensearch.Span{
+		TraceID:         span.TraceID,
+		SpanID:          span.SpanID,
+		ParentSpanID:    span.ParentSpanID,
+		Name:            span.Name,
+		StartTime:       time.Unix(0, startNanos),
+		EndTime:         time.Unix(0, endNanos),
+		DurationInNanos: endNanos - startNanos,
+		Attributes:      make(map[string]interface{}, len(span.Attributes)),
+	}
+	if osSpan.TraceID == "" {
+		osSpan.TraceID = traceID
+	}
+
+	for _, attr := range span.Attributes {
+		osSpan.Attributes[attr.Key] = fromOTLPAttribute(attr.Value)
+	}
+
+	if span.Status != nil {
+		switch span.Status.Code {
+		case otlpStatusCodeOK:
+			osSpan.StatusCode = "OK"
+		case otlpStatusCodeError:
+			osSpan.StatusCode = "ERROR"
+		}
+	}
+
+	return osSpan, nil
+}
+
+func fromOTLPAttribute(value otlpAnyValue) interface{} {
+	switch {
+	case value.StringValue != nil:
+		return *value.StringValue
+	case value.BoolValue != nil:
+		return *value.BoolValue
+	case value.DoubleValue != nil:
+		return *value.DoubleValue
+	case value.IntValue != nil:
+		n, err := strconv.ParseInt(*value.IntValue, 10, 64)
+		if err != nil {
+			return *value.IntValue
+		}
+		return n
+	default:
+		return nil
+	}
+}
+
+func toOTLPAttribute(key string, value interface{}) otlpKeyValue {
+	switch v := value.(type) {
+	case string:
+		return otlpStringAttribute(key, v)
+	case bool:
+		return otlpKeyValue{Key: key, Value: otlpAnyValue{BoolValue: &v}}
+	case float64:
+		return otlpKeyValue{Key: key, Value: otlpAnyValue{DoubleValue: &v}}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &s}}
+	default:
+		return otlpStringAttribute(key, fmt.Sprintf("%v", v))
+	}
+}
+
+func otlpStringAttribute(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
+)
+
+// SpanStore is the storage abstraction TracingController queries through,
+// so the traces observer can sit in front of whatever tracing backend a
+// user already runs instead of being hard-coupled to OpenSearch. See
+// NewOpenSearchSpanStore, NewJaegerSpanStore, and NewTempoSpanStore for the
+// adapters, and NewSpanStore for the config-driven factory that picks one.
+type SpanStore interface {
+	// SearchSpans returns every span matching params, unsorted, for callers
+	// that group spans into traces themselves. No TracingController method
+	// calls it.
+	SearchSpans(ctx context.Context, params opensearch.TraceQueryParams) ([]opensearch.Span, error)
+
+	// SearchTraceIDs returns the trace IDs matching params, in the order
+	// AggregateTraceBuckets returns them, without fetching their spans.
+	SearchTraceIDs(ctx context.Context, params opensearch.TraceQueryParams) ([]string, error)
+
+	// FetchSpansByTraceIDs returns every span belonging to any of traceIDs.
+	// The opensearch adapter scopes the lookup to componentUid/environmentUid;
+	// the jaeger and tempo adapters fetch each trace by ID alone and ignore
+	// both. When rootOnly is true, only
+	// each trace's root span (parentSpanId == "") is returned - used for
+	// overview enrichment, where only the root span's name/status/tokens
+	// are needed.
+	FetchSpansByTraceIDs(ctx context.Context, traceIDs []string, componentUid, environmentUid string, rootOnly bool) ([]opensearch.Span, error)
+
+	// AggregateTraceBuckets returns one bucket per distinct trace ID
+	// matching params (trace ID, span count) plus a total trace count - the
+	// paginated discovery phase of GetTraceOverviewsV2 and
+	// retrieveAndGroupTraces. The opensearch and jaeger adapters order
+	// buckets by each trace's earliest span start per params.SortOrder
+	// (newest first by default); the tempo adapter keeps the order of
+	// Tempo's search response. The opensearch adapter's total is the
+	// distinct trace count over all of params' scope; the jaeger and tempo
+	// adapters return the number of buckets they fetched.
+	AggregateTraceBuckets(ctx context.Context, params opensearch.TraceQueryParams) (buckets []TraceBucket, totalTraces int, err error)
+
+	// HealthCheck reports whether the backing store is reachable.
+	HealthCheck(ctx context.Context) error
+}
+
+// TraceBucket is one trace's aggregated span count, as returned by
+// SpanStore.AggregateTraceBuckets.
+type TraceBucket struct {
+	TraceID  string
+	DocCount int
+}
+
+// ErrAdvancedQueriesUnsupported is returned by StreamExportTraces,
+// SearchTraces, SearchTracesQL, and QuerySpanMetrics
+// when the configured SpanStore doesn't implement advancedSpanStore - all
+// currently true only of the jaeger and tempo adapters, since these
+// operations rely on OpenSearch-specific capabilities (composite
+// aggregations, search_after pagination, span-level TraceQL pre-filtering)
+// that have no equivalent in Jaeger's or Tempo's query APIs.
+var ErrAdvancedQueriesUnsupported = errors.New("this operation requires the opensearch backend")
+
+// advancedSpanStore is implemented by SpanStore adapters that expose the
+// underlying *opensearch.Client for the handful of controller methods that
+// haven't been generalized behind SpanStore yet. Currently only
+// opensearchSpanStore implements it.
+type advancedSpanStore interface {
+	osClient() *opensearch.Client
+}
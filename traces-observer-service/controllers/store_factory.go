@@ -0,0 +1,54 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
+)
+
+// StoreConfig selects and configures the SpanStore a service deployment
+// queries through. Read from this service's config file alongside
+// opensearch.ClientConfig and telemetry.Config.
+type StoreConfig struct {
+	// Backend is "opensearch", "jaeger", or "tempo". Empty defaults to
+	// "opensearch".
+	Backend string
+	// JaegerQueryAddr is Jaeger's gRPC query endpoint, used when Backend
+	// is "jaeger", e.g. "jaeger-query:16685".
+	JaegerQueryAddr string
+	// TempoBaseURL is Tempo's query frontend address, used when Backend
+	// is "tempo", e.g. "http://tempo:3200".
+	TempoBaseURL string
+}
+
+// NewSpanStore builds the SpanStore cfg.Backend selects. osClient is only
+// used when cfg.Backend is "opensearch" (or empty); callers that only ever
+// run against Jaeger or Tempo may pass nil.
+func NewSpanStore(cfg StoreConfig, osClient *opensearch.Client) (SpanStore, error) {
+	switch cfg.Backend {
+	case "", "opensearch":
+		return NewOpenSearchSpanStore(osClient), nil
+	case "jaeger":
+		return NewJaegerSpanStore(cfg.JaegerQueryAddr)
+	case "tempo":
+		return NewTempoSpanStore(cfg.TempoBaseURL), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
+)
+
+// jaegerSpanStore adapts Jaeger's gRPC query API (api_v2.QueryServiceClient)
+// to SpanStore, for users who already run Jaeger as their tracing backend.
+// It does not implement advancedSpanStore: Jaeger's query API has no
+// equivalent of OpenSearch's composite span-metrics aggregation, root-span
+// search_after pagination, or TraceQL span-level pre-filtering.
+type jaegerSpanStore struct {
+	client api_v2.QueryServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewJaegerSpanStore dials addr - Jaeger's gRPC query endpoint, e.g.
+// "jaeger-query:16685" - and returns a SpanStore backed by it.
+func NewJaegerSpanStore(addr string) (SpanStore, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial jaeger query service at %q: %w", addr, err)
+	}
+	return &jaegerSpanStore{client: api_v2.NewQueryServiceClient(conn), conn: conn}, nil
+}
+
+func (j *jaegerSpanStore) SearchSpans(ctx context.Context, params opensearch.TraceQueryParams) ([]opensearch.Span, error) {
+	traces, err := j.findTraces(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	var spans []opensearch.Span
+	for _, traceSpans := range traces {
+		spans = append(spans, traceSpans...)
+	}
+	return spans, nil
+}
+
+func (j *jaegerSpanStore) SearchTraceIDs(ctx context.Context, params opensearch.TraceQueryParams) ([]string, error) {
+	buckets, _, err := j.AggregateTraceBuckets(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(buckets))
+	for i, bucket := range buckets {
+		ids[i] = bucket.TraceID
+	}
+	return ids, nil
+}
+
+func (j *jaegerSpanStore) FetchSpansByTraceIDs(ctx context.Context, traceIDs []string, componentUid, environmentUid string, rootOnly bool) ([]opensearch.Span, error) {
+	var spans []opensearch.Span
+	for _, id := range traceIDs {
+		traceID, err := model.TraceIDFromString(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jaeger trace id %q: %w", id, err)
+		}
+
+		stream, err := j.client.GetTrace(ctx, &api_v2.GetTraceRequest{TraceID: traceID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch jaeger trace %q: %w", id, err)
+		}
+		traceSpans, err := drainJaegerTraceStream(stream)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jaeger trace %q: %w", id, err)
+		}
+		if rootOnly {
+			traceSpans = rootSpansOnly(traceSpans)
+		}
+		spans = append(spans, traceSpans...)
+	}
+	return spans, nil
+}
+
+func (j *jaegerSpanStore) AggregateTraceBuckets(ctx context.Context, params opensearch.TraceQueryParams) ([]TraceBucket, int, error) {
+	traces, err := j.findTraces(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	buckets := make([]TraceBucket, 0, len(traces))
+	earliest := make(map[string]time.Time, len(traces))
+	for traceID, traceSpans := range traces {
+		buckets = append(buckets, TraceBucket{TraceID: traceID, DocCount: len(traceSpans)})
+		for _, span := range traceSpans {
+			if start, ok := earliest[traceID]; !ok || span.StartTime.Before(start) {
+				earliest[traceID] = span.StartTime
+			}
+		}
+	}
+	sort.Slice(buckets, func(i, k int) bool {
+		a, b := earliest[buckets[i].TraceID], earliest[buckets[k].TraceID]
+		if params.SortOrder == "asc" {
+			return a.Before(b)
+		}
+		return a.After(b)
+	})
+	return buckets, len(buckets), nil
+}
+
+func (j *jaegerSpanStore) HealthCheck(ctx context.Context) error {
+	if _, err := j.client.GetServices(ctx, &api_v2.GetServicesRequest{}); err != nil {
+		return fmt.Errorf("jaeger query service unreachable: %w", err)
+	}
+	return nil
+}
+
+// findTraces runs a Jaeger FindTraces query scoped by params - ServiceName
+// maps from params.ComponentUid, and params.EnvironmentUid is passed as a
+// tag filter since Jaeger's TraceQueryParameters has no separate
+// environment concept - and returns the matching traces keyed by trace ID.
+func (j *jaegerSpanStore) findTraces(ctx context.Context, params opensearch.TraceQueryParams) (map[string][]opensearch.Span, error) {
+	startTime, endTime, err := parseTraceQueryTimeRange(params.StartTime, params.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int32(params.Offset + params.Limit)
+	if limit <= 0 {
+		limit = DefaultTracesLimit
+	}
+
+	stream, err := j.client.FindTraces(ctx, &api_v2.FindTracesRequest{
+		Query: &api_v2.TraceQueryParameters{
+			ServiceName:  params.ComponentUid,
+			Tags:         map[string]string{"environment.uid": params.EnvironmentUid},
+			StartTimeMin: startTime,
+			StartTimeMax: endTime,
+			NumTraces:    limit,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("jaeger FindTraces failed: %w", err)
+	}
+
+	traces := make(map[string][]opensearch.Span)
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jaeger FindTraces stream failed: %w", err)
+		}
+		for _, span := range chunk.Spans {
+			osSpan := fromJaegerSpan(span)
+			traces[osSpan.TraceID] = append(traces[osSpan.TraceID], osSpan)
+		}
+	}
+	return traces, nil
+}
+
+// drainJaegerTraceStream reads every chunk of a GetTrace stream into a
+// single flat span slice.
+func drainJaegerTraceStream(stream api_v2.QueryService_GetTraceClient) ([]opensearch.Span, error) {
+	var spans []opensearch.Span
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, span := range chunk.Spans {
+			spans = append(spans, fromJaegerSpan(span))
+		}
+	}
+	return spans, nil
+}
+
+// rootSpansOnly filters spans down to those with no parent. It does not
+// group by trace ID, so a trace with several parentless spans yields all
+// of them.
+func rootSpansOnly(spans []opensearch.Span) []opensearch.Span {
+	var roots []opensearch.Span
+	for _, span := range spans {
+		if span.ParentSpanID == "" {
+			roots = append(roots, span)
+		}
+	}
+	return roots
+}
+
+// fromJaegerSpan converts a Jaeger model.Span into this service's
+// opensearch.Span shape. Jaeger encodes the parent span via a CHILD_OF
+// SpanRef rather than a dedicated parent field; the first CHILD_OF
+// reference is used, whatever trace ID it carries, matching how every other span
+// source in this service (OTLP ingestion) treats "parent".
+func fromJaegerSpan(span *model.Span) opensearch.Span {
+	osSpan := opensearch.Span{
+		TraceID:         span.TraceID.String(),
+		SpanID:          span.SpanID.String(),
+		Name:            span.OperationName,
+		StartTime:       span.StartTime,
+		EndTime:         span.StartTime.Add(span.Duration),
+		DurationInNanos: span.Duration.Nanoseconds(),
+		Attributes:      make(map[string]interface{}, len(span.Tags)),
+	}
+
+	for _, ref := range span.References {
+		if ref.RefType == model.ChildOf {
+			osSpan.ParentSpanID = ref.SpanID.String()
+			break
+		}
+	}
+
+	for _, tag := range span.Tags {
+		osSpan.Attributes[tag.Key] = jaegerTagValue(tag)
+	}
+
+	for _, kv := range span.Process.Tags {
+		osSpan.Attributes["process."+kv.Key] = jaegerTagValue(kv)
+	}
+
+	return osSpan
+}
+
+func jaegerTagValue(tag model.KeyValue) interface{} {
+	switch tag.VType {
+	case model.StringType:
+		return tag.VStr
+	case model.BoolType:
+		return tag.VBool
+	case model.Int64Type:
+		return tag.VInt64
+	case model.Float64Type:
+		return tag.VFloat64
+	default:
+		return tag.VStr
+	}
+}
+
+// parseTraceQueryTimeRange parses the RFC3339 start/end strings
+// opensearch.TraceQueryParams carries into time.Time, for adapters whose
+// native query API takes times directly rather than OpenSearch index
+// names.
+func parseTraceQueryTimeRange(startTime, endTime string) (start, end time.Time, err error) {
+	start, err = time.Parse(time.RFC3339, startTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid startTime %q: %w", startTime, err)
+	}
+	end, err = time.Parse(time.RFC3339, endTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid endTime %q: %w", endTime, err)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
+)
+
+// byIdLookupWindow is how far back GetTraceByIdAndService/GetTraceByIdV2
+// search when only a trace ID is known and no explicit time range is
+// given, mirroring the 7-day window those methods used before SpanStore
+// existed.
+const byIdLookupWindow = 7 * 24 * time.Hour
+
+// opensearchSpanStore adapts *opensearch.Client, along with this package's
+// existing BuildTraceQuery/BuildTraceAggregationQuery/BuildV2TraceByIdsQuery/
+// ParseSpans/GetIndicesForTimeRange helpers, to SpanStore. It's the default
+// backend and the only one that also implements advancedSpanStore.
+type opensearchSpanStore struct {
+	client *opensearch.Client
+}
+
+// NewOpenSearchSpanStore adapts an existing *opensearch.Client to SpanStore.
+func NewOpenSearchSpanStore(client *opensearch.Client) SpanStore {
+	return &opensearchSpanStore{client: client}
+}
+
+func (o *opensearchSpanStore) osClient() *opensearch.Client {
+	return o.client
+}
+
+func (o *opensearchSpanStore) SearchSpans(ctx context.Context, params opensearch.TraceQueryParams) ([]opensearch.Span, error) {
+	indices, err := opensearch.GetIndicesForTimeRange(params.StartTime, params.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate indices: %w", err)
+	}
+
+	query := opensearch.BuildTraceQuery(params)
+	response, err := o.client.Search(ctx, indices, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search spans: %w", err)
+	}
+	return opensearch.ParseSpans(response), nil
+}
+
+func (o *opensearchSpanStore) SearchTraceIDs(ctx context.Context, params opensearch.TraceQueryParams) ([]string, error) {
+	buckets, _, err := o.AggregateTraceBuckets(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(buckets))
+	for i, bucket := range buckets {
+		ids[i] = bucket.TraceID
+	}
+	return ids, nil
+}
+
+func (o *opensearchSpanStore) FetchSpansByTraceIDs(ctx context.Context, traceIDs []string, componentUid, environmentUid string, rootOnly bool) ([]opensearch.Span, error) {
+	if rootOnly {
+		return o.fetchRootSpansByTraceIDs(ctx, traceIDs, componentUid, environmentUid)
+	}
+	return o.fetchAllSpansByTraceIDs(ctx, traceIDs, componentUid, environmentUid)
+}
+
+// traceIDsFetchPageSize bounds each page fetchAllSpansByTraceIDs requests.
+const traceIDsFetchPageSize = 5000
+
+// fetchRootSpansByTraceIDs fetches one root span per trace ID in a single
+// request. Used for overview enrichment, where only the root span's
+// name/status/token usage is needed, so there's no pagination concern.
+func (o *opensearchSpanStore) fetchRootSpansByTraceIDs(ctx context.Context, traceIDs []string, componentUid, environmentUid string) ([]opensearch.Span, error) {
+	params := opensearch.V2TraceByIdParams{
+		TraceIDs:       traceIDs,
+		ComponentUid:   componentUid,
+		EnvironmentUid: environmentUid,
+		ParentSpan:     true,
+		Limit:          len(traceIDs),
+	}
+	query := opensearch.BuildV2TraceByIdsQuery(params)
+
+	endTime := time.Now()
+	startTime := endTime.Add(-byIdLookupWindow)
+	indices, err := opensearch.GetIndicesForTimeRange(startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate indices: %w", err)
+	}
+
+	response, err := o.client.Search(ctx, indices, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch root spans by trace IDs: %w", err)
+	}
+	return opensearch.ParseSpans(response), nil
+}
+
+// fetchAllSpansByTraceIDs pages through every span belonging to traceIDs via
+// search_after (see opensearch.BuildTraceIDsSpansQuery), rather than a
+// single flat-size request - the number of spans across traceIDs can run
+// into the thousands for large agent workflows.
+func (o *opensearchSpanStore) fetchAllSpansByTraceIDs(ctx context.Context, traceIDs []string, componentUid, environmentUid string) ([]opensearch.Span, error) {
+	endTime := time.Now()
+	startTime := endTime.Add(-byIdLookupWindow)
+	indices, err := opensearch.GetIndicesForTimeRange(startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate indices: %w", err)
+	}
+
+	var (
+		spans       []opensearch.Span
+		searchAfter []interface{}
+	)
+	for {
+		query := opensearch.BuildTraceIDsSpansQuery(traceIDs, componentUid, environmentUid, searchAfter, traceIDsFetchPageSize)
+		response, err := o.client.Search(ctx, indices, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch spans by trace IDs: %w", err)
+		}
+
+		page := opensearch.ParseSpans(response)
+		if len(page) == 0 {
+			break
+		}
+		spans = append(spans, page...)
+
+		last := page[len(page)-1]
+		searchAfter = []interface{}{last.TraceID, last.SpanID}
+
+		if len(page) < traceIDsFetchPageSize {
+			break
+		}
+	}
+	return spans, nil
+}
+
+func (o *opensearchSpanStore) AggregateTraceBuckets(ctx context.Context, params opensearch.TraceQueryParams) ([]TraceBucket, int, error) {
+	indices, err := opensearch.GetIndicesForTimeRange(params.StartTime, params.EndTime)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to generate indices: %w", err)
+	}
+
+	query := opensearch.BuildTraceAggregationQuery(params)
+	response, err := o.client.SearchWithAggregation(ctx, indices, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to aggregate trace buckets: %w", err)
+	}
+
+	buckets := make([]TraceBucket, len(response.Aggregations.Traces.Buckets))
+	for i, bucket := range response.Aggregations.Traces.Buckets {
+		buckets[i] = TraceBucket{TraceID: bucket.Key, DocCount: bucket.DocCount}
+	}
+	return buckets, response.Aggregations.TotalTraces.Value, nil
+}
+
+func (o *opensearchSpanStore) HealthCheck(ctx context.Context) error {
+	return o.client.HealthCheck(ctx)
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
+)
+
+// tempoHTTPTimeout bounds every request tempoSpanStore makes to Tempo's
+// query API.
+const tempoHTTPTimeout = 30 * time.Second
+
+// tempoSpanStore adapts Grafana Tempo's HTTP query API (GET /api/search,
+// GET /api/traces/{traceID}) to SpanStore, for users who already run Tempo
+// as their tracing backend. Like jaegerSpanStore, it does not implement
+// advancedSpanStore: Tempo's search API has no composite span-metrics
+// aggregation, search_after pagination, or TraceQL span-level pre-filter.
+type tempoSpanStore struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewTempoSpanStore returns a SpanStore backed by baseURL, Tempo's query
+// frontend address, e.g. "http://tempo:3200".
+func NewTempoSpanStore(baseURL string) SpanStore {
+	return &tempoSpanStore{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: tempoHTTPTimeout},
+	}
+}
+
+func (t *tempoSpanStore) SearchSpans(ctx context.Context, params opensearch.TraceQueryParams) ([]opensearch.Span, error) {
+	summaries, err := t.search(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var spans []opensearch.Span
+	for _, summary := range summaries {
+		traceSpans, err := t.fetchTrace(ctx, summary.TraceID)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, traceSpans...)
+	}
+	return spans, nil
+}
+
+func (t *tempoSpanStore) SearchTraceIDs(ctx context.Context, params opensearch.TraceQueryParams) ([]string, error) {
+	summaries, err := t.search(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(summaries))
+	for i, summary := range summaries {
+		ids[i] = summary.TraceID
+	}
+	return ids, nil
+}
+
+func (t *tempoSpanStore) FetchSpansByTraceIDs(ctx context.Context, traceIDs []string, componentUid, environmentUid string, rootOnly bool) ([]opensearch.Span, error) {
+	var spans []opensearch.Span
+	for _, traceID := range traceIDs {
+		traceSpans, err := t.fetchTrace(ctx, traceID)
+		if err != nil {
+			return nil, err
+		}
+		if rootOnly {
+			traceSpans = rootSpansOnly(traceSpans)
+		}
+		spans = append(spans, traceSpans...)
+	}
+	return spans, nil
+}
+
+func (t *tempoSpanStore) AggregateTraceBuckets(ctx context.Context, params opensearch.TraceQueryParams) ([]TraceBucket, int, error) {
+	summaries, err := t.search(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	buckets := make([]TraceBucket, len(summaries))
+	for i, summary := range summaries {
+		buckets[i] = TraceBucket{TraceID: summary.TraceID, DocCount: summary.SpanCount}
+	}
+	return buckets, len(buckets), nil
+}
+
+func (t *tempoSpanStore) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.baseURL+"/ready", nil)
+	if err != nil {
+		return fmt.Errorf("failed to build tempo health check request: %w", err)
+	}
+	res, err := t.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("tempo unreachable: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("tempo not ready: status %s", res.Status)
+	}
+	return nil
+}
+
+// tempoTraceSummary is one entry of GET /api/search's "traces" array.
+type tempoTraceSummary struct {
+	TraceID   string `json:"traceID"`
+	SpanCount int    `json:"spanCount"`
+}
+
+// search calls Tempo's GET /api/search, scoped by component/environment
+// (passed as space-separated key=value pairs in the tags parameter) and
+// params' time range.
+func (t *tempoSpanStore) search(ctx context.Context, params opensearch.TraceQueryParams) ([]tempoTraceSummary, error) {
+	startTime, endTime, err := parseTraceQueryTimeRange(params.StartTime, params.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := params.Offset + params.Limit
+	if limit <= 0 {
+		limit = DefaultTracesLimit
+	}
+
+	query := url.Values{}
+	query.Set("tags", fmt.Sprintf("component.uid=%s environment.uid=%s", params.ComponentUid, params.EnvironmentUid))
+	query.Set("start", strconv.FormatInt(startTime.Unix(), 10))
+	query.Set("end", strconv.FormatInt(endTime.Unix(), 10))
+	query.Set("limit", strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.baseURL+"/api/search?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tempo search request: %w", err)
+	}
+
+	res, err := t.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("tempo search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tempo search request failed with status: %s", res.Status)
+	}
+
+	var body struct {
+		Traces []tempoTraceSummary `json:"traces"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode tempo search response: %w", err)
+	}
+	return body.Traces, nil
+}
+
+// fetchTrace calls Tempo's GET /api/traces/{traceID}, which returns the
+// trace as an OTLP/JSON ExportTraceServiceRequest envelope - the same
+// shape otlpTraceEncoder writes for ExportFormatOTLPJSON - and converts it
+// back into this service's opensearch.Span shape via fromOTLPSpan.
+func (t *tempoSpanStore) fetchTrace(ctx context.Context, traceID string) ([]opensearch.Span, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.baseURL+"/api/traces/"+traceID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tempo trace request: %w", err)
+	}
+
+	res, err := t.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("tempo trace request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tempo trace request failed with status: %s", res.Status)
+	}
+
+	var envelope otlpExportEnvelope
+	if err := json.NewDecoder(res.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode tempo trace response: %w", err)
+	}
+
+	var spans []opensearch.Span
+	for _, resourceSpans := range envelope.ResourceSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				osSpan, err := fromOTLPSpan(span, traceID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert tempo span: %w", err)
+				}
+				spans = append(spans, osSpan)
+			}
+		}
+	}
+	return spans, nil
+}
@@ -14,9 +14,15 @@
 // specific language governing permissions and limitations
 // under the License.
 
+// Package handlers implements the trace API against api/openapi/v1/traces.yaml.
+// Request parsing and validation below is still hand-rolled query.Get +
+// strconv parsing rather than generated from the spec: that migration
+// (Handler implementing api/gen/v1's ServerInterface) lands once that
+// package's generate.go has been run against the spec.
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,21 +31,49 @@ import (
 	"strconv"
 	"time"
 
+	openapiv1 "github.com/wso2/ai-agent-management-platform/traces-observer-service/api/openapi/v1"
 	"github.com/wso2/ai-agent-management-platform/traces-observer-service/controllers"
 	"github.com/wso2/ai-agent-management-platform/traces-observer-service/middleware/logger"
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/middleware/ratelimit"
 	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
 )
 
+// traceStreamHeartbeatInterval bounds how long a trace stream connection can
+// go quiet before GetTraceStream writes a comment line to keep any
+// intermediate proxy from timing out the connection.
+const traceStreamHeartbeatInterval = 15 * time.Second
+
 // Handler handles HTTP requests for tracing
 type Handler struct {
 	controllers *controllers.TracingController
+	rateLimiter *ratelimit.Limiter
 }
 
-// NewHandler creates a new handler
-func NewHandler(controllers *controllers.TracingController) *Handler {
+// NewHandler creates a new handler. rateLimiter may be nil, in which case
+// requests are never throttled.
+func NewHandler(controllers *controllers.TracingController, rateLimiter *ratelimit.Limiter) *Handler {
 	return &Handler{
 		controllers: controllers,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// checkRateLimit enforces bucket for r, writing a 429 response and
+// reporting false if the caller has exceeded it. Callers should return
+// immediately when this returns false.
+func (h *Handler) checkRateLimit(w http.ResponseWriter, r *http.Request, bucket ratelimit.Bucket) bool {
+	if h.rateLimiter == nil {
+		return true
 	}
+
+	allowed, retryAfter := h.rateLimiter.AllowRequest(r, bucket)
+	if allowed {
+		return true
+	}
+
+	w.Header().Set("Retry-After", ratelimit.FormatRetryAfter(retryAfter))
+	h.writeError(w, http.StatusTooManyRequests, "Too many requests, please retry later")
+	return false
 }
 
 // TraceRequest represents the request body for getting traces
@@ -148,6 +182,10 @@ func (h *Handler) GetTraceOverviews(w http.ResponseWriter, r *http.Request) {
 
 // GetTraceByIdAndService handles GET /api/trace with query parameters
 func (h *Handler) GetTraceByIdAndService(w http.ResponseWriter, r *http.Request) {
+	if !h.checkRateLimit(w, r, ratelimit.BucketCheap) {
+		return
+	}
+
 	// Get logger from context
 	log := logger.GetLogger(r.Context())
 
@@ -223,6 +261,10 @@ func (h *Handler) GetTraceByIdAndService(w http.ResponseWriter, r *http.Request)
 
 // ExportTraces handles GET /api/traces/export with query parameters
 func (h *Handler) ExportTraces(w http.ResponseWriter, r *http.Request) {
+	if !h.checkRateLimit(w, r, ratelimit.BucketExpensive) {
+		return
+	}
+
 	// Get logger from context
 	log := logger.GetLogger(r.Context())
 
@@ -291,6 +333,11 @@ func (h *Handler) ExportTraces(w http.ResponseWriter, r *http.Request) {
 		SortOrder:      sortOrder,
 	}
 
+	if format := query.Get("format"); format == "ndjson" || format == "otlp-json" {
+		h.writeStreamExport(w, r, log, params, controllers.ExportFormat(format))
+		return
+	}
+
 	// Execute query
 	ctx := r.Context()
 	result, err := h.controllers.ExportTraces(ctx, params)
@@ -317,6 +364,10 @@ func (h *Handler) ExportTraces(w http.ResponseWriter, r *http.Request) {
 // GetTraceOverviewsV2 handles GET /api/v2/traces with query parameters
 // Same interface as v1 but uses OpenSearch aggregations for proper trace-level grouping
 func (h *Handler) GetTraceOverviewsV2(w http.ResponseWriter, r *http.Request) {
+	if !h.checkRateLimit(w, r, ratelimit.BucketExpensive) {
+		return
+	}
+
 	log := logger.GetLogger(r.Context())
 
 	query := r.URL.Query()
@@ -386,6 +437,138 @@ func (h *Handler) GetTraceOverviewsV2(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, result)
 }
 
+// SearchTracesQLRequest is the request body for POST /api/v2/traces/search-ql.
+type SearchTracesQLRequest struct {
+	Query          string `json:"query"`
+	ComponentUid   string `json:"componentUid"`
+	EnvironmentUid string `json:"environmentUid"`
+	StartTime      string `json:"startTime"`
+	EndTime        string `json:"endTime"`
+	Limit          int    `json:"limit,omitempty"`
+}
+
+// SearchTracesQL handles POST /api/v2/traces/search-ql, alongside
+// GetTraceOverviewsV2: query is a TraceQL-like expression (e.g.
+// "{ span.http.status_code >= 500 && duration > 2s } | count() > 3")
+// evaluated by controllers.TracingController.SearchTracesQL.
+func (h *Handler) SearchTracesQL(w http.ResponseWriter, r *http.Request) {
+	if !h.checkRateLimit(w, r, ratelimit.BucketExpensive) {
+		return
+	}
+
+	log := logger.GetLogger(r.Context())
+
+	var req SearchTracesQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid JSON request body")
+		return
+	}
+
+	if req.Query == "" {
+		h.writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+	if req.ComponentUid == "" {
+		h.writeError(w, http.StatusBadRequest, "componentUid is required")
+		return
+	}
+	if req.EnvironmentUid == "" {
+		h.writeError(w, http.StatusBadRequest, "environmentUid is required")
+		return
+	}
+
+	params := opensearch.TraceQueryParams{
+		ComponentUid:   req.ComponentUid,
+		EnvironmentUid: req.EnvironmentUid,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		Limit:          req.Limit,
+	}
+
+	result, err := h.controllers.SearchTracesQL(r.Context(), req.Query, params)
+	if err != nil {
+		log.Error("Failed to search traces by TraceQL query", "query", req.Query, "error", err)
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Failed to search traces: %v", err))
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// SpanMetricsQueryRequest is the request body for POST /api/v2/metrics/query_range.
+type SpanMetricsQueryRequest struct {
+	ComponentUid     string                            `json:"componentUid"`
+	EnvironmentUid   string                            `json:"environmentUid"`
+	StartTime        string                            `json:"startTime"`
+	EndTime          string                            `json:"endTime"`
+	SpanKind         string                            `json:"spanKind,omitempty"`
+	AttributeFilters []opensearch.AttributeFilter      `json:"attributeFilters,omitempty"`
+	Aggregation      opensearch.SpanMetricsAggregation `json:"aggregation"`
+	Field            string                            `json:"field,omitempty"`
+	Quantiles        []float64                         `json:"quantiles,omitempty"`
+	GroupBy          []string                          `json:"groupBy,omitempty"`
+	Step             string                            `json:"step"`
+}
+
+// QuerySpanMetrics handles POST /api/v2/metrics/query_range: a
+// TraceQL-metrics-style range query (rate()/count_over_time()/
+// quantile_over_time()/sum_over_time()) evaluated server-side by
+// controllers.TracingController.QuerySpanMetrics, returning a time series
+// per distinct GroupBy label combination rather than raw spans.
+func (h *Handler) QuerySpanMetrics(w http.ResponseWriter, r *http.Request) {
+	if !h.checkRateLimit(w, r, ratelimit.BucketExpensive) {
+		return
+	}
+
+	log := logger.GetLogger(r.Context())
+
+	var req SpanMetricsQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid JSON request body")
+		return
+	}
+
+	if req.ComponentUid == "" {
+		h.writeError(w, http.StatusBadRequest, "componentUid is required")
+		return
+	}
+	if req.EnvironmentUid == "" {
+		h.writeError(w, http.StatusBadRequest, "environmentUid is required")
+		return
+	}
+	if req.Aggregation == "" {
+		h.writeError(w, http.StatusBadRequest, "aggregation is required")
+		return
+	}
+	if req.Step == "" {
+		h.writeError(w, http.StatusBadRequest, "step is required")
+		return
+	}
+
+	params := opensearch.SpanMetricsParams{
+		ComponentUid:     req.ComponentUid,
+		EnvironmentUid:   req.EnvironmentUid,
+		StartTime:        req.StartTime,
+		EndTime:          req.EndTime,
+		SpanKind:         req.SpanKind,
+		AttributeFilters: req.AttributeFilters,
+		Aggregation:      req.Aggregation,
+		Field:            req.Field,
+		Quantiles:        req.Quantiles,
+		GroupBy:          req.GroupBy,
+		Step:             req.Step,
+	}
+
+	result, err := h.controllers.QuerySpanMetrics(r.Context(), params)
+	if err != nil {
+		log.Error("Failed to query span metrics", "error", err)
+		h.writeError(w, http.StatusInternalServerError, "Failed to query span metrics")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
+
 // GetTraceByIdAndServiceV2 handles GET /api/v2/trace with query parameters
 // Same interface as v1, plus parentSpan filter
 func (h *Handler) GetTraceByIdAndServiceV2(w http.ResponseWriter, r *http.Request) {
@@ -453,6 +636,10 @@ func (h *Handler) GetTraceByIdAndServiceV2(w http.ResponseWriter, r *http.Reques
 // ExportTracesV2 handles GET /api/v2/traces/export with query parameters
 // Same interface as v1 but uses aggregation for proper trace grouping and supports pagination
 func (h *Handler) ExportTracesV2(w http.ResponseWriter, r *http.Request) {
+	if !h.checkRateLimit(w, r, ratelimit.BucketExpensive) {
+		return
+	}
+
 	log := logger.GetLogger(r.Context())
 
 	query := r.URL.Query()
@@ -514,6 +701,11 @@ func (h *Handler) ExportTracesV2(w http.ResponseWriter, r *http.Request) {
 		SortOrder:      sortOrder,
 	}
 
+	if format := query.Get("format"); format == "ndjson" || format == "otlp-json" {
+		h.writeStreamExport(w, r, log, params, controllers.ExportFormat(format))
+		return
+	}
+
 	ctx := r.Context()
 	result, err := h.controllers.ExportTracesV2(ctx, params)
 	if err != nil {
@@ -533,8 +725,220 @@ func (h *Handler) ExportTracesV2(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, result)
 }
 
+// AttributeFilterRequest is the wire shape of one entry in
+// SearchTracesRequest.Attributes, e.g. {"key": "llm.model", "op": "eq",
+// "value": "gpt-4o"}. Op defaults to "eq" when omitted.
+type AttributeFilterRequest struct {
+	Key   string `json:"key"`
+	Op    string `json:"op,omitempty"`
+	Value string `json:"value"`
+}
+
+// SearchTracesRequest is the request body for POST /api/v2/traces/search.
+type SearchTracesRequest struct {
+	ComponentUid       string                   `json:"componentUid"`
+	EnvironmentUid     string                   `json:"environmentUid"`
+	StartTime          string                   `json:"startTime"`
+	EndTime            string                   `json:"endTime"`
+	Attributes         []AttributeFilterRequest `json:"attributes,omitempty"`
+	MinDurationInNanos int64                    `json:"minDurationInNanos,omitempty"`
+	MaxDurationInNanos int64                    `json:"maxDurationInNanos,omitempty"`
+	StatusCode         string                   `json:"statusCode,omitempty"`
+	RootSpanNameGlob   string                   `json:"rootSpanNameGlob,omitempty"`
+	EventText          string                   `json:"eventText,omitempty"`
+	Limit              int                      `json:"limit,omitempty"`
+	SortOrder          string                   `json:"sortOrder,omitempty"`
+	Cursor             string                   `json:"cursor,omitempty"`
+}
+
+// SearchTraces handles POST /api/v2/traces/search: a richer alternative to
+// GetTraceOverviews/GetTraceOverviewsV2 that filters on span attributes,
+// duration, status, and root span name/event text, and paginates via an
+// opaque cursor instead of offset so deep pages stay stable as new traces
+// are ingested.
+func (h *Handler) SearchTraces(w http.ResponseWriter, r *http.Request) {
+	if !h.checkRateLimit(w, r, ratelimit.BucketExpensive) {
+		return
+	}
+
+	log := logger.GetLogger(r.Context())
+
+	var req SearchTracesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid JSON request body")
+		return
+	}
+
+	if req.ComponentUid == "" {
+		h.writeError(w, http.StatusBadRequest, "componentUid is required")
+		return
+	}
+	if req.EnvironmentUid == "" {
+		h.writeError(w, http.StatusBadRequest, "environmentUid is required")
+		return
+	}
+
+	sortOrder := req.SortOrder
+	if sortOrder == "" {
+		sortOrder = "desc"
+	}
+	if sortOrder != "asc" && sortOrder != "desc" {
+		h.writeError(w, http.StatusBadRequest, "sortOrder must be 'asc' or 'desc'")
+		return
+	}
+
+	if req.StatusCode != "" && req.StatusCode != "OK" && req.StatusCode != "ERROR" {
+		h.writeError(w, http.StatusBadRequest, "statusCode must be 'OK' or 'ERROR'")
+		return
+	}
+
+	var searchAfter []interface{}
+	if req.Cursor != "" {
+		decoded, err := controllers.DecodeSearchCursor(req.Cursor)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		searchAfter = decoded
+	}
+
+	attributeFilters := make([]opensearch.AttributeFilter, 0, len(req.Attributes))
+	for _, a := range req.Attributes {
+		if a.Key == "" {
+			h.writeError(w, http.StatusBadRequest, "attribute filters require a key")
+			return
+		}
+		op := opensearch.AttributeFilterOp(a.Op)
+		switch op {
+		case opensearch.AttributeFilterEqual, opensearch.AttributeFilterPrefix, opensearch.AttributeFilterRegex:
+		case "":
+			op = opensearch.AttributeFilterEqual
+		default:
+			h.writeError(w, http.StatusBadRequest, "attribute filter op must be 'eq', 'prefix', or 'regex'")
+			return
+		}
+		attributeFilters = append(attributeFilters, opensearch.AttributeFilter{
+			Key:   a.Key,
+			Op:    op,
+			Value: a.Value,
+		})
+	}
+
+	params := opensearch.TraceSearchParams{
+		ComponentUid:       req.ComponentUid,
+		EnvironmentUid:     req.EnvironmentUid,
+		StartTime:          req.StartTime,
+		EndTime:            req.EndTime,
+		AttributeFilters:   attributeFilters,
+		MinDurationInNanos: req.MinDurationInNanos,
+		MaxDurationInNanos: req.MaxDurationInNanos,
+		StatusCode:         req.StatusCode,
+		RootSpanNameGlob:   req.RootSpanNameGlob,
+		EventText:          req.EventText,
+		Limit:              req.Limit,
+		SortOrder:          sortOrder,
+		SearchAfter:        searchAfter,
+	}
+
+	result, err := h.controllers.SearchTraces(r.Context(), params)
+	if err != nil {
+		log.Error("Failed to search traces", "error", err)
+		h.writeError(w, http.StatusInternalServerError, "Failed to search traces")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// GetTraceStream handles GET /api/v2/traces/stream
+// Keeps the connection open and pushes newly ingested traces matching
+// componentUid+environmentUid as Server-Sent Events until the client
+// disconnects. Supports Last-Event-ID to resume from the cursor a
+// reconnecting client last saw.
+func (h *Handler) GetTraceStream(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetLogger(r.Context())
+
+	query := r.URL.Query()
+
+	componentUid := query.Get("componentUid")
+	if componentUid == "" {
+		h.writeError(w, http.StatusBadRequest, "componentUid is required")
+		return
+	}
+
+	environmentUid := query.Get("environmentUid")
+	if environmentUid == "" {
+		h.writeError(w, http.StatusBadRequest, "environmentUid is required")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(w, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	// Traces don't have a monotonic sequence number, so Last-Event-ID is
+	// treated as the tail cursor's starting timestamp instead.
+	startTime := r.Header.Get("Last-Event-ID")
+
+	params := opensearch.TraceQueryParams{
+		ComponentUid:   componentUid,
+		EnvironmentUid: environmentUid,
+		StartTime:      startTime,
+	}
+
+	ctx := r.Context()
+	ch := make(chan opensearch.TraceOverview)
+	go func() {
+		if err := h.controllers.StreamTraces(ctx, params, ch); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error("Trace stream ended with error", "error", err)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(traceStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case trace, ok := <-ch:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(trace)
+			if err != nil {
+				log.Error("Failed to marshal streamed trace", "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: trace\ndata: %s\n\n", trace.StartTime, data); err != nil {
+				log.Error("Failed to write trace event", "error", err)
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				log.Error("Failed to write heartbeat", "error", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
 // Health handles GET /health
 func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
+	if !h.checkRateLimit(w, r, ratelimit.BucketCheap) {
+		return
+	}
+
 	// Get logger from context
 	log := logger.GetLogger(r.Context())
 
@@ -548,10 +952,82 @@ func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, map[string]string{
+	body := map[string]interface{}{
 		"status":    "healthy",
 		"timestamp": time.Now().Format(time.RFC3339),
-	})
+	}
+	if h.rateLimiter != nil {
+		body["rateLimiterUsage"] = h.rateLimiter.Snapshot()
+	}
+
+	h.writeJSON(w, http.StatusOK, body)
+}
+
+// GetOpenAPISpec handles GET /openapi.yaml
+// Serves api/openapi/v1/traces.yaml, the source of truth these handlers are
+// validated against once api/gen/v1's generated ServerInterface lands (see
+// that package's generate.go).
+func (h *Handler) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(openapiv1.Spec)
+}
+
+// GetDocs handles GET /docs
+// Serves a Swagger UI page pointed at /openapi.yaml.
+func (h *Handler) GetDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(swaggerUIPage))
+}
+
+const swaggerUIPage = `<!doctype html>
+<html>
+<head>
+  <title>Traces Observer API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({
+        url: "/openapi.yaml",
+        dom_id: "#swagger-ui",
+      });
+    };
+  </script>
+</body>
+</html>
+`
+
+// writeStreamExport streams params' matching traces to w via
+// controllers.StreamExportTraces in the given format, instead of buffering
+// the whole export into one JSON response body like writeJSON does. The
+// handler never sets Content-Length and flushes incrementally, so Go's
+// net/http server sends the response with Transfer-Encoding: chunked
+// automatically.
+func (h *Handler) writeStreamExport(w http.ResponseWriter, r *http.Request, log *slog.Logger, params opensearch.TraceQueryParams, format controllers.ExportFormat) {
+	timestamp := time.Now().Format("20060102-150405")
+	contentType := "application/x-ndjson"
+	extension := "ndjson"
+	if format == controllers.ExportFormatOTLPJSON {
+		contentType = "application/json"
+		extension = "json"
+	}
+
+	filename := fmt.Sprintf("traces-export-%s.%s", timestamp, extension)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	w.WriteHeader(http.StatusOK)
+
+	if err := h.controllers.StreamExportTraces(r.Context(), params, w, format); err != nil {
+		log.Error("Failed to stream trace export", "format", format, "error", err)
+	}
 }
 
 // Helper functions
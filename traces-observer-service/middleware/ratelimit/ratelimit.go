@@ -0,0 +1,339 @@
+// Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package ratelimit provides per-caller HTTP rate limiting for the
+// expensive trace query and export endpoints.
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Bucket names which configured rate.Limiter tier a request falls under.
+type Bucket string
+
+const (
+	// BucketCheap is for cheap, frequently-polled endpoints like
+	// GetTraceByIdAndService and Health.
+	BucketCheap Bucket = "cheap"
+	// BucketExpensive is for endpoints that do heavy OpenSearch aggregation
+	// or export work, like ExportTraces, ExportTracesV2, and
+	// GetTraceOverviewsV2.
+	BucketExpensive Bucket = "expensive"
+)
+
+// BucketConfig configures one bucket's rate.Limiter.
+type BucketConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// Config configures both rate limiting buckets. Read from this service's
+// config file alongside config.OpenSearchConfig.
+type Config struct {
+	Cheap     BucketConfig
+	Expensive BucketConfig
+
+	// TrustedProxyCIDRs lists the CIDR ranges of this service's own
+	// ingress/load balancer. X-Forwarded-For is only honored when a
+	// request's RemoteAddr falls inside one of these ranges; otherwise a
+	// caller connecting directly could claim any X-Forwarded-For value it
+	// likes and dodge its real per-IP limit entirely. Left empty,
+	// X-Forwarded-For is never trusted and every caller is identified by
+	// RemoteAddr.
+	TrustedProxyCIDRs []string
+}
+
+// DefaultConfig returns conservative defaults for deployments that don't
+// override rate limiting in their config file.
+func DefaultConfig() Config {
+	return Config{
+		Cheap:     BucketConfig{RequestsPerSecond: 20, Burst: 40},
+		Expensive: BucketConfig{RequestsPerSecond: 2, Burst: 5},
+	}
+}
+
+// maxCallerLimiters bounds Limiter.limiters. Unlike a fixed-size config
+// bucket, caller is effectively attacker-controlled (it's derived from the
+// client IP, or from X-Forwarded-For when a trusted proxy set it), so
+// without a cap a flood of distinct callers would grow this map forever.
+// Once full, idle entries are swept on insert; if nothing is stale, the
+// least-recently-seen caller is evicted to make room.
+const maxCallerLimiters = 10000
+
+// callerLimiterIdleTTL is how long a caller's limiter pair survives without
+// a request before it's eligible for eviction.
+const callerLimiterIdleTTL = 30 * time.Minute
+
+type callerLimiters struct {
+	cheap      *rate.Limiter
+	expensive  *rate.Limiter
+	lastAccess time.Time
+}
+
+// Limiter enforces per-caller, per-bucket rate limits, lazily creating a
+// rate.Limiter pair for each caller identity it sees.
+type Limiter struct {
+	config         Config
+	trustedProxies []*net.IPNet
+
+	mu       sync.Mutex
+	limiters map[string]*callerLimiters
+}
+
+// New creates a Limiter that enforces config's buckets. CIDRs in
+// config.TrustedProxyCIDRs that fail to parse are logged nowhere and
+// simply ignored, since New has no logger to report them to; callers
+// should validate their own config at startup.
+func New(config Config) *Limiter {
+	var trustedProxies []*net.IPNet
+	for _, cidr := range config.TrustedProxyCIDRs {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			trustedProxies = append(trustedProxies, ipNet)
+		}
+	}
+	return &Limiter{
+		config:         config,
+		trustedProxies: trustedProxies,
+		limiters:       make(map[string]*callerLimiters),
+	}
+}
+
+func (l *Limiter) callerLimiter(caller string) *callerLimiters {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if cl, ok := l.limiters[caller]; ok {
+		cl.lastAccess = now
+		return cl
+	}
+
+	if len(l.limiters) >= maxCallerLimiters {
+		l.evictStaleLocked(now)
+	}
+	if len(l.limiters) >= maxCallerLimiters {
+		l.evictOldestLocked()
+	}
+
+	cl := &callerLimiters{
+		cheap:      rate.NewLimiter(rate.Limit(l.config.Cheap.RequestsPerSecond), l.config.Cheap.Burst),
+		expensive:  rate.NewLimiter(rate.Limit(l.config.Expensive.RequestsPerSecond), l.config.Expensive.Burst),
+		lastAccess: now,
+	}
+	l.limiters[caller] = cl
+	return cl
+}
+
+// evictStaleLocked removes every caller whose limiter has been idle for
+// longer than callerLimiterIdleTTL. l.mu must be held.
+func (l *Limiter) evictStaleLocked(now time.Time) {
+	for caller, cl := range l.limiters {
+		if now.Sub(cl.lastAccess) > callerLimiterIdleTTL {
+			delete(l.limiters, caller)
+		}
+	}
+}
+
+// evictOldestLocked removes the single least-recently-seen caller, used
+// when the map is full but nothing has aged past callerLimiterIdleTTL yet.
+// l.mu must be held.
+func (l *Limiter) evictOldestLocked() {
+	var oldest string
+	var oldestAccess time.Time
+	for caller, cl := range l.limiters {
+		if oldest == "" || cl.lastAccess.Before(oldestAccess) {
+			oldest = caller
+			oldestAccess = cl.lastAccess
+		}
+	}
+	if oldest != "" {
+		delete(l.limiters, oldest)
+	}
+}
+
+// Allow reports whether a request from caller is allowed under bucket right
+// now. When it isn't, the returned duration is how long the caller should
+// wait before its next attempt would succeed.
+func (l *Limiter) Allow(caller string, bucket Bucket) (bool, time.Duration) {
+	cl := l.callerLimiter(caller)
+
+	limiter := cl.cheap
+	if bucket == BucketExpensive {
+		limiter = cl.expensive
+	}
+
+	reservation := limiter.Reserve()
+	if !reservation.OK() {
+		// Burst can never accommodate this request even after waiting
+		// (e.g. Burst is 0); treat as rejected with no useful Retry-After.
+		return false, 0
+	}
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return false, delay
+	}
+	return true, 0
+}
+
+// AllowRequest is Allow, but derives the caller identity from r the same
+// way Middleware does (the authenticated user ID set via WithUserID,
+// falling back to client IP). Handlers that can't be wrapped by Middleware
+// because they're registered directly as http.HandlerFunc values can call
+// this at the top of the handler instead.
+func (l *Limiter) AllowRequest(r *http.Request, bucket Bucket) (bool, time.Duration) {
+	return l.Allow(l.callerIdentity(r), bucket)
+}
+
+// Usage is a point-in-time snapshot of one caller's available tokens, for
+// surfacing in Health output.
+type Usage struct {
+	Caller              string  `json:"caller"`
+	CheapTokensAvail    float64 `json:"cheapTokensAvailable"`
+	ExpensiveTokensAvail float64 `json:"expensiveTokensAvailable"`
+}
+
+// Snapshot returns current token availability for every caller identity the
+// Limiter has seen so far. A caller idle long enough naturally refills to
+// full burst, so this is most useful for spotting who is actively being
+// throttled.
+func (l *Limiter) Snapshot() []Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	usage := make([]Usage, 0, len(l.limiters))
+	for caller, cl := range l.limiters {
+		usage = append(usage, Usage{
+			Caller:               caller,
+			CheapTokensAvail:     cl.cheap.TokensAt(now),
+			ExpensiveTokensAvail: cl.expensive.TokensAt(now),
+		})
+	}
+	return usage
+}
+
+// errorResponse mirrors handlers.ErrorResponse's JSON shape. It's
+// duplicated here rather than imported to keep this package below
+// handlers in the dependency graph.
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Middleware enforces bucket's rate limit per caller, identified by the
+// authenticated user ID set on the request context via WithUserID, falling
+// back to the client IP when none is set. Requests exceeding the burst get
+// HTTP 429 with a Retry-After header and a JSON errorResponse body instead
+// of reaching next.
+func Middleware(limiter *Limiter, bucket Bucket) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			caller := limiter.callerIdentity(r)
+
+			allowed, retryAfter := limiter.Allow(caller, bucket)
+			if !allowed {
+				w.Header().Set("Retry-After", FormatRetryAfter(retryAfter))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_ = json.NewEncoder(w).Encode(errorResponse{
+					Error:   "rate_limited",
+					Message: "Too many requests, please retry later",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type contextKey string
+
+const userIDContextKey contextKey = "ratelimit.userID"
+
+// WithUserID returns a copy of ctx carrying userID as the caller identity
+// Middleware should rate limit against, instead of falling back to the
+// client IP.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDContextKey, userID)
+}
+
+func (l *Limiter) callerIdentity(r *http.Request) string {
+	if userID, ok := r.Context().Value(userIDContextKey).(string); ok && userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + l.clientIP(r)
+}
+
+// clientIP extracts the caller's IP, honoring the first hop of
+// X-Forwarded-For only when RemoteAddr itself is a configured trusted
+// proxy (this service's own ingress); otherwise a caller connecting
+// directly could put any value it likes in that header and rate limit
+// under someone else's identity instead of its own. Falls back to
+// RemoteAddr when X-Forwarded-For isn't trusted or isn't present.
+func (l *Limiter) clientIP(r *http.Request) string {
+	remoteHost, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteHost = r.RemoteAddr
+	}
+
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" && l.isTrustedProxy(remoteHost) {
+		if parts := strings.Split(fwd, ","); len(parts) > 0 {
+			if ip := strings.TrimSpace(parts[0]); ip != "" {
+				return ip
+			}
+		}
+	}
+	return remoteHost
+}
+
+// isTrustedProxy reports whether host falls inside one of l's configured
+// TrustedProxyCIDRs.
+func (l *Limiter) isTrustedProxy(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range l.trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatRetryAfter renders d as a whole-second Retry-After header value,
+// rounding up so a sub-second delay never collapses to "0" (which a client
+// would read as "retry immediately").
+func FormatRetryAfter(d time.Duration) string {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.Itoa(seconds)
+}
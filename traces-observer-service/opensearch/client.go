@@ -19,10 +19,13 @@ package opensearch
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/opensearch-project/opensearch-go"
 	"github.com/opensearch-project/opensearch-go/opensearchapi"
@@ -34,22 +37,33 @@ import (
 type Client struct {
 	client *opensearch.Client
 	config *config.OpenSearchConfig
+
+	// breaker is this client's per-node circuit breaker: NewClient only
+	// ever dials cfg.Address, a single node, so one breaker covers every
+	// request that goes through doWithRetry (Search, SearchWithAggregation,
+	// SearchRootSpans, SearchSpanCounts, MultiSearch). The PIT, sliced,
+	// span-metrics and health-check requests bypass it.
+	breaker        *circuitBreaker
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
 }
 
-// NewClient creates a new OpenSearch client
+// NewClient creates a new OpenSearch client. Authentication is determined by
+// cfg.AuthMode via TransportProviderFromConfig - basic auth (the default),
+// AWS SigV4, mTLS, or an OpenSearch API key - so callers don't need to
+// change anything to pick up a different scheme; only config changes.
 func NewClient(cfg *config.OpenSearchConfig) (*Client, error) {
-	// Create HTTP transport with TLS verification disabled
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: true,
-		},
+	provider, err := TransportProviderFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure OpenSearch transport: %w", err)
 	}
 
 	opensearchConfig := opensearch.Config{
 		Addresses: []string{cfg.Address},
-		Transport: transport,
-		Username:  cfg.Username,
-		Password:  cfg.Password,
+	}
+	if err := provider.Apply(&opensearchConfig); err != nil {
+		return nil, fmt.Errorf("failed to apply OpenSearch transport: %w", err)
 	}
 
 	client, err := opensearch.NewClient(opensearchConfig)
@@ -69,29 +83,51 @@ func NewClient(cfg *config.OpenSearchConfig) (*Client, error) {
 	// Set package-level default span query limit from config
 	SetDefaultSpanQueryLimit(cfg.DefaultSpanQueryLimit)
 
+	maxRetries := cfg.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	retryBaseDelay := time.Duration(cfg.RetryBaseDelayMs) * time.Millisecond
+	if retryBaseDelay <= 0 {
+		retryBaseDelay = defaultRetryBaseDelay
+	}
+	retryMaxDelay := time.Duration(cfg.RetryMaxDelayMs) * time.Millisecond
+	if retryMaxDelay <= 0 {
+		retryMaxDelay = defaultRetryMaxDelay
+	}
+	breakerCooldown := time.Duration(cfg.CircuitBreakerCooldownSeconds) * time.Second
+	if breakerCooldown <= 0 {
+		breakerCooldown = defaultCircuitBreakerCooldown
+	}
+
 	return &Client{
-		client: client,
-		config: cfg,
+		client:         client,
+		config:         cfg,
+		breaker:        newCircuitBreaker(cfg.CircuitBreakerThreshold, breakerCooldown),
+		maxRetries:     maxRetries,
+		retryBaseDelay: retryBaseDelay,
+		retryMaxDelay:  retryMaxDelay,
 	}, nil
 }
 
 // Search executes a search query against one or more indices
 func (c *Client) Search(ctx context.Context, indices []string, query map[string]interface{}) (*SearchResponse, error) {
 	// Convert query to JSON
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+	encoded, err := json.Marshal(query)
+	if err != nil {
 		return nil, fmt.Errorf("failed to encode query: %w", err)
 	}
 
-	// Create search request with IgnoreUnavailable option
-	req := opensearchapi.SearchRequest{
-		Index:             indices,
-		Body:              &buf,
-		IgnoreUnavailable: opensearchapi.BoolPtr(true),
-	}
-
-	// Execute search
-	res, err := req.Do(ctx, c.client)
+	// Execute search, retrying on 429/5xx/timeout and short-circuiting via
+	// the circuit breaker
+	res, err := c.doWithRetry(ctx, "Search", func() (*opensearchapi.Response, error) {
+		req := opensearchapi.SearchRequest{
+			Index:             indices,
+			Body:              bytes.NewReader(encoded),
+			IgnoreUnavailable: opensearchapi.BoolPtr(true),
+		}
+		return req.Do(ctx, c.client)
+	})
 	if err != nil {
 		log := logger.GetLogger(ctx)
 		log.Error("Search request failed", "error", err)
@@ -119,18 +155,19 @@ func (c *Client) Search(ctx context.Context, indices []string, query map[string]
 
 // SearchWithAggregation executes a search query and returns the aggregation response
 func (c *Client) SearchWithAggregation(ctx context.Context, indices []string, query map[string]interface{}) (*AggregationResponse, error) {
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+	encoded, err := json.Marshal(query)
+	if err != nil {
 		return nil, fmt.Errorf("failed to encode query: %w", err)
 	}
 
-	req := opensearchapi.SearchRequest{
-		Index:             indices,
-		Body:              &buf,
-		IgnoreUnavailable: opensearchapi.BoolPtr(true),
-	}
-
-	res, err := req.Do(ctx, c.client)
+	res, err := c.doWithRetry(ctx, "SearchWithAggregation", func() (*opensearchapi.Response, error) {
+		req := opensearchapi.SearchRequest{
+			Index:             indices,
+			Body:              bytes.NewReader(encoded),
+			IgnoreUnavailable: opensearchapi.BoolPtr(true),
+		}
+		return req.Do(ctx, c.client)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("aggregation request failed: %w", err)
 	}
@@ -155,18 +192,19 @@ func (c *Client) SearchWithAggregation(ctx context.Context, indices []string, qu
 
 // SearchRootSpans executes a search_after query and returns root spans with sort values and aggregations
 func (c *Client) SearchRootSpans(ctx context.Context, indices []string, query map[string]interface{}) (*RootSpanSearchResponse, error) {
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+	encoded, err := json.Marshal(query)
+	if err != nil {
 		return nil, fmt.Errorf("failed to encode query: %w", err)
 	}
 
-	req := opensearchapi.SearchRequest{
-		Index:             indices,
-		Body:              &buf,
-		IgnoreUnavailable: opensearchapi.BoolPtr(true),
-	}
-
-	res, err := req.Do(ctx, c.client)
+	res, err := c.doWithRetry(ctx, "SearchRootSpans", func() (*opensearchapi.Response, error) {
+		req := opensearchapi.SearchRequest{
+			Index:             indices,
+			Body:              bytes.NewReader(encoded),
+			IgnoreUnavailable: opensearchapi.BoolPtr(true),
+		}
+		return req.Do(ctx, c.client)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("root span search request failed: %w", err)
 	}
@@ -192,6 +230,181 @@ func (c *Client) SearchRootSpans(ctx context.Context, indices []string, query ma
 
 // SearchSpanCounts executes a span count aggregation query for specific trace IDs
 func (c *Client) SearchSpanCounts(ctx context.Context, indices []string, query map[string]interface{}) (*SpanCountResponse, error) {
+	encoded, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := c.doWithRetry(ctx, "SearchSpanCounts", func() (*opensearchapi.Response, error) {
+		req := opensearchapi.SearchRequest{
+			Index:             indices,
+			Body:              bytes.NewReader(encoded),
+			IgnoreUnavailable: opensearchapi.BoolPtr(true),
+		}
+		return req.Do(ctx, c.client)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("span count request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("span count request failed with status: %s", res.Status())
+	}
+
+	var response SpanCountResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode span count response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// MultiSearchRequest is one query MultiSearch batches into a single
+// _msearch round trip.
+type MultiSearchRequest struct {
+	Indices []string
+	Query   map[string]interface{}
+}
+
+// MultiSearch batches requests into a single OpenSearch _msearch call and
+// returns one SearchResponse per request, in the same order - so callers
+// that would otherwise issue one per-trace-ID query at a time (e.g.
+// span-count lookups) can collapse them into one round trip.
+func (c *Client) MultiSearch(ctx context.Context, requests []MultiSearchRequest) ([]*SearchResponse, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, r := range requests {
+		header := map[string]interface{}{}
+		if len(r.Indices) > 0 {
+			header["index"] = r.Indices
+		}
+		if err := encoder.Encode(header); err != nil {
+			return nil, fmt.Errorf("failed to encode msearch header: %w", err)
+		}
+		if err := encoder.Encode(r.Query); err != nil {
+			return nil, fmt.Errorf("failed to encode msearch query: %w", err)
+		}
+	}
+	encoded := buf.Bytes()
+
+	msearchBatchSize.Observe(float64(len(requests)))
+
+	res, err := c.doWithRetry(ctx, "MultiSearch", func() (*opensearchapi.Response, error) {
+		req := opensearchapi.MsearchRequest{Body: bytes.NewReader(encoded)}
+		return req.Do(ctx, c.client)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("msearch request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("msearch request failed with status: %s", res.Status())
+	}
+
+	var body struct {
+		Responses []SearchResponse `json:"responses"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode msearch response: %w", err)
+	}
+
+	responses := make([]*SearchResponse, len(body.Responses))
+	for i := range body.Responses {
+		responses[i] = &body.Responses[i]
+	}
+	return responses, nil
+}
+
+// SearchRootSpansPIT is SearchRootSpans against a point-in-time context
+// instead of a live index pattern, for callers that need their cursor-based
+// pagination (see TracingController.SearchTraces) to survive index rollover
+// across a long-lived scan rather than re-resolving which indices match on
+// every page.
+func (c *Client) SearchRootSpansPIT(ctx context.Context, pitID string, sliceID, maxSlices int, query map[string]interface{}) (*RootSpanSearchResponse, error) {
+	sliced := make(map[string]interface{}, len(query)+2)
+	for k, v := range query {
+		sliced[k] = v
+	}
+	sliced["pit"] = map[string]interface{}{"id": pitID, "keep_alive": DefaultPITKeepAlive}
+	if maxSlices > 1 {
+		sliced["slice"] = map[string]interface{}{"id": sliceID, "max": maxSlices}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(sliced); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	req := opensearchapi.SearchRequest{Body: &buf}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("root span PIT search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("root span PIT search request failed with status: %s", res.Status())
+	}
+
+	var response RootSpanSearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode root span PIT search response: %w", err)
+	}
+
+	log := logger.GetLogger(ctx)
+	log.Info("Root span PIT search completed",
+		"total_hits", response.Hits.Total.Value,
+		"returned_hits", len(response.Hits.Hits))
+
+	return &response, nil
+}
+
+// SearchSpanCountsPIT is SearchSpanCounts against a point-in-time context,
+// for callers building a consistent trace view alongside
+// SearchRootSpansPIT.
+func (c *Client) SearchSpanCountsPIT(ctx context.Context, pitID string, query map[string]interface{}) (*SpanCountResponse, error) {
+	sliced := make(map[string]interface{}, len(query)+1)
+	for k, v := range query {
+		sliced[k] = v
+	}
+	sliced["pit"] = map[string]interface{}{"id": pitID, "keep_alive": DefaultPITKeepAlive}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(sliced); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	req := opensearchapi.SearchRequest{Body: &buf}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("span count PIT request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("span count PIT request failed with status: %s", res.Status())
+	}
+
+	var response SpanCountResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode span count PIT response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// SearchSpanMetrics executes a composite aggregation query built by
+// BuildSpanMetricsQuery and returns the decoded bucket response for
+// EvaluateSpanMetrics to reshape.
+func (c *Client) SearchSpanMetrics(ctx context.Context, indices []string, query map[string]interface{}) (*SpanMetricsAggregationResponse, error) {
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
 		return nil, fmt.Errorf("failed to encode query: %w", err)
@@ -205,19 +418,22 @@ func (c *Client) SearchSpanCounts(ctx context.Context, indices []string, query m
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return nil, fmt.Errorf("span count request failed: %w", err)
+		return nil, fmt.Errorf("span metrics request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("span count request failed with status: %s", res.Status())
+		return nil, fmt.Errorf("span metrics request failed with status: %s", res.Status())
 	}
 
-	var response SpanCountResponse
+	var response SpanMetricsAggregationResponse
 	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode span count response: %w", err)
+		return nil, fmt.Errorf("failed to decode span metrics response: %w", err)
 	}
 
+	log := logger.GetLogger(ctx)
+	log.Info("Span metrics query completed", "buckets", len(response.Aggregations.Series.Buckets))
+
 	return &response, nil
 }
 
@@ -226,3 +442,209 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	_, err := c.client.Info()
 	return err
 }
+
+// DefaultPITKeepAlive is the keep_alive duration OpenPIT requests when
+// callers don't need a custom value - long enough to cover a typical
+// sliced export without tying up OpenSearch resources indefinitely.
+const DefaultPITKeepAlive = "5m"
+
+// pitScanPageSize bounds each page ScanWithPIT requests per slice.
+const pitScanPageSize = 1000
+
+// OpenPIT opens a point-in-time context against indices, valid for
+// keepAlive (an OpenSearch duration string, e.g. "5m"), and returns the PIT
+// ID. Unlike plain from/size or a bare search_after cursor, a PIT snapshot
+// keeps paginating against a consistent view of the index even if matching
+// documents are added, removed, or the backing daily indices roll over
+// while the scan is in progress - the scenario that otherwise silently
+// skips or duplicates spans partway through a long trace export.
+func (c *Client) OpenPIT(ctx context.Context, indices []string, keepAlive string) (string, error) {
+	path := fmt.Sprintf("/%s/_search/point_in_time", strings.Join(indices, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build open PIT request: %w", err)
+	}
+	req.URL.RawQuery = url.Values{"keep_alive": {keepAlive}}.Encode()
+
+	res, err := c.client.Transport.Perform(req)
+	if err != nil {
+		return "", fmt.Errorf("open PIT request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return "", fmt.Errorf("open PIT request failed with status: %s", res.Status)
+	}
+
+	var body struct {
+		PitID string `json:"pit_id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode open PIT response: %w", err)
+	}
+	return body.PitID, nil
+}
+
+// ClosePIT releases a point-in-time context opened by OpenPIT. Callers
+// should defer this right after a successful OpenPIT call.
+func (c *Client) ClosePIT(ctx context.Context, pitID string) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"pit_id": []string{pitID}}); err != nil {
+		return fmt.Errorf("failed to encode close PIT request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "/_search/point_in_time", &buf)
+	if err != nil {
+		return fmt.Errorf("failed to build close PIT request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.client.Transport.Perform(req)
+	if err != nil {
+		return fmt.Errorf("close PIT request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("close PIT request failed with status: %s", res.Status)
+	}
+	return nil
+}
+
+// SearchSliced runs query against the point-in-time context pitID, scoped
+// to one slice of maxSlices (OpenSearch's sliced scroll), so ScanWithPIT's
+// goroutines can each own a disjoint subset of the snapshot instead of
+// contending over the same search_after cursor. query should not set
+// "index": a PIT search targets whatever indices OpenPIT opened the context
+// against, not a path segment.
+func (c *Client) SearchSliced(ctx context.Context, pitID string, sliceID, maxSlices int, query map[string]interface{}) (*SearchResponse, error) {
+	sliced := make(map[string]interface{}, len(query)+2)
+	for k, v := range query {
+		sliced[k] = v
+	}
+	sliced["pit"] = map[string]interface{}{"id": pitID, "keep_alive": DefaultPITKeepAlive}
+	if maxSlices > 1 {
+		sliced["slice"] = map[string]interface{}{"id": sliceID, "max": maxSlices}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(sliced); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	req := opensearchapi.SearchRequest{
+		Body: &buf,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("sliced search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("sliced search request failed with status: %s", res.Status())
+	}
+
+	var response SearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode sliced search response: %w", err)
+	}
+	return &response, nil
+}
+
+// ScanWithPIT exhaustively scans query across indices using a point-in-time
+// snapshot, fanning the scan out across maxSlices goroutines and merging
+// their results - the mechanism large trace/span exports need once a
+// tenant's result set runs well past DefaultSpanQueryLimit, where a single
+// flat query would otherwise silently truncate. query must sort by
+// "traceId" then "spanId" (the same sort BuildTraceExportQuery uses), since
+// each slice paginates independently via search_after over that sort.
+func (c *Client) ScanWithPIT(ctx context.Context, indices []string, query map[string]interface{}, keepAlive string, maxSlices int) ([]Span, error) {
+	if maxSlices <= 0 {
+		maxSlices = 1
+	}
+	if keepAlive == "" {
+		keepAlive = DefaultPITKeepAlive
+	}
+
+	pitID, err := c.OpenPIT(ctx, indices, keepAlive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PIT: %w", err)
+	}
+	defer func() {
+		if closeErr := c.ClosePIT(ctx, pitID); closeErr != nil {
+			log := logger.GetLogger(ctx)
+			log.Warn("failed to close PIT", "error", closeErr)
+		}
+	}()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		merged []Span
+		errs   []error
+	)
+
+	for slice := 0; slice < maxSlices; slice++ {
+		wg.Add(1)
+		go func(sliceID int) {
+			defer wg.Done()
+
+			spans, sliceErr := c.scanSlice(ctx, pitID, sliceID, maxSlices, query)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if sliceErr != nil {
+				errs = append(errs, fmt.Errorf("slice %d: %w", sliceID, sliceErr))
+				return
+			}
+			merged = append(merged, spans...)
+		}(slice)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return merged, nil
+}
+
+// scanSlice pages through a single slice of a PIT scan via search_after
+// until a page returns fewer than pitScanPageSize hits.
+func (c *Client) scanSlice(ctx context.Context, pitID string, sliceID, maxSlices int, baseQuery map[string]interface{}) ([]Span, error) {
+	query := make(map[string]interface{}, len(baseQuery)+1)
+	for k, v := range baseQuery {
+		query[k] = v
+	}
+	query["size"] = pitScanPageSize
+
+	var (
+		spans       []Span
+		searchAfter []interface{}
+	)
+	for {
+		if len(searchAfter) > 0 {
+			query["search_after"] = searchAfter
+		}
+
+		response, err := c.SearchSliced(ctx, pitID, sliceID, maxSlices, query)
+		if err != nil {
+			return nil, err
+		}
+
+		page := ParseSpans(response)
+		if len(page) == 0 {
+			break
+		}
+		spans = append(spans, page...)
+
+		last := page[len(page)-1]
+		searchAfter = []interface{}{last.TraceID, last.SpanID}
+
+		if len(page) < pitScanPageSize {
+			break
+		}
+	}
+	return spans, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package opensearch
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics for the resiliency layer in resilience.go/client.go: how often
+// requests are retried or rejected by the circuit breaker, and how
+// effectively MultiSearch is batching per-trace-ID lookups.
+var (
+	retriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "traces_observer_opensearch_retries_total",
+		Help: "Number of OpenSearch requests retried after a 429/5xx response or a retryable transport error, by operation.",
+	}, []string{"operation"})
+
+	circuitBreakerTripsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "traces_observer_opensearch_circuit_breaker_trips_total",
+		Help: "Number of times the OpenSearch client's circuit breaker transitioned to open, by the operation whose failure tripped it.",
+	}, []string{"operation"})
+
+	circuitBreakerShortCircuitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "traces_observer_opensearch_circuit_breaker_short_circuits_total",
+		Help: "Number of requests rejected outright because the circuit breaker was open, by operation.",
+	}, []string{"operation"})
+
+	msearchBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "traces_observer_opensearch_msearch_batch_size",
+		Help:    "Number of individual searches batched into each MultiSearch call.",
+		Buckets: []float64{1, 2, 5, 10, 20, 50, 100},
+	})
+)
@@ -18,6 +18,9 @@ package opensearch
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -207,3 +210,679 @@ func BuildTraceByIdsQuery(params TraceByIdParams) map[string]interface{} {
 		"size": limit,
 	}
 }
+
+// BuildTraceIDsSpansQuery builds a search_after-paginated query over every
+// span belonging to any of traceIDs, sorted by traceId then spanId - the
+// same sort BuildTraceExportQuery uses, so a caller paging through results
+// with the last page's (traceId, spanId) as searchAfter behaves the same
+// way. Unlike BuildTraceByIdsQuery's flat "size" fetch, this is meant for
+// retrieving an entire trace's worth of spans (potentially thousands, for
+// large agent workflows) rather than a single bounded page.
+func BuildTraceIDsSpansQuery(traceIDs []string, componentUid, environmentUid string, searchAfter []interface{}, pageSize int) map[string]interface{} {
+	mustConditions := []map[string]interface{}{
+		{
+			"terms": map[string]interface{}{
+				"traceId": traceIDs,
+			},
+		},
+	}
+
+	if componentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/component-uid": componentUid,
+			},
+		})
+	}
+	if environmentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/environment-uid": environmentUid,
+			},
+		})
+	}
+
+	query := map[string]interface{}{
+		"size": pageSize,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": mustConditions,
+			},
+		},
+		"sort": []map[string]interface{}{
+			{"traceId": map[string]interface{}{"order": "asc"}},
+			{"spanId": map[string]interface{}{"order": "asc"}},
+		},
+	}
+
+	if len(searchAfter) > 0 {
+		query["search_after"] = searchAfter
+	}
+
+	return query
+}
+
+// defaultSpanFetchLimit bounds BuildTraceQLPreFilterQuery when the caller
+// doesn't pass an explicit limit.
+const defaultSpanFetchLimit = 10000
+
+// BuildTraceQLPreFilterQuery builds the span-level query
+// TracingController.SearchTracesQL issues to OpenSearch before evaluating
+// the parsed TraceQL pipeline in Go: the usual component/environment/time
+// scoping, plus preFilter (see traceql.CompilePreFilter) as an extra must
+// clause when it is non-nil. Unlike
+// BuildTraceSearchQuery, this fetches every span matching the scope, not
+// just root spans, since a SpansetFilter can match any span in a trace.
+func BuildTraceQLPreFilterQuery(params TraceQueryParams, preFilter map[string]interface{}, limit int) map[string]interface{} {
+	mustConditions := []map[string]interface{}{}
+
+	if params.ComponentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/component-uid": params.ComponentUid,
+			},
+		})
+	}
+	if params.EnvironmentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/environment-uid": params.EnvironmentUid,
+			},
+		})
+	}
+	if params.StartTime != "" && params.EndTime != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"startTime": map[string]interface{}{
+					"gte": params.StartTime,
+					"lte": params.EndTime,
+				},
+			},
+		})
+	}
+	if preFilter != nil {
+		mustConditions = append(mustConditions, preFilter)
+	}
+
+	if limit <= 0 {
+		limit = defaultSpanFetchLimit
+	}
+
+	return map[string]interface{}{
+		"size": limit,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": mustConditions,
+			},
+		},
+	}
+}
+
+// BuildTraceExportQuery builds a search_after-paginated query over every
+// span (not just root spans) matching params, sorted by traceId then
+// spanId. That sort guarantees one trace's spans are contiguous across
+// pages, so a streaming caller (see TracingController.StreamExportTraces)
+// can flush a trace as soon as it sees the next span's traceId change,
+// without first scanning the whole time range like BuildTraceQuery's
+// fixed-multiplier fetch.
+func BuildTraceExportQuery(params TraceQueryParams, searchAfter []interface{}, pageSize int) map[string]interface{} {
+	mustConditions := []map[string]interface{}{}
+
+	if params.ComponentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/component-uid": params.ComponentUid,
+			},
+		})
+	}
+	if params.EnvironmentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/environment-uid": params.EnvironmentUid,
+			},
+		})
+	}
+	if params.StartTime != "" && params.EndTime != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"startTime": map[string]interface{}{
+					"gte": params.StartTime,
+					"lte": params.EndTime,
+				},
+			},
+		})
+	}
+
+	query := map[string]interface{}{
+		"size": pageSize,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": mustConditions,
+			},
+		},
+		"sort": []map[string]interface{}{
+			{"traceId": map[string]interface{}{"order": "asc"}},
+			{"spanId": map[string]interface{}{"order": "asc"}},
+		},
+	}
+
+	if len(searchAfter) > 0 {
+		query["search_after"] = searchAfter
+	}
+
+	return query
+}
+
+// AttributeFilterOp identifies how a TraceSearchParams.AttributeFilters
+// entry compares a span attribute against its value.
+type AttributeFilterOp string
+
+const (
+	// AttributeFilterEqual matches the attribute exactly.
+	AttributeFilterEqual AttributeFilterOp = "eq"
+	// AttributeFilterPrefix matches attributes starting with the value.
+	AttributeFilterPrefix AttributeFilterOp = "prefix"
+	// AttributeFilterRegex matches attributes against a regular expression.
+	AttributeFilterRegex AttributeFilterOp = "regex"
+)
+
+// AttributeFilter matches a single span attribute, e.g.
+// {Key: "llm.model", Op: AttributeFilterEqual, Value: "gpt-4o"}.
+type AttributeFilter struct {
+	Key   string
+	Op    AttributeFilterOp
+	Value string
+}
+
+// TraceSearchParams is the query shape for BuildTraceSearchQuery: the usual
+// component/environment/time scoping plus the richer filters SearchTraces
+// supports over TraceQueryParams (attribute matches, duration bounds,
+// status, a root span name glob, and free-text span event search), and
+// search_after cursor pagination in place of an offset.
+type TraceSearchParams struct {
+	ComponentUid       string
+	EnvironmentUid     string
+	StartTime          string
+	EndTime            string
+	AttributeFilters   []AttributeFilter
+	MinDurationInNanos int64
+	MaxDurationInNanos int64
+	StatusCode         string
+	RootSpanNameGlob   string
+	EventText          string
+	Limit              int
+	SortOrder          string
+	SearchAfter        []interface{}
+}
+
+// TraceSearchResponse is the result of a trace search: matching traces plus
+// an opaque NextCursor for fetching the following page, empty once there
+// are no more results.
+type TraceSearchResponse struct {
+	Traces     []TraceOverview `json:"traces"`
+	TotalCount int             `json:"totalCount"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+}
+
+// BuildTraceSearchQuery builds a search_after-paginated query over root
+// spans (parentSpanId == "") matching every filter in params. Callers page
+// through results by feeding the sort values of the last hit back in as
+// SearchAfter, which OpenSearch evaluates against the live index rather
+// than a frozen offset, so results stay correct as new traces are ingested
+// mid-pagination, unlike BuildTraceAggregationQuery's offset-based paging.
+func BuildTraceSearchQuery(params TraceSearchParams) map[string]interface{} {
+	mustConditions := []map[string]interface{}{
+		{
+			"term": map[string]interface{}{
+				"parentSpanId": "",
+			},
+		},
+	}
+
+	if params.ComponentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/component-uid": params.ComponentUid,
+			},
+		})
+	}
+	if params.EnvironmentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/environment-uid": params.EnvironmentUid,
+			},
+		})
+	}
+	if params.StartTime != "" && params.EndTime != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"startTime": map[string]interface{}{
+					"gte": params.StartTime,
+					"lte": params.EndTime,
+				},
+			},
+		})
+	}
+
+	if params.MinDurationInNanos > 0 || params.MaxDurationInNanos > 0 {
+		durationRange := map[string]interface{}{}
+		if params.MinDurationInNanos > 0 {
+			durationRange["gte"] = params.MinDurationInNanos
+		}
+		if params.MaxDurationInNanos > 0 {
+			durationRange["lte"] = params.MaxDurationInNanos
+		}
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"durationInNanos": durationRange,
+			},
+		})
+	}
+
+	if params.StatusCode != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"status.code": params.StatusCode,
+			},
+		})
+	}
+
+	if params.RootSpanNameGlob != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"wildcard": map[string]interface{}{
+				"name": map[string]interface{}{
+					"value": params.RootSpanNameGlob,
+				},
+			},
+		})
+	}
+
+	if params.EventText != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"match": map[string]interface{}{
+				"events.attributes.value": params.EventText,
+			},
+		})
+	}
+
+	for _, filter := range params.AttributeFilters {
+		field := fmt.Sprintf("attributes.%s", filter.Key)
+		switch filter.Op {
+		case AttributeFilterPrefix:
+			mustConditions = append(mustConditions, map[string]interface{}{
+				"prefix": map[string]interface{}{
+					field: filter.Value,
+				},
+			})
+		case AttributeFilterRegex:
+			mustConditions = append(mustConditions, map[string]interface{}{
+				"regexp": map[string]interface{}{
+					field: filter.Value,
+				},
+			})
+		default: // AttributeFilterEqual
+			mustConditions = append(mustConditions, map[string]interface{}{
+				"term": map[string]interface{}{
+					field: filter.Value,
+				},
+			})
+		}
+	}
+
+	sortOrder := params.SortOrder
+	if sortOrder == "" {
+		sortOrder = "desc"
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	query := map[string]interface{}{
+		"size": limit,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": mustConditions,
+			},
+		},
+		"sort": []map[string]interface{}{
+			{"startTime": map[string]interface{}{"order": sortOrder}},
+			{"spanId": map[string]interface{}{"order": "asc"}},
+		},
+	}
+
+	if len(params.SearchAfter) > 0 {
+		query["search_after"] = params.SearchAfter
+	}
+
+	return query
+}
+
+// SpanMetricsAggregation selects what BuildSpanMetricsQuery computes per
+// time bucket, mirroring TraceQL-metrics' rate()/count_over_time()/
+// quantile_over_time()/sum_over_time() functions.
+type SpanMetricsAggregation string
+
+const (
+	// AggregationRate divides each bucket's doc_count by Step's duration.
+	AggregationRate SpanMetricsAggregation = "rate"
+	// AggregationCountOverTime reports each bucket's raw doc_count.
+	AggregationCountOverTime SpanMetricsAggregation = "count_over_time"
+	// AggregationQuantileOverTime computes Quantiles of Field per bucket,
+	// one MetricSeries per quantile (see EvaluateSpanMetrics).
+	AggregationQuantileOverTime SpanMetricsAggregation = "quantile_over_time"
+	// AggregationSumOverTime sums Field per bucket.
+	AggregationSumOverTime SpanMetricsAggregation = "sum_over_time"
+)
+
+// compositeMaxBuckets caps how many distinct GroupBy x time-step
+// combinations BuildSpanMetricsQuery's composite aggregation returns in one
+// page. QuerySpanMetrics doesn't page through after_key, so series beyond
+// this cap are silently dropped by OpenSearch - acceptable for dashboard
+// queries, which bound their own GroupBy cardinality and time range.
+const compositeMaxBuckets = 10000
+
+// SpanMetricsParams is the query shape for BuildSpanMetricsQuery and
+// TracingController.QuerySpanMetrics.
+type SpanMetricsParams struct {
+	ComponentUid     string
+	EnvironmentUid   string
+	StartTime        string
+	EndTime          string
+	// SpanKind filters on the span's raw OTel kind (e.g. "SPAN_KIND_SERVER")
+	// as stored on the document - distinct from DetermineSpanType's
+	// application-level heuristic classification used elsewhere.
+	SpanKind         string
+	AttributeFilters []AttributeFilter
+
+	Aggregation SpanMetricsAggregation
+	// Field is the OpenSearch field quantile_over_time/sum_over_time
+	// aggregate over, e.g. "durationInNanos" or "attributes.llm.tokens".
+	// Ignored by rate/count_over_time.
+	Field string
+	// Quantiles are the percentiles (0-1) quantile_over_time computes,
+	// e.g. [0.5, 0.95, 0.99]. Ignored by other aggregations.
+	Quantiles []float64
+	// GroupBy are attribute keys bucketed via composite terms sources, so
+	// each distinct combination of values becomes its own MetricSeries.
+	GroupBy []string
+	// Step is the date_histogram bucket width, e.g. "1m", "5m", "1h".
+	Step string
+}
+
+// MetricPoint is one time-bucketed sample of a MetricSeries.
+type MetricPoint struct {
+	T int64 `json:"t"` // bucket start, Unix milliseconds
+	V float64 `json:"v"`
+}
+
+// MetricSeries is one GroupBy combination's points, reshaped from a
+// composite aggregation response by EvaluateSpanMetrics. For
+// AggregationQuantileOverTime, Labels also carries a "quantile" entry since
+// each requested quantile becomes its own series.
+type MetricSeries struct {
+	Labels map[string]string `json:"labels"`
+	Points []MetricPoint     `json:"points"`
+}
+
+// SpanMetricsResponse is QuerySpanMetrics' result: one MetricSeries per
+// distinct GroupBy combination (and, for quantiles, per quantile) found in
+// the time range.
+type SpanMetricsResponse struct {
+	Series []MetricSeries `json:"series"`
+}
+
+// SpanMetricsAggregationResponse is the decoded shape of a composite
+// aggregation response built by BuildSpanMetricsQuery.
+type SpanMetricsAggregationResponse struct {
+	Aggregations struct {
+		Series struct {
+			Buckets []SpanMetricsBucket `json:"buckets"`
+		} `json:"series"`
+	} `json:"aggregations"`
+}
+
+// SpanMetricsBucket is one composite bucket: Key holds one entry per
+// GroupBy attribute plus "time" (bucket start, epoch millis), DocCount
+// backs rate()/count_over_time(), and Value backs
+// quantile_over_time()/sum_over_time() when BuildSpanMetricsQuery added a
+// sub-aggregation.
+type SpanMetricsBucket struct {
+	Key      map[string]interface{} `json:"key"`
+	DocCount int                    `json:"doc_count"`
+	Value    *SpanMetricsBucketValue `json:"value,omitempty"`
+}
+
+// SpanMetricsBucketValue is a bucket's sub-aggregation result: Value for a
+// single-value metric aggregation (sum), Values (keyed by percent string,
+// e.g. "95.0") for a percentiles aggregation.
+type SpanMetricsBucketValue struct {
+	Value  float64            `json:"value"`
+	Values map[string]float64 `json:"values"`
+}
+
+// BuildSpanMetricsQuery builds a composite aggregation over spans matching
+// params: one composite terms source per GroupBy attribute key, plus a
+// trailing date_histogram source bucketing by Step, with a sub-aggregation
+// computing Aggregation per bucket. rate() and count_over_time() need no
+// sub-aggregation - both read a bucket's doc_count directly, with rate()
+// dividing by Step's duration in EvaluateSpanMetrics.
+func BuildSpanMetricsQuery(params SpanMetricsParams) map[string]interface{} {
+	mustConditions := []map[string]interface{}{}
+
+	if params.ComponentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/component-uid": params.ComponentUid,
+			},
+		})
+	}
+	if params.EnvironmentUid != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"resource.openchoreo.dev/environment-uid": params.EnvironmentUid,
+			},
+		})
+	}
+	if params.StartTime != "" && params.EndTime != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"startTime": map[string]interface{}{
+					"gte": params.StartTime,
+					"lte": params.EndTime,
+				},
+			},
+		})
+	}
+	if params.SpanKind != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"kind": params.SpanKind,
+			},
+		})
+	}
+
+	for _, filter := range params.AttributeFilters {
+		field := fmt.Sprintf("attributes.%s", filter.Key)
+		switch filter.Op {
+		case AttributeFilterPrefix:
+			mustConditions = append(mustConditions, map[string]interface{}{
+				"prefix": map[string]interface{}{
+					field: filter.Value,
+				},
+			})
+		case AttributeFilterRegex:
+			mustConditions = append(mustConditions, map[string]interface{}{
+				"regexp": map[string]interface{}{
+					field: filter.Value,
+				},
+			})
+		default: // AttributeFilterEqual
+			mustConditions = append(mustConditions, map[string]interface{}{
+				"term": map[string]interface{}{
+					field: filter.Value,
+				},
+			})
+		}
+	}
+
+	step := params.Step
+	if step == "" {
+		step = "1m"
+	}
+
+	sources := make([]map[string]interface{}, 0, len(params.GroupBy)+1)
+	for _, key := range params.GroupBy {
+		sources = append(sources, map[string]interface{}{
+			key: map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": fmt.Sprintf("attributes.%s", key),
+				},
+			},
+		})
+	}
+	sources = append(sources, map[string]interface{}{
+		"time": map[string]interface{}{
+			"date_histogram": map[string]interface{}{
+				"field":          "startTime",
+				"fixed_interval": step,
+			},
+		},
+	})
+
+	seriesAgg := map[string]interface{}{
+		"composite": map[string]interface{}{
+			"size":    compositeMaxBuckets,
+			"sources": sources,
+		},
+	}
+
+	switch params.Aggregation {
+	case AggregationQuantileOverTime:
+		percents := make([]float64, len(params.Quantiles))
+		for i, q := range params.Quantiles {
+			percents[i] = q * 100
+		}
+		seriesAgg["aggs"] = map[string]interface{}{
+			"value": map[string]interface{}{
+				"percentiles": map[string]interface{}{
+					"field":    params.Field,
+					"percents": percents,
+				},
+			},
+		}
+	case AggregationSumOverTime:
+		seriesAgg["aggs"] = map[string]interface{}{
+			"value": map[string]interface{}{
+				"sum": map[string]interface{}{
+					"field": params.Field,
+				},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": mustConditions,
+			},
+		},
+		"aggs": map[string]interface{}{
+			"series": seriesAgg,
+		},
+	}
+}
+
+// EvaluateSpanMetrics reshapes a composite aggregation response from
+// BuildSpanMetricsQuery into per-GroupBy-combination series, without
+// materializing the underlying spans: every value comes straight from
+// OpenSearch's bucket aggregates.
+func EvaluateSpanMetrics(params SpanMetricsParams, response *SpanMetricsAggregationResponse) []MetricSeries {
+	stepDuration, err := time.ParseDuration(params.Step)
+	if err != nil {
+		stepDuration = time.Minute
+	}
+
+	seriesByLabels := make(map[string]*MetricSeries)
+	var order []string
+
+	addPoint := func(labels map[string]string, point MetricPoint) {
+		key := labelsKey(labels)
+		series, ok := seriesByLabels[key]
+		if !ok {
+			series = &MetricSeries{Labels: labels}
+			seriesByLabels[key] = series
+			order = append(order, key)
+		}
+		series.Points = append(series.Points, point)
+	}
+
+	for _, bucket := range response.Aggregations.Series.Buckets {
+		baseLabels := make(map[string]string, len(params.GroupBy))
+		for _, groupKey := range params.GroupBy {
+			if v, ok := bucket.Key[groupKey]; ok {
+				baseLabels[groupKey] = fmt.Sprintf("%v", v)
+			}
+		}
+		t, _ := bucket.Key["time"].(float64)
+
+		switch params.Aggregation {
+		case AggregationRate:
+			addPoint(baseLabels, MetricPoint{T: int64(t), V: float64(bucket.DocCount) / stepDuration.Seconds()})
+		case AggregationSumOverTime:
+			var value float64
+			if bucket.Value != nil {
+				value = bucket.Value.Value
+			}
+			addPoint(baseLabels, MetricPoint{T: int64(t), V: value})
+		case AggregationQuantileOverTime:
+			for _, q := range params.Quantiles {
+				var value float64
+				if bucket.Value != nil {
+					value = bucket.Value.Values[percentileKey(q)]
+				}
+				labels := make(map[string]string, len(baseLabels)+1)
+				for k, v := range baseLabels {
+					labels[k] = v
+				}
+				labels["quantile"] = strconv.FormatFloat(q, 'g', -1, 64)
+				addPoint(labels, MetricPoint{T: int64(t), V: value})
+			}
+		default: // AggregationCountOverTime
+			addPoint(baseLabels, MetricPoint{T: int64(t), V: float64(bucket.DocCount)})
+		}
+	}
+
+	result := make([]MetricSeries, 0, len(order))
+	for _, key := range order {
+		result = append(result, *seriesByLabels[key])
+	}
+	return result
+}
+
+// percentileKey formats a 0-1 quantile the way OpenSearch keys percentiles
+// aggregation results, e.g. 0.95 -> "95.0".
+func percentileKey(q float64) string {
+	return strconv.FormatFloat(q*100, 'f', 1, 64)
+}
+
+// labelsKey builds a stable map key from a label set so equal label sets
+// (regardless of map iteration order) land in the same MetricSeries.
+func labelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
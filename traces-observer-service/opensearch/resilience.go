@@ -0,0 +1,222 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package opensearch
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+)
+
+const (
+	defaultMaxRetries              = 3
+	defaultRetryBaseDelay          = 100 * time.Millisecond
+	defaultRetryMaxDelay           = 2 * time.Second
+	defaultCircuitBreakerThreshold = 5
+	defaultCircuitBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned by doWithRetry when the circuit breaker has
+// tripped and its cooldown has not yet elapsed.
+var ErrCircuitOpen = errors.New("opensearch circuit breaker open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker trips after consecutive request failures and stops
+// letting requests through until a cooldown elapses, at which point it
+// admits one probe request (half-open) to test whether the node has
+// recovered. Client embeds exactly one, since NewClient only ever dials a
+// single node (cfg.Address).
+type circuitBreaker struct {
+	mu               sync.Mutex
+	threshold        int
+	cooldown         time.Duration
+	state            circuitState
+	consecutiveFails int
+	openedAt         time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = defaultCircuitBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, state: circuitClosed}
+}
+
+// allow reports whether a request may proceed, transitioning an open
+// breaker to half-open once its cooldown has elapsed. While half-open, only
+// that one probe is admitted until its outcome is recorded.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitClosed:
+		return true
+	case circuitHalfOpen:
+		return false
+	}
+	if time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.state = circuitHalfOpen
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFails = 0
+	b.state = circuitClosed
+}
+
+// recordFailure reports a failed request and returns whether this call is
+// what tripped the breaker open.
+func (b *circuitBreaker) recordFailure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFails++
+	if b.state != circuitOpen && (b.state == circuitHalfOpen || b.consecutiveFails >= b.threshold) {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus reports whether status warrants a retry: 429 (rate
+// limited) or any 5xx (shard unavailable, node overloaded, etc).
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// isRetryableError reports whether a transport-level error (as opposed to
+// an HTTP error status) is worth retrying - currently just i/o timeouts and
+// a context deadline expiring mid-request.
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), "i/o timeout") || errors.Is(err, context.DeadlineExceeded)
+}
+
+// retryAfter parses a response's Retry-After header (seconds, or an
+// HTTP-date) into a wait duration, returning ok=false when absent or
+// unparseable.
+func retryAfter(res *opensearchapi.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when), true
+	}
+	return 0, false
+}
+
+// backoff computes the delay before retry attempt n (1-indexed): exponential
+// growth from baseDelay, capped at maxDelay, plus up to 50% jitter so a
+// burst of clients retrying the same failure don't all retry in lockstep.
+func backoff(n int, baseDelay, maxDelay time.Duration) time.Duration {
+	delay := time.Duration(float64(baseDelay) * math.Pow(2, float64(n-1)))
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay + jitter
+}
+
+// doWithRetry issues the request built by attempt, retrying on a 429/5xx
+// response or a retryable transport error, honoring a Retry-After header
+// when the server sends one, up to c.maxRetries additional attempts.
+// attempt must build and issue a fresh request each call, since a
+// previously-read request body can't be replayed. The circuit breaker short
+// circuits entirely (ErrCircuitOpen, no request issued) once it has
+// tripped; operation is used only to label the retries/trips metrics.
+func (c *Client) doWithRetry(ctx context.Context, operation string, attempt func() (*opensearchapi.Response, error)) (*opensearchapi.Response, error) {
+	if !c.breaker.allow() {
+		circuitBreakerShortCircuitsTotal.WithLabelValues(operation).Inc()
+		return nil, ErrCircuitOpen
+	}
+
+	var (
+		res *opensearchapi.Response
+		err error
+	)
+	for try := 0; ; try++ {
+		res, err = attempt()
+
+		retryable := isRetryableError(err) || (err == nil && isRetryableStatus(res.StatusCode))
+		if !retryable || try >= c.maxRetries {
+			break
+		}
+
+		wait := backoff(try+1, c.retryBaseDelay, c.retryMaxDelay)
+		if res != nil {
+			if ra, ok := retryAfter(res); ok {
+				wait = ra
+			}
+			res.Body.Close()
+		}
+
+		retriesTotal.WithLabelValues(operation).Inc()
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			c.breaker.recordFailure()
+			return nil, ctx.Err()
+		}
+	}
+
+	failed := err != nil || (res != nil && isRetryableStatus(res.StatusCode))
+	if failed {
+		if c.breaker.recordFailure() {
+			circuitBreakerTripsTotal.WithLabelValues(operation).Inc()
+		}
+	} else {
+		c.breaker.recordSuccess()
+	}
+
+	return res, err
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceql
+
+import "time"
+
+// ComparisonOp is a comparison between a field and a literal value, e.g.
+// the ">=" in "span.http.status_code >= 500".
+type ComparisonOp string
+
+const (
+	OpEQ  ComparisonOp = "=="
+	OpNEQ ComparisonOp = "!="
+	OpGT  ComparisonOp = ">"
+	OpGTE ComparisonOp = ">="
+	OpLT  ComparisonOp = "<"
+	OpLTE ComparisonOp = "<="
+)
+
+// LogicalOp combines or negates predicates within a single SpansetFilter.
+type LogicalOp string
+
+const (
+	LogicalAnd LogicalOp = "&&"
+	LogicalOr  LogicalOp = "||"
+)
+
+// LiteralKind identifies which field of Literal holds the parsed value.
+type LiteralKind int
+
+const (
+	LiteralNumber LiteralKind = iota
+	LiteralString
+	LiteralDuration
+)
+
+// Literal is a parsed comparison operand: a number (500), a duration
+// (2s, 150ms), or a string (an explicit "quoted" value or a bare word like
+// the "error" in "status == error").
+type Literal struct {
+	Kind     LiteralKind
+	Number   float64
+	Str      string
+	Duration time.Duration
+}
+
+// FieldRef identifies what a ComparisonExpr reads from a span: one of the
+// reserved fields (duration, name, status, kind), or a dotted attribute
+// path reached through the "span." namespace (e.g. "span.http.status_code"
+// reads span.Attributes["http.status_code"]).
+type FieldRef struct {
+	Reserved  string
+	Attribute string
+}
+
+// PredicateExpr is a node in the boolean expression inside a single
+// SpansetFilter's "{...}", evaluated against one span at a time.
+type PredicateExpr interface {
+	predicateNode()
+}
+
+// ComparisonExpr is a leaf predicate, e.g. "duration > 2s".
+type ComparisonExpr struct {
+	Field FieldRef
+	Op    ComparisonOp
+	Value Literal
+}
+
+func (*ComparisonExpr) predicateNode() {}
+
+// BinaryExpr combines two predicates with && or ||.
+type BinaryExpr struct {
+	Op          LogicalOp
+	Left, Right PredicateExpr
+}
+
+func (*BinaryExpr) predicateNode() {}
+
+// NotExpr negates a predicate ("!").
+type NotExpr struct {
+	Operand PredicateExpr
+}
+
+func (*NotExpr) predicateNode() {}
+
+// SpansetFilter selects the spans within one trace whose attributes satisfy
+// Predicate, e.g. "{ span.http.status_code >= 500 && duration > 2s }".
+type SpansetFilter struct {
+	Predicate PredicateExpr
+}
+
+// AggregateFunc is the function applied to a spanset by one pipeline stage.
+type AggregateFunc string
+
+const (
+	AggregateCount AggregateFunc = "count"
+	AggregateSum   AggregateFunc = "sum"
+	AggregateAvg   AggregateFunc = "avg"
+	AggregateMax   AggregateFunc = "max"
+	AggregateMin   AggregateFunc = "min"
+)
+
+// AggregateStage is one "| func(field) op value" pipeline stage chained
+// onto a SpansetFilter, e.g. "| count() > 3". Field is the zero FieldRef
+// for count(); every other aggregate requires one (sum(attr), max(duration)).
+type AggregateStage struct {
+	Func  AggregateFunc
+	Field FieldRef
+	Op    ComparisonOp
+	Value Literal
+}
+
+// Query is a parsed TraceQL-like expression: a SpansetFilter selecting the
+// candidate spans within each trace, optionally piped through one or more
+// aggregate stages that must all hold for the trace to match. A bare filter
+// with no stages matches any trace whose spanset is non-empty.
+type Query struct {
+	Filter SpansetFilter
+	Stages []AggregateStage
+}
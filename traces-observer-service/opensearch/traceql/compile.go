@@ -0,0 +1,119 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceql
+
+import "fmt"
+
+// CompilePreFilter walks q's SpansetFilter predicate tree and returns an
+// OpenSearch bool-query clause that over-approximates it, for use as a
+// pre-filter that shrinks the span scan before Evaluate runs the real
+// pipeline in Go - it is never the final answer on its own. Structural
+// operators (&&, ||) are not compiled: every leaf comparison found
+// anywhere in the tree is OR'd together instead, which is a superset of
+// the true match set. "!=" comparisons and "kind" predicates (kind is
+// DetermineSpanType's classification, not a stored field) can't narrow the
+// scan at all and are skipped. ok is false, and no clause is returned, when
+// nothing could be pushed down, when the tree contains a "!", or when it
+// contains both a "||" and a skipped leaf, since the OR'd clause could then
+// exclude true matches; the caller then fetches without this clause.
+func CompilePreFilter(q *Query) (clause map[string]interface{}, ok bool) {
+	var should []map[string]interface{}
+	var hasOr, hasNot, skipped bool
+	collectComparisons(q.Filter.Predicate, &should, &hasOr, &hasNot, &skipped)
+	if len(should) == 0 || hasNot || (hasOr && skipped) {
+		return nil, false
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}, true
+}
+
+func collectComparisons(pred PredicateExpr, out *[]map[string]interface{}, hasOr, hasNot, skipped *bool) {
+	switch e := pred.(type) {
+	case *BinaryExpr:
+		if e.Op == LogicalOr {
+			*hasOr = true
+		}
+		collectComparisons(e.Left, out, hasOr, hasNot, skipped)
+		collectComparisons(e.Right, out, hasOr, hasNot, skipped)
+	case *NotExpr:
+		*hasNot = true
+	case *ComparisonExpr:
+		if clause, ok := compileComparison(e); ok {
+			*out = append(*out, clause)
+		} else {
+			*skipped = true
+		}
+	}
+}
+
+// osField maps a FieldRef to the field path it occupies in an indexed span
+// document, following the same "attributes.<dotted.key>" convention
+// BuildTraceSearchQuery uses for AttributeFilter.
+func osField(f FieldRef) (string, bool) {
+	switch f.Reserved {
+	case "duration":
+		return "durationInNanos", true
+	case "name":
+		return "name", true
+	case "status":
+		return "status.code", true
+	case "kind":
+		return "", false
+	}
+	if f.Attribute != "" {
+		return fmt.Sprintf("attributes.%s", f.Attribute), true
+	}
+	return "", false
+}
+
+func compileComparison(e *ComparisonExpr) (map[string]interface{}, bool) {
+	field, ok := osField(e.Field)
+	if !ok {
+		return nil, false
+	}
+
+	value := literalValue(e.Value)
+	switch e.Op {
+	case OpEQ:
+		return map[string]interface{}{"term": map[string]interface{}{field: value}}, true
+	case OpGT:
+		return map[string]interface{}{"range": map[string]interface{}{field: map[string]interface{}{"gt": value}}}, true
+	case OpGTE:
+		return map[string]interface{}{"range": map[string]interface{}{field: map[string]interface{}{"gte": value}}}, true
+	case OpLT:
+		return map[string]interface{}{"range": map[string]interface{}{field: map[string]interface{}{"lt": value}}}, true
+	case OpLTE:
+		return map[string]interface{}{"range": map[string]interface{}{field: map[string]interface{}{"lte": value}}}, true
+	default: // OpNEQ: can't narrow the pre-filter, see CompilePreFilter's doc comment
+		return nil, false
+	}
+}
+
+func literalValue(l Literal) interface{} {
+	switch l.Kind {
+	case LiteralNumber:
+		return l.Number
+	case LiteralDuration:
+		return l.Duration.Nanoseconds()
+	default:
+		return l.Str
+	}
+}
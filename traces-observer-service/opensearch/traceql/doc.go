@@ -0,0 +1,24 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package traceql implements a small TraceQL-like query language for
+// filtering traces by span attributes: Parse turns a query string into a
+// Query AST (a SpansetFilter matching individual spans, optionally piped
+// through aggregate stages like "| count() > 3"), CompilePreFilter turns
+// its leaf predicates into an OpenSearch bool query that over-approximates
+// the match set to shrink the span scan, and Evaluate runs the real
+// pipeline in Go over the spans OpenSearch returns, trace by trace.
+package traceql
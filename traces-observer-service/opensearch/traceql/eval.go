@@ -0,0 +1,236 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceql
+
+import (
+	"fmt"
+
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/opensearch"
+)
+
+// Evaluate runs q against every span of one trace and reports whether the
+// trace belongs in the result: a bare SpansetFilter matches when the
+// resulting spanset is non-empty; a pipeline matches when every aggregate
+// stage's comparison also holds over that spanset.
+func Evaluate(q *Query, spans []opensearch.Span) bool {
+	spanset := filterSpanset(q.Filter, spans)
+
+	for _, stage := range q.Stages {
+		value, ok := runAggregate(stage, spanset)
+		if !ok || !compareAggregate(stage.Op, value, stage.Value) {
+			return false
+		}
+	}
+
+	if len(q.Stages) == 0 {
+		return len(spanset) > 0
+	}
+	return true
+}
+
+// MatchingTraceIDs returns the keys of spansByTrace whose spans satisfy q,
+// in no particular order - callers page and sort the result themselves
+// (see TracingController.SearchTracesQL).
+func MatchingTraceIDs(q *Query, spansByTrace map[string][]opensearch.Span) []string {
+	var matched []string
+	for traceID, spans := range spansByTrace {
+		if Evaluate(q, spans) {
+			matched = append(matched, traceID)
+		}
+	}
+	return matched
+}
+
+func filterSpanset(filter SpansetFilter, spans []opensearch.Span) []opensearch.Span {
+	matched := make([]opensearch.Span, 0, len(spans))
+	for _, span := range spans {
+		if evalPredicate(filter.Predicate, span) {
+			matched = append(matched, span)
+		}
+	}
+	return matched
+}
+
+func evalPredicate(pred PredicateExpr, span opensearch.Span) bool {
+	switch e := pred.(type) {
+	case *BinaryExpr:
+		switch e.Op {
+		case LogicalAnd:
+			return evalPredicate(e.Left, span) && evalPredicate(e.Right, span)
+		case LogicalOr:
+			return evalPredicate(e.Left, span) || evalPredicate(e.Right, span)
+		}
+		return false
+	case *NotExpr:
+		return !evalPredicate(e.Operand, span)
+	case *ComparisonExpr:
+		actual, ok := fieldValue(e.Field, span)
+		if !ok {
+			return false
+		}
+		return compareValues(e.Op, actual, literalValue(e.Value))
+	}
+	return false
+}
+
+// fieldValue reads a FieldRef off span. kind has no indexed OpenSearch
+// field (see CompilePreFilter), so it's only ever resolved here.
+func fieldValue(f FieldRef, span opensearch.Span) (interface{}, bool) {
+	switch f.Reserved {
+	case "duration":
+		return float64(span.DurationInNanos), true
+	case "name":
+		return span.Name, true
+	case "status":
+		return span.StatusCode, true
+	case "kind":
+		return string(opensearch.DetermineSpanType(span)), true
+	}
+	if f.Attribute != "" {
+		v, ok := span.Attributes[f.Attribute]
+		return v, ok
+	}
+	return nil, false
+}
+
+func compareValues(op ComparisonOp, actual, expected interface{}) bool {
+	if af, aok := toFloat(actual); aok {
+		if ef, eok := toFloat(expected); eok {
+			return compareFloats(op, af, ef)
+		}
+	}
+
+	as := fmt.Sprintf("%v", actual)
+	es := fmt.Sprintf("%v", expected)
+	switch op {
+	case OpEQ:
+		return as == es
+	case OpNEQ:
+		return as != es
+	case OpGT:
+		return as > es
+	case OpGTE:
+		return as >= es
+	case OpLT:
+		return as < es
+	case OpLTE:
+		return as <= es
+	}
+	return false
+}
+
+func compareFloats(op ComparisonOp, a, b float64) bool {
+	switch op {
+	case OpEQ:
+		return a == b
+	case OpNEQ:
+		return a != b
+	case OpGT:
+		return a > b
+	case OpGTE:
+		return a >= b
+	case OpLT:
+		return a < b
+	case OpLTE:
+		return a <= b
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func runAggregate(stage AggregateStage, spans []opensearch.Span) (float64, bool) {
+	if stage.Func == AggregateCount {
+		return float64(len(spans)), true
+	}
+
+	values := make([]float64, 0, len(spans))
+	for _, span := range spans {
+		v, ok := fieldValue(stage.Field, span)
+		if !ok {
+			continue
+		}
+		f, ok := toFloat(v)
+		if !ok {
+			continue
+		}
+		values = append(values, f)
+	}
+
+	switch stage.Func {
+	case AggregateSum:
+		var total float64
+		for _, v := range values {
+			total += v
+		}
+		return total, true
+	case AggregateAvg:
+		if len(values) == 0 {
+			return 0, false
+		}
+		var total float64
+		for _, v := range values {
+			total += v
+		}
+		return total / float64(len(values)), true
+	case AggregateMax:
+		if len(values) == 0 {
+			return 0, false
+		}
+		max := values[0]
+		for _, v := range values[1:] {
+			if v > max {
+				max = v
+			}
+		}
+		return max, true
+	case AggregateMin:
+		if len(values) == 0 {
+			return 0, false
+		}
+		min := values[0]
+		for _, v := range values[1:] {
+			if v < min {
+				min = v
+			}
+		}
+		return min, true
+	}
+	return 0, false
+}
+
+func compareAggregate(op ComparisonOp, actual float64, expected Literal) bool {
+	var ef float64
+	if expected.Kind == LiteralDuration {
+		ef = float64(expected.Duration.Nanoseconds())
+	} else {
+		ef = expected.Number
+	}
+	return compareFloats(op, actual, ef)
+}
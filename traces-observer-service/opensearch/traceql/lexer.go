@@ -0,0 +1,193 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceql
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokDuration
+	tokString
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+	tokLBrace
+	tokRBrace
+	tokPipe
+	tokEQ
+	tokNEQ
+	tokGT
+	tokGTE
+	tokLT
+	tokLTE
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	dur  time.Duration
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// lex tokenizes a TraceQL-like query string. Dotted attribute references
+// (e.g. "span.http.status_code") and reserved field names are both lexed as
+// plain tokIdent tokens; resolveFieldRef (in parser.go) is what tells them
+// apart.
+func lex(input string) ([]token, error) {
+	var toks []token
+	i, n := 0, len(input)
+
+	for i < n {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			toks = append(toks, token{kind: tokLParen})
+			i++
+		case c == ')':
+			toks = append(toks, token{kind: tokRParen})
+			i++
+		case c == '{':
+			toks = append(toks, token{kind: tokLBrace})
+			i++
+		case c == '}':
+			toks = append(toks, token{kind: tokRBrace})
+			i++
+		case c == '|':
+			if i+1 < n && input[i+1] == '|' {
+				toks = append(toks, token{kind: tokOr})
+				i += 2
+			} else {
+				toks = append(toks, token{kind: tokPipe})
+				i++
+			}
+		case c == '&':
+			if i+1 < n && input[i+1] == '&' {
+				toks = append(toks, token{kind: tokAnd})
+				i += 2
+			} else {
+				return nil, fmt.Errorf("unexpected '&' at position %d", i)
+			}
+		case c == '!':
+			if i+1 < n && input[i+1] == '=' {
+				toks = append(toks, token{kind: tokNEQ})
+				i += 2
+			} else {
+				toks = append(toks, token{kind: tokNot})
+				i++
+			}
+		case c == '=':
+			if i+1 < n && input[i+1] == '=' {
+				toks = append(toks, token{kind: tokEQ})
+				i += 2
+			} else {
+				return nil, fmt.Errorf("unexpected '=' at position %d, did you mean '=='?", i)
+			}
+		case c == '>':
+			if i+1 < n && input[i+1] == '=' {
+				toks = append(toks, token{kind: tokGTE})
+				i += 2
+			} else {
+				toks = append(toks, token{kind: tokGT})
+				i++
+			}
+		case c == '<':
+			if i+1 < n && input[i+1] == '=' {
+				toks = append(toks, token{kind: tokLTE})
+				i += 2
+			} else {
+				toks = append(toks, token{kind: tokLT})
+				i++
+			}
+		case c == '"':
+			j := i + 1
+			for j < n && input[j] != '"' {
+				j++
+			}
+			if j >= n {
+				return nil, fmt.Errorf("unterminated string literal at position %d", i)
+			}
+			toks = append(toks, token{kind: tokString, text: input[i+1 : j]})
+			i = j + 1
+		case isDigit(c):
+			j := i
+			for j < n && isDigit(input[j]) {
+				j++
+			}
+			if j < n && input[j] == '.' {
+				j++
+				for j < n && isDigit(input[j]) {
+					j++
+				}
+			}
+			numStr := input[i:j]
+
+			unitEnd := j
+			for unitEnd < n && isLetter(input[unitEnd]) {
+				unitEnd++
+			}
+			unit := input[j:unitEnd]
+
+			if unit != "" {
+				dur, err := time.ParseDuration(numStr + unit)
+				if err != nil {
+					return nil, fmt.Errorf("invalid duration literal %q: %w", numStr+unit, err)
+				}
+				toks = append(toks, token{kind: tokDuration, dur: dur})
+				i = unitEnd
+			} else {
+				val, err := strconv.ParseFloat(numStr, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid number literal %q: %w", numStr, err)
+				}
+				toks = append(toks, token{kind: tokNumber, num: val})
+				i = j
+			}
+		case isLetter(c) || c == '_':
+			j := i
+			for j < n && (isLetter(input[j]) || isDigit(input[j]) || input[j] == '_' || input[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{kind: tokIdent, text: input[i:j]})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+
+	toks = append(toks, token{kind: tokEOF})
+	return toks, nil
+}
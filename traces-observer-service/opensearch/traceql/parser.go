@@ -0,0 +1,275 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// attrNamespace is the prefix an identifier must carry to be read as an
+// attribute path rather than one of the reserved fields (duration, name,
+// status, kind), e.g. "span.http.status_code".
+const attrNamespace = "span."
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+// Parse parses a TraceQL-like query, e.g.
+// "{ span.http.status_code >= 500 && duration > 2s } | count() > 3".
+func Parse(input string) (*Query, error) {
+	toks, err := lex(input)
+	if err != nil {
+		return nil, fmt.Errorf("traceql: %w", err)
+	}
+
+	p := &parser{toks: toks}
+
+	filter, err := p.parseSpansetFilter()
+	if err != nil {
+		return nil, fmt.Errorf("traceql: %w", err)
+	}
+
+	var stages []AggregateStage
+	for p.peek().kind == tokPipe {
+		p.next()
+		stage, err := p.parseAggregateStage()
+		if err != nil {
+			return nil, fmt.Errorf("traceql: %w", err)
+		}
+		stages = append(stages, stage)
+	}
+
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("traceql: unexpected trailing input")
+	}
+
+	return &Query{Filter: *filter, Stages: stages}, nil
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if p.pos < len(p.toks)-1 {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseSpansetFilter() (*SpansetFilter, error) {
+	if p.peek().kind != tokLBrace {
+		return nil, fmt.Errorf("expected '{' to start spanset filter")
+	}
+	p.next()
+
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokRBrace {
+		return nil, fmt.Errorf("expected '}' to close spanset filter")
+	}
+	p.next()
+
+	return &SpansetFilter{Predicate: pred}, nil
+}
+
+func (p *parser) parseOr() (PredicateExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: LogicalOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (PredicateExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: LogicalAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (PredicateExpr, error) {
+	if p.peek().kind == tokNot {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{Operand: operand}, nil
+	}
+	if p.peek().kind == tokLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, fmt.Errorf("expected ')'")
+		}
+		p.next()
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (PredicateExpr, error) {
+	if p.peek().kind != tokIdent {
+		return nil, fmt.Errorf("expected field name")
+	}
+	field, err := resolveFieldRef(p.next().text)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := comparisonOpFromToken(p.next())
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ComparisonExpr{Field: field, Op: op, Value: value}, nil
+}
+
+// resolveFieldRef maps a lexed identifier to either one of the reserved
+// fields (duration, name, status, kind) or a dotted attribute path. An
+// attribute reference must carry the "span." namespace prefix, the marker
+// that sets it apart from the bare reserved field names in the same query.
+func resolveFieldRef(word string) (FieldRef, error) {
+	switch word {
+	case "duration", "name", "status", "kind":
+		return FieldRef{Reserved: word}, nil
+	}
+	if strings.HasPrefix(word, attrNamespace) {
+		attr := strings.TrimPrefix(word, attrNamespace)
+		if attr == "" {
+			return FieldRef{}, fmt.Errorf("empty attribute path after %q", attrNamespace)
+		}
+		return FieldRef{Attribute: attr}, nil
+	}
+	return FieldRef{}, fmt.Errorf("unknown field %q: attribute references must be prefixed with %q", word, attrNamespace)
+}
+
+func comparisonOpFromToken(tok token) (ComparisonOp, error) {
+	switch tok.kind {
+	case tokEQ:
+		return OpEQ, nil
+	case tokNEQ:
+		return OpNEQ, nil
+	case tokGT:
+		return OpGT, nil
+	case tokGTE:
+		return OpGTE, nil
+	case tokLT:
+		return OpLT, nil
+	case tokLTE:
+		return OpLTE, nil
+	}
+	return "", fmt.Errorf("expected a comparison operator")
+}
+
+func (p *parser) parseLiteral() (Literal, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		return Literal{Kind: LiteralNumber, Number: tok.num}, nil
+	case tokDuration:
+		return Literal{Kind: LiteralDuration, Duration: tok.dur}, nil
+	case tokString:
+		return Literal{Kind: LiteralString, Str: tok.text}, nil
+	case tokIdent:
+		// A bare word, e.g. the "error" in "status == error".
+		return Literal{Kind: LiteralString, Str: tok.text}, nil
+	}
+	return Literal{}, fmt.Errorf("expected a literal value")
+}
+
+func (p *parser) parseAggregateStage() (AggregateStage, error) {
+	if p.peek().kind != tokIdent {
+		return AggregateStage{}, fmt.Errorf("expected aggregate function name")
+	}
+	fnTok := p.next()
+	fn := AggregateFunc(fnTok.text)
+	switch fn {
+	case AggregateCount, AggregateSum, AggregateAvg, AggregateMax, AggregateMin:
+	default:
+		return AggregateStage{}, fmt.Errorf("unknown aggregate function %q", fnTok.text)
+	}
+
+	if p.peek().kind != tokLParen {
+		return AggregateStage{}, fmt.Errorf("expected '(' after %q", fn)
+	}
+	p.next()
+
+	var field FieldRef
+	if p.peek().kind != tokRParen {
+		if p.peek().kind != tokIdent {
+			return AggregateStage{}, fmt.Errorf("expected field name inside %q(...)", fn)
+		}
+		f, err := resolveFieldRef(p.next().text)
+		if err != nil {
+			return AggregateStage{}, err
+		}
+		field = f
+	} else if fn != AggregateCount {
+		return AggregateStage{}, fmt.Errorf("%q requires a field argument", fn)
+	}
+
+	if p.peek().kind != tokRParen {
+		return AggregateStage{}, fmt.Errorf("expected ')' to close %q(...)", fn)
+	}
+	p.next()
+
+	op, err := comparisonOpFromToken(p.next())
+	if err != nil {
+		return AggregateStage{}, err
+	}
+
+	value, err := p.parseLiteral()
+	if err != nil {
+		return AggregateStage{}, err
+	}
+
+	return AggregateStage{Func: fn, Field: field, Op: op, Value: value}, nil
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package opensearch
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts/stscreds"
+	opensearch "github.com/opensearch-project/opensearch-go"
+	requestsigner "github.com/opensearch-project/opensearch-go/signer/awsv2"
+	"github.com/wso2/ai-agent-management-platform/traces-observer-service/config"
+)
+
+// AuthMode selects which TransportProvider TransportProviderFromConfig
+// builds. Basic auth remains the default so existing deployments (and every
+// call site that just does NewClient(cfg)) are unaffected.
+type AuthMode string
+
+const (
+	AuthModeBasic  AuthMode = "basic"
+	AuthModeSigV4  AuthMode = "sigv4"
+	AuthModeMTLS   AuthMode = "mtls"
+	AuthModeAPIKey AuthMode = "apikey"
+)
+
+// TransportProvider configures an opensearch.Config for a particular
+// authentication scheme. Apply is called once, from NewClient, against a
+// Config that already has Addresses set; it fills in whatever combination of
+// Transport/Username/Password/Signer the scheme needs.
+type TransportProvider interface {
+	Apply(cfg *opensearch.Config) error
+}
+
+// tlsTransport builds an *http.Transport honoring cfg.TLSInsecureSkipVerify
+// and, when set, a custom CA bundle. Verification is opt-out only: the zero
+// value verifies against the system root pool.
+func tlsTransport(cfg *config.OpenSearchConfig) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+	}
+
+	if cfg.CABundlePath != "" {
+		pem, err := os.ReadFile(cfg.CABundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA bundle %q", cfg.CABundlePath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &http.Transport{TLSClientConfig: tlsConfig}, nil
+}
+
+// basicAuthProvider is the original behavior: a plain HTTP transport plus
+// username/password basic auth.
+type basicAuthProvider struct {
+	cfg *config.OpenSearchConfig
+}
+
+func (p *basicAuthProvider) Apply(osCfg *opensearch.Config) error {
+	transport, err := tlsTransport(p.cfg)
+	if err != nil {
+		return err
+	}
+	osCfg.Transport = transport
+	osCfg.Username = p.cfg.Username
+	osCfg.Password = p.cfg.Password
+	return nil
+}
+
+// sigV4Provider signs every request with AWS SigV4 using aws-sdk-go-v2's
+// default credentials chain (env vars, shared config, instance/task roles),
+// for use against Amazon OpenSearch Service.
+type sigV4Provider struct {
+	cfg *config.OpenSearchConfig
+}
+
+func (p *sigV4Provider) Apply(osCfg *opensearch.Config) error {
+	transport, err := tlsTransport(p.cfg)
+	if err != nil {
+		return err
+	}
+	osCfg.Transport = transport
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(
+		awsconfig.WithRegion(p.cfg.AWSRegion),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	if p.cfg.AWSRoleARN != "" {
+		stsClient := sts.NewFromConfig(awsCfg)
+		awsCfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, p.cfg.AWSRoleARN))
+	}
+
+	signer, err := requestsigner.NewSignerWithService(awsCfg, "es")
+	if err != nil {
+		return fmt.Errorf("failed to build SigV4 signer: %w", err)
+	}
+	osCfg.Signer = signer
+	return nil
+}
+
+// mTLSProvider authenticates with a client certificate instead of a
+// username/password, for clusters gated on mutual TLS.
+type mTLSProvider struct {
+	cfg *config.OpenSearchConfig
+}
+
+func (p *mTLSProvider) Apply(osCfg *opensearch.Config) error {
+	transport, err := tlsTransport(p.cfg)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(p.cfg.ClientCertPath, p.cfg.ClientKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+
+	osCfg.Transport = transport
+	return nil
+}
+
+// apiKeyProvider sends OpenSearch's `Authorization: ApiKey <key>` header
+// instead of basic auth, for clusters fronted by OpenSearch's API key
+// authentication backend.
+type apiKeyProvider struct {
+	cfg *config.OpenSearchConfig
+}
+
+func (p *apiKeyProvider) Apply(osCfg *opensearch.Config) error {
+	transport, err := tlsTransport(p.cfg)
+	if err != nil {
+		return err
+	}
+	osCfg.Transport = transport
+	osCfg.Header = http.Header{
+		"Authorization": []string{"ApiKey " + p.cfg.APIKey},
+	}
+	return nil
+}
+
+// TransportProviderFromConfig selects a TransportProvider based on
+// cfg.AuthMode, defaulting to basic auth when unset so existing
+// configuration keeps working without a migration. Callers outside this
+// package never need to touch this directly - NewClient(cfg) wires it in.
+func TransportProviderFromConfig(cfg *config.OpenSearchConfig) (TransportProvider, error) {
+	switch AuthMode(cfg.AuthMode) {
+	case "", AuthModeBasic:
+		return &basicAuthProvider{cfg: cfg}, nil
+	case AuthModeSigV4:
+		return &sigV4Provider{cfg: cfg}, nil
+	case AuthModeMTLS:
+		return &mTLSProvider{cfg: cfg}, nil
+	case AuthModeAPIKey:
+		return &apiKeyProvider{cfg: cfg}, nil
+	default:
+		return nil, fmt.Errorf("unknown opensearch auth mode: %q", cfg.AuthMode)
+	}
+}
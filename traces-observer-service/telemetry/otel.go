@@ -0,0 +1,74 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package telemetry bootstraps this service's own OpenTelemetry tracer
+// provider, so the traces observer can dogfood the same OTLP pipeline it
+// serves to callers (see controllers.NewTracingController, which consumes
+// the trace.TracerProvider this package builds).
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// Config configures InitTracerProvider. Read from this service's config
+// file alongside opensearch.ClientConfig.
+type Config struct {
+	// ServiceName identifies this process's spans to the OTLP backend.
+	ServiceName string
+	// OTLPEndpoint is the OTLP/gRPC collector address, e.g. "localhost:4317".
+	// Empty disables the exporter: InitTracerProvider still returns a
+	// working TracerProvider, it just has no span processor attached.
+	OTLPEndpoint string
+}
+
+// InitTracerProvider builds the sdktrace.TracerProvider that
+// controllers.NewTracingController's tracerProvider argument expects,
+// exporting spans to cfg.OTLPEndpoint over OTLP/gRPC. The returned shutdown
+// func flushes and closes the exporter; callers should defer it from main.
+func InitTracerProvider(ctx context.Context, cfg Config) (*sdktrace.TracerProvider, func(context.Context) error, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build resource: %w", err)
+	}
+
+	if cfg.OTLPEndpoint == "" {
+		tp := sdktrace.NewTracerProvider(sdktrace.WithResource(res))
+		return tp, func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exporter),
+	)
+	return tp, tp.Shutdown, nil
+}